@@ -23,8 +23,5 @@ import (
 )
 
 func main() {
-	rootCmd := cmd.NewRootCommand()
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
-	}
+	os.Exit(cmd.Execute(os.Stderr))
 }
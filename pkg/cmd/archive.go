@@ -0,0 +1,207 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/output"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/redact"
+	"github.com/spf13/cobra"
+)
+
+// manifestsAll excludes every manifest category
+const manifestsAll = "all"
+
+// Values of --archive
+const (
+	archiveDefault = "true" // A bare --archive, written to the archive directory
+	archiveStdout  = "-"    // Streamed to stdout
+)
+
+// archiveOptions holds the flags configuring the diagnostic archive
+type archiveOptions struct {
+	destination      string
+	dir              string
+	name             string
+	format           string
+	encryptTo        []string
+	excludeManifests []string
+}
+
+func (o *archiveOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.destination, "archive", "",
+		"Generate a diagnostic archive; --archive - streams it to stdout")
+	cmd.Flags().Lookup("archive").NoOptDefVal = archiveDefault
+	cmd.Flags().StringVar(&o.dir, "archive-dir", "", "Directory the archive is written to (default the current directory)")
+	cmd.Flags().StringVar(&o.name, "archive-name", "",
+		"Name of the archive (default fluid-diagnose-<dataset>-<timestamp>)")
+	cmd.Flags().StringVar(&o.format, "archive-format", "",
+		fmt.Sprintf("Format of the archive: %s (default %s)", strings.Join(output.ArchiveFormats, ", "), output.ArchiveFormatTarGz))
+	cmd.Flags().StringArrayVar(&o.encryptTo, "encrypt-to", nil,
+		"File of age public keys (age1...) the archive is encrypted to, one per line (repeatable)")
+	cmd.Flags().StringSliceVar(&o.excludeManifests, "exclude-manifests", nil,
+		fmt.Sprintf("Object manifests left out of the archive: %s, or %s",
+			strings.Join(diagnose.ManifestCategories, ", "), manifestsAll))
+}
+
+// enabled reports whether an archive is requested
+func (o *archiveOptions) enabled() bool {
+	return o.destination != "" && o.destination != "false"
+}
+
+// toStdout reports whether the archive is streamed to stdout
+func (o *archiveOptions) toStdout() bool {
+	return o.destination == archiveStdout
+}
+
+// args validates the positional arguments of a command taking a single one. pflag leaves
+// the "-" of "--archive -" as an argument, before or after the other one; it is taken as
+// the stdout destination. No Dataset can be named "-".
+func (o *archiveOptions) args(cmd *cobra.Command, args []string) error {
+	if len(args) == 2 && o.destination == archiveDefault {
+		for i, arg := range args {
+			if arg == archiveStdout {
+				o.destination = archiveStdout
+				args = []string{args[1-i]}
+				break
+			}
+		}
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// datasetArg returns the positional argument accepted by args, leaving out the "-" of
+// "--archive -"
+func (o *archiveOptions) datasetArg(args []string) string {
+	if len(args) == 2 && args[0] == archiveStdout {
+		return args[1]
+	}
+	return args[0]
+}
+
+// buildArchiver returns the archiver configured by the config file and flags
+func (o *archiveOptions) buildArchiver(cmd *cobra.Command, redaction *redact.Policy) (*output.Archiver, error) {
+	switch o.destination {
+	case "", "false", archiveDefault, archiveStdout:
+	default:
+		return nil, fmt.Errorf("invalid --archive value %q: use --archive-dir and --archive-name to place the archive, or - for stdout", o.destination)
+	}
+
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, format := cfg.Archive.Dir, cfg.Archive.Format
+	if cmd.Flags().Changed("archive-dir") {
+		dir = o.dir
+	}
+	if cmd.Flags().Changed("archive-format") {
+		format = o.format
+	}
+	if format == "" {
+		format = output.ArchiveFormatTarGz
+	}
+	if err := output.ValidateArchiveFormat(format); err != nil {
+		return nil, err
+	}
+	if o.toStdout() {
+		if format == output.ArchiveFormatDir {
+			return nil, fmt.Errorf("a %s archive cannot be streamed to stdout", format)
+		}
+		if o.name != "" || cmd.Flags().Changed("archive-dir") {
+			return nil, fmt.Errorf("--archive-dir and --archive-name do not apply to an archive streamed to stdout")
+		}
+	}
+
+	archiver := output.NewArchiver().WithFormat(format).WithName(o.name).WithRedaction(redaction)
+	if dir != "" {
+		archiver.WithOutputDir(dir)
+	}
+
+	if files := append(append([]string(nil), cfg.Archive.EncryptTo...), o.encryptTo...); len(files) > 0 {
+		if format == output.ArchiveFormatDir {
+			return nil, fmt.Errorf("a %s archive cannot be encrypted, use %s or %s",
+				format, output.ArchiveFormatTarGz, output.ArchiveFormatZip)
+		}
+		recipients, err := output.LoadRecipients(files)
+		if err != nil {
+			return nil, err
+		}
+		archiver.WithEncryption(recipients...)
+	}
+	return archiver, nil
+}
+
+// manifestCategories returns the manifest categories archived, those not excluded by the
+// config file or flags
+func (o *archiveOptions) manifestCategories(cmd *cobra.Command) ([]string, error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := map[string]bool{}
+	for _, category := range append(append([]string(nil), cfg.Archive.ExcludeManifests...), o.excludeManifests...) {
+		if category == manifestsAll {
+			return nil, nil
+		}
+		if err := diagnose.ValidateManifestCategories([]string{category}); err != nil {
+			return nil, err
+		}
+		excluded[category] = true
+	}
+
+	var categories []string
+	for _, category := range diagnose.ManifestCategories {
+		if !excluded[category] {
+			categories = append(categories, category)
+		}
+	}
+	return categories, nil
+}
+
+// identityOptions holds the flags giving the keys that decrypt archives
+type identityOptions struct {
+	files []string
+}
+
+func (o *identityOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&o.files, "identity", "i", nil,
+		"File of age secret keys (AGE-SECRET-KEY-1...) decrypting an encrypted archive (repeatable)")
+}
+
+// load reads the identities of the files, none when no file is given
+func (o *identityOptions) load() ([]age.Identity, error) {
+	if len(o.files) == 0 {
+		return nil, nil
+	}
+	return output.LoadIdentities(o.files)
+}
+
+// archiveReadError points at --identity when an archive is encrypted
+func archiveReadError(err error) error {
+	if errors.Is(err, output.ErrArchiveEncrypted) {
+		return fmt.Errorf("%w, pass the identity file with --identity", err)
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestArchiveStdoutArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		destination string
+		wantErr     bool
+	}{
+		{name: "dash after the dataset", args: []string{"demo", "--archive", "-"}, destination: archiveStdout},
+		{name: "dash before the dataset", args: []string{"--archive", "-", "demo"}, destination: archiveStdout},
+		{name: "explicit value", args: []string{"--archive=-", "demo"}, destination: archiveStdout},
+		{name: "bare archive", args: []string{"demo", "--archive"}, destination: archiveDefault},
+		{name: "dash without archive", args: []string{"demo", "-"}, wantErr: true},
+		{name: "two datasets", args: []string{"--archive", "demo", "other"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &archiveOptions{}
+			var dataset string
+			cmd := &cobra.Command{
+				Use:  "dataset",
+				Args: opts.args,
+				RunE: func(cmd *cobra.Command, args []string) error {
+					dataset = opts.datasetArg(args)
+					return nil
+				},
+				SilenceErrors: true,
+				SilenceUsage:  true,
+			}
+			opts.addFlags(cmd)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dataset != "demo" || opts.destination != tt.destination {
+				t.Errorf("dataset = %q, destination = %q, want demo and %q", dataset, opts.destination, tt.destination)
+			}
+		})
+	}
+}
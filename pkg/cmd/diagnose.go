@@ -33,8 +33,9 @@ This command automates the manual workflow of running multiple kubectl commands
 (get, describe, logs) and correlating the results.`,
 	}
 
-	// Add dataset subcommand
+	// Add subcommands
 	cmd.AddCommand(NewDiagnoseDatasetCommand())
+	cmd.AddCommand(NewDiagnoseArchiveCommand())
 
 	return cmd
 }
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/output"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/redact"
+	"github.com/spf13/cobra"
+)
+
+type diagnoseArchiveOptions struct {
+	outputFmt string
+	rules     ruleOptions
+	redact    redactOptions
+	identity  identityOptions
+}
+
+// NewDiagnoseArchiveCommand creates the 'diagnose archive' subcommand
+func NewDiagnoseArchiveCommand() *cobra.Command {
+	opts := &diagnoseArchiveOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "archive <archive>",
+		Short: "Re-analyze a diagnostic archive offline",
+		Long: `Load a diagnostic archive created by 'diagnose dataset --archive' and
+re-run failure analysis on its contents. The archive is a .tar.gz or .zip
+file, or a directory written with --archive-format dir. Encrypted archives
+(.age) are decrypted in memory with --identity.
+
+No Kubernetes cluster is required. Analysis uses the rules of this version of
+the tool, so archives created by older versions are re-evaluated with any newly
+added checks.
+
+Secrets are masked again with the current redaction rules and any
+--redact-pattern, so archives created by older versions are cleaned too.`,
+		Example: `  # Re-analyze an archive received from a user
+  kubectl fluid diagnose archive fluid-diagnose-demo-data-20260208-003045.tar.gz
+
+  # Export the re-analyzed archive as AI-ready JSON
+  kubectl fluid diagnose archive fluid-diagnose-demo-data-20260208-003045.tar.gz -o json
+
+  # Re-analyze an encrypted archive
+  kubectl fluid diagnose archive fluid-diagnose-demo-data-20260208-003045.tar.gz.age --identity key.txt
+
+  # Check that no file of the archive was altered
+  kubectl fluid diagnose archive verify fluid-diagnose-demo-data-20260208-003045.tar.gz`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiagnoseArchive(cmd, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.outputFmt, "output", "o", "text", "Output format: text, json")
+	opts.rules.addFlags(cmd)
+	opts.redact.addFlags(cmd)
+	opts.identity.addFlags(cmd)
+
+	cmd.AddCommand(NewDiagnoseArchiveVerifyCommand())
+	cmd.AddCommand(NewDiagnoseArchiveDecryptCommand())
+
+	return cmd
+}
+
+func runDiagnoseArchive(cmd *cobra.Command, path string, opts *diagnoseArchiveOptions) error {
+	rules, err := opts.rules.buildRegistry(cmd)
+	if err != nil {
+		return err
+	}
+
+	redaction, err := opts.redact.buildPolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	identities, err := opts.identity.load()
+	if err != nil {
+		return err
+	}
+
+	result, err := output.ReadArchive(path, identities...)
+	if err != nil {
+		return fmt.Errorf("failed to load archive: %w", archiveReadError(err))
+	}
+
+	redactor := redaction.NewRedactor()
+	redactor.Result(result)
+	result.Redaction = redact.Merge(result.Redaction, redactor.Report())
+
+	diagnose.Analyze(result, rules)
+
+	switch opts.outputFmt {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diagnose.ToContext(result)); err != nil {
+			return err
+		}
+	case "text":
+		fallthrough
+	default:
+		printer := output.NewDiagnosticPrinter(os.Stdout)
+		printer.Print(result)
+	}
+
+	return healthExit(exitCodeForHealth(result.HealthStatus))
+}
+
+// NewDiagnoseArchiveVerifyCommand creates the 'diagnose archive verify' subcommand
+func NewDiagnoseArchiveVerifyCommand() *cobra.Command {
+	var outputFmt string
+	var identity identityOptions
+
+	cmd := &cobra.Command{
+		Use:   "verify <archive>",
+		Short: "Check a diagnostic archive against the checksums of its manifest",
+		Long: `Check every file of a diagnostic archive against the SHA-256 recorded in its
+manifest.json, and report files that were modified, removed or added since the
+archive was written. The tool version, cluster version and kube context the
+archive was collected with are printed alongside.
+
+Exits with 2 when a file does not match. Archives written before checksums were
+recorded (schema version 1) cannot be verified.`,
+		Example: `  kubectl fluid diagnose archive verify fluid-diagnose-demo-data-20260208-003045.tar.gz`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			identities, err := identity.load()
+			if err != nil {
+				return err
+			}
+			verification, err := output.VerifyArchive(args[0], identities...)
+			if err != nil {
+				return fmt.Errorf("failed to verify archive: %w", archiveReadError(err))
+			}
+
+			switch outputFmt {
+			case "json":
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(verification); err != nil {
+					return err
+				}
+			default:
+				output.NewDiagnosticPrinter(os.Stdout).PrintArchiveVerification(verification)
+			}
+
+			if len(verification.Problems()) > 0 {
+				return healthExit(ExitFailed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFmt, "output", "o", "text", "Output format: text, json")
+	identity.addFlags(cmd)
+
+	return cmd
+}
+
+// NewDiagnoseArchiveDecryptCommand creates the 'diagnose archive decrypt' subcommand
+func NewDiagnoseArchiveDecryptCommand() *cobra.Command {
+	var identity identityOptions
+
+	cmd := &cobra.Command{
+		Use:   "decrypt <archive.age>",
+		Short: "Write the plaintext of an encrypted diagnostic archive to stdout",
+		Long: `Decrypt an archive created with 'diagnose dataset --archive --encrypt-to' and
+write the plaintext tar.gz or zip archive to stdout.
+
+'diagnose archive' and 'diagnose archive verify' read encrypted archives
+directly with --identity, in memory; decrypt only when the files themselves are
+needed.`,
+		Example: `  # Extract an encrypted archive
+  kubectl fluid diagnose archive decrypt fluid-diagnose-demo-data-20260208-003045.tar.gz.age -i key.txt | tar xz`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			identities, err := identity.load()
+			if err != nil {
+				return err
+			}
+			return output.DecryptArchive(os.Stdout, args[0], identities)
+		},
+	}
+
+	identity.addFlags(cmd)
+	_ = cmd.MarkFlagRequired("identity")
+
+	return cmd
+}
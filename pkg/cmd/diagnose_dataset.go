@@ -17,10 +17,10 @@ limitations under the License.
 package cmd
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose"
 	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose/mock"
@@ -30,12 +30,21 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// defaultStepTimeout bounds each collection step of a diagnosis
+const defaultStepTimeout = 30 * time.Second
+
 type diagnoseDatasetOptions struct {
-	namespace  string
-	kubeconfig string
-	archive    bool
-	outputFmt  string
-	mockMode   bool
+	namespace      string
+	kubeconfig     string
+	outputFmt      string
+	mockMode       bool
+	stepTimeout    time.Duration
+	fluidNamespace string
+	checkPerms     bool
+	rules          ruleOptions
+	logs           logOptions
+	redact         redactOptions
+	archiveOpts    archiveOptions
 }
 
 // NewDiagnoseDatasetCommand creates the 'diagnose dataset' subcommand
@@ -53,9 +62,35 @@ This command automates the manual workflow of running multiple kubectl commands
 
 The diagnostic pipeline collects:
   1. Dataset and Runtime CR snapshots (cleaned YAML)
-  2. Kubernetes events related to the Dataset
-  3. Runtime resource status (StatefulSets, DaemonSet, PVC)
-  4. Container logs (master, worker, failing fuse pods)
+  2. The objects backing the runtime, found through owner references and
+     Fluid dataset labels
+  3. Kubernetes events related to the Dataset and those objects
+  4. Runtime resource status (workloads, pods, PVC)
+  5. Application pods mounting the Dataset PVC, their mount events and
+     whether a ready fuse pod runs on their node
+  6. The nodes running those pods: readiness, pressure, free resources and
+     whether fuse and worker pods can be scheduled there
+  7. The Fluid controllers, webhook, CSI nodeplugin and CSIDriver, whether a
+     ready CSI nodeplugin runs on every application node, and the lines of
+     their logs mentioning the Dataset (see --fluid-namespace)
+  8. Container logs of a sample of the runtime pods, failing and most
+     restarted pods first (see --tail, --max-pods and --all-pods)
+
+Before collecting, the permissions of the current user are reviewed with
+SelfSubjectAccessReviews. A step denied a permission it requires is skipped,
+and every step records the denied permissions it uses. --check-permissions
+prints only that review.
+
+With --archive, the cleaned and redacted YAML of the objects backing the
+Dataset is also saved under manifests/: workloads, runtime pods, configmaps,
+services, the PVC and PV, the evaluated nodes and the CSIDriver. Leave
+categories out with --exclude-manifests.
+
+Credentials are masked in the CR snapshots, events and logs before they are
+analyzed, printed or archived: sensitive CR fields such as mount options,
+encryptOptions and env values, URL passwords, bearer tokens, access keys and
+key=value pairs naming a secret. Only the value is replaced with [REDACTED].
+Add patterns with --redact-pattern and exempt values with --redact-allow.
 
 The output includes automatic failure analysis with hints and suggestions.
 
@@ -74,9 +109,36 @@ MOCK MODE:
   # Generate a diagnostic archive for sharing
   kubectl fluid diagnose dataset demo-data --archive
 
+  # Write a zip archive under a chosen directory and name
+  kubectl fluid diagnose dataset demo-data --archive --archive-dir /tmp/cases --archive-name case-1234 --archive-format zip
+
+  # Stream the archive to stdout
+  kubectl fluid diagnose dataset demo-data --archive - > demo-data.tar.gz
+
+  # Encrypt the archive to a vendor's age public key
+  kubectl fluid diagnose dataset demo-data --archive --encrypt-to vendor.pub
+
+  # Archive without the node and pod manifests
+  kubectl fluid diagnose dataset demo-data --archive --exclude-manifests nodes,pods
+
   # Diagnose in a specific namespace
   kubectl fluid diagnose dataset demo-data -n fluid-system
 
+  # Skip a rule and escalate another
+  kubectl fluid diagnose dataset demo-data --disable-rule high-restart-count --rule-severity fuse-not-ready=critical
+
+  # Collect the last hour of logs from every runtime pod
+  kubectl fluid diagnose dataset demo-data --all-pods --since 1h --tail -1
+
+  # Sample more workers, starting with those on the application's node
+  kubectl fluid diagnose dataset demo-data --max-pods worker=4 --prefer-node node-1
+
+  # Check that the current user may read everything the diagnosis needs
+  kubectl fluid diagnose dataset demo-data --check-permissions
+
+  # Also mask internal hostnames, but keep the public endpoint readable
+  kubectl fluid diagnose dataset demo-data --archive --redact-pattern 'host=(?P<value>[\w.-]+\.corp)' --redact-allow 'oss-cn-hangzhou\.aliyuncs\.com'
+
   # Run with mock data (no cluster required)
   kubectl fluid diagnose dataset demo-data --mock
 
@@ -85,29 +147,74 @@ MOCK MODE:
 
   # Export mock JSON for AI testing
   kubectl fluid diagnose dataset demo-data --mock -o json`,
-		Args: cobra.ExactArgs(1),
+		Args: opts.archiveOpts.args,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDiagnoseDataset(args[0], opts)
+			return runDiagnoseDataset(cmd, opts.archiveOpts.datasetArg(args), opts)
 		},
 	}
 
 	// Add flags
 	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default", "The namespace of the dataset")
 	cmd.Flags().StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file")
-	cmd.Flags().BoolVar(&opts.archive, "archive", false, "Generate a diagnostic archive (.tar.gz)")
 	cmd.Flags().StringVarP(&opts.outputFmt, "output", "o", "text", "Output format: text, json")
 	cmd.Flags().BoolVar(&opts.mockMode, "mock", false, "Use mock data (no Kubernetes cluster required, for demos/development)")
+	cmd.Flags().DurationVar(&opts.stepTimeout, "step-timeout", defaultStepTimeout,
+		"Maximum duration of each collection step; a step that times out is reported as partial (0 for no limit)")
+	cmd.Flags().StringVar(&opts.fluidNamespace, "fluid-namespace", k8s.FluidNamespace,
+		"The namespace of the Fluid controllers, webhook and CSI plugin")
+	cmd.Flags().BoolVar(&opts.checkPerms, "check-permissions", false,
+		"Only review the permissions the diagnosis needs and print the report")
+	opts.rules.addFlags(cmd)
+	opts.logs.addFlags(cmd)
+	opts.redact.addFlags(cmd)
+	opts.archiveOpts.addFlags(cmd)
 
 	return cmd
 }
 
-func runDiagnoseDataset(name string, opts *diagnoseDatasetOptions) error {
+func runDiagnoseDataset(cmd *cobra.Command, name string, opts *diagnoseDatasetOptions) error {
+	if opts.checkPerms {
+		return runCheckPermissions(cmd, name, opts)
+	}
+
+	rules, err := opts.rules.buildRegistry(cmd)
+	if err != nil {
+		return err
+	}
+
+	logPolicy, err := opts.logs.buildPolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	redaction, err := opts.redact.buildPolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	// The full manifests of the objects are only collected for archives
+	var manifests []string
+	var archiver *output.Archiver
+	if opts.archiveOpts.enabled() {
+		if archiver, err = opts.archiveOpts.buildArchiver(cmd, redaction); err != nil {
+			return err
+		}
+		if manifests, err = opts.archiveOpts.manifestCategories(cmd); err != nil {
+			return err
+		}
+	} else if cmd.Flags().Changed("encrypt-to") {
+		return fmt.Errorf("--encrypt-to requires --archive")
+	}
+
 	var result *types.DiagnosticResult
 	var ctx *types.DiagnosticContext
 
 	if opts.mockMode {
 		// Mock mode: use simulated data, no K8s client needed
 		result = mock.MockDiagnosticResult(name, opts.namespace)
+		result.Manifests = diagnose.SelectManifests(result.Manifests, manifests)
+		result.Collection.ToolVersion = Version
+		result.Collection.Options.Manifests = manifests
 		ctx = mock.MockDiagnosticContext(name, opts.namespace)
 	} else {
 		// Real mode: connect to Kubernetes
@@ -116,28 +223,41 @@ func runDiagnoseDataset(name string, opts *diagnoseDatasetOptions) error {
 			return fmt.Errorf("failed to create Kubernetes client: %w", err)
 		}
 
-		diagnoser := diagnose.NewDatasetDiagnoser(client)
-		result, err = diagnoser.Diagnose(context.Background(), opts.namespace, name)
+		callCtx, cancel := commandContext(cmd)
+		defer cancel()
+
+		diagnoser := diagnose.NewDatasetDiagnoser(client).WithRules(rules).WithStepTimeout(opts.stepTimeout).
+			WithLogPolicy(logPolicy).WithFluidNamespace(opts.fluidNamespace).WithRedaction(redaction).
+			WithManifests(manifests)
+		result, err = diagnoser.Diagnose(callCtx, opts.namespace, name)
 		if err != nil {
 			return fmt.Errorf("failed to diagnose dataset: %w", err)
 		}
+		result.Collection.ToolVersion = Version
+		result.Collection.KubeContext = client.KubeContext()
 		ctx = diagnoser.ToContext(result)
 	}
 
 	// Handle output based on flags
-	if opts.archive {
-		// Generate archive
-		archiver := output.NewArchiver()
+	if archiver != nil {
+		label := "Diagnostic archive"
+		if opts.mockMode {
+			label = "Mock diagnostic archive"
+		}
+		if opts.archiveOpts.toStdout() {
+			// stdout carries the archive, so the status goes to stderr
+			if err := archiver.WriteArchive(os.Stdout, result); err != nil {
+				return fmt.Errorf("failed to create archive: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "✅ %s written to stdout\n", label)
+			return healthExit(exitCodeForHealth(result.HealthStatus))
+		}
 		archivePath, err := archiver.CreateArchive(result)
 		if err != nil {
 			return fmt.Errorf("failed to create archive: %w", err)
 		}
-		if opts.mockMode {
-			fmt.Printf("✅ Mock diagnostic archive created: %s\n", archivePath)
-		} else {
-			fmt.Printf("✅ Diagnostic archive created: %s\n", archivePath)
-		}
-		return nil
+		fmt.Printf("✅ %s created: %s\n", label, archivePath)
+		return healthExit(exitCodeForHealth(result.HealthStatus))
 	}
 
 	switch opts.outputFmt {
@@ -145,7 +265,9 @@ func runDiagnoseDataset(name string, opts *diagnoseDatasetOptions) error {
 		// Output AI-ready context as JSON
 		encoder := json.NewEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
-		return encoder.Encode(ctx)
+		if err := encoder.Encode(ctx); err != nil {
+			return err
+		}
 	case "text":
 		fallthrough
 	default:
@@ -154,5 +276,43 @@ func runDiagnoseDataset(name string, opts *diagnoseDatasetOptions) error {
 		printer.Print(result)
 	}
 
+	return healthExit(exitCodeForHealth(result.HealthStatus))
+}
+
+// runCheckPermissions prints the review of the permissions a diagnosis needs. It exits
+// with ExitForbidden when a permission is denied.
+func runCheckPermissions(cmd *cobra.Command, name string, opts *diagnoseDatasetOptions) error {
+	var report *types.PermissionReport
+	if opts.mockMode {
+		report = mock.MockDiagnosticResult(name, opts.namespace).Permissions
+	} else {
+		client, err := k8s.NewClient(opts.kubeconfig)
+		if err != nil {
+			return fmt.Errorf("failed to create Kubernetes client: %w", err)
+		}
+
+		callCtx, cancel := commandContext(cmd)
+		defer cancel()
+
+		report, err = diagnose.NewDatasetDiagnoser(client).WithFluidNamespace(opts.fluidNamespace).CheckPermissions(callCtx, opts.namespace)
+		if err != nil {
+			return fmt.Errorf("failed to check permissions: %w", err)
+		}
+	}
+
+	switch opts.outputFmt {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	default:
+		output.NewDiagnosticPrinter(os.Stdout).PrintPermissions(report)
+	}
+
+	if len(report.Denied()) > 0 {
+		return healthExit(ExitForbidden)
+	}
 	return nil
 }
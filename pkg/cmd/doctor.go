@@ -0,0 +1,113 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/doctor"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/output"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+type doctorOptions struct {
+	kubeconfig     string
+	fluidNamespace string
+	outputFmt      string
+}
+
+// NewDoctorCommand creates the 'doctor' command
+func NewDoctorCommand() *cobra.Command {
+	opts := &doctorOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the Fluid installation of the cluster",
+		Long: `Check the Fluid installation of the cluster and print a pass/warn/fail checklist:
+
+  1. The data.fluid.io CRDs: the served versions and resources
+  2. The Fluid controllers: whether they are ready, run the same version, and
+     manage runtimes whose CRDs are served
+  3. The Fluid webhook: whether its service has a ready endpoint
+  4. The Fluid CSI driver: whether the CSIDriver is registered and the CSI
+     nodeplugin is ready on every node
+  5. The permissions of the current user: whether they cover everything
+     inspect and diagnose read, in all namespaces
+
+The command exits with 0 when every check passes, 1 on a warning and 2 on a failure.`,
+		Example: `  # Check the Fluid installation
+  kubectl fluid doctor
+
+  # Check a Fluid installed in another namespace, as JSON
+  kubectl fluid doctor --fluid-namespace fluid -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file")
+	cmd.Flags().StringVar(&opts.fluidNamespace, "fluid-namespace", k8s.FluidNamespace,
+		"The namespace of the Fluid controllers, webhook and CSI plugin")
+	cmd.Flags().StringVarP(&opts.outputFmt, "output", "o", "text", "Output format: text, json")
+
+	return cmd
+}
+
+func runDoctor(cmd *cobra.Command, opts *doctorOptions) error {
+	client, err := k8s.NewClient(opts.kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	result, err := doctor.NewDoctor(client).WithFluidNamespace(opts.fluidNamespace).Run(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check the Fluid installation: %w", err)
+	}
+
+	switch opts.outputFmt {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	default:
+		output.NewDiagnosticPrinter(os.Stdout).PrintDoctor(result)
+	}
+
+	return healthExit(exitCodeForDoctor(result.Status))
+}
+
+// exitCodeForDoctor maps the outcome of the installation checks to an exit code
+func exitCodeForDoctor(status types.DoctorStatus) int {
+	switch status {
+	case types.DoctorPass:
+		return ExitHealthy
+	case types.DoctorFail:
+		return ExitFailed
+	default:
+		return ExitWarning
+	}
+}
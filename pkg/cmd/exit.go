@@ -0,0 +1,142 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// Exit codes, see "Exit Codes" in README.md
+const (
+	ExitHealthy      = 0 // Dataset is healthy
+	ExitWarning      = 1 // Dataset is degraded or not yet bound
+	ExitFailed       = 2 // Dataset is failed or unhealthy
+	ExitNotFound     = 3 // Dataset not found
+	ExitCommandError = 4 // The command failed (invalid arguments, unreadable files, ...)
+	ExitForbidden    = 5 // Access to the cluster was denied
+	ExitUnreachable  = 6 // The cluster could not be reached
+)
+
+// ExitError carries the exit code of a command. A nil Err means the command
+// succeeded and the code reports the health of the Dataset.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// healthExit returns the error reporting a health exit code, or nil when healthy
+func healthExit(code int) error {
+	if code == ExitHealthy {
+		return nil
+	}
+	return &ExitError{Code: code}
+}
+
+// ExitCode maps an error returned by a command to the process exit code
+func ExitCode(err error) int {
+	var exitErr *ExitError
+	switch {
+	case err == nil:
+		return ExitHealthy
+	case errors.As(err, &exitErr):
+		return exitErr.Code
+	case k8s.IsNotFound(err):
+		return ExitNotFound
+	case k8s.IsForbidden(err):
+		return ExitForbidden
+	case k8s.IsUnreachable(err), errors.Is(err, context.DeadlineExceeded):
+		return ExitUnreachable
+	}
+	return ExitCommandError
+}
+
+// Execute runs the root command, reports any error on stderr and returns the exit code
+func Execute(stderr io.Writer) int {
+	err := NewRootCommand().Execute()
+
+	var exitErr *ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.Err == nil) {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+	}
+	return ExitCode(err)
+}
+
+// exitCodeForHealth maps a diagnosed health status to an exit code
+func exitCodeForHealth(status types.HealthStatus) int {
+	switch status {
+	case types.HealthStatusHealthy:
+		return ExitHealthy
+	case types.HealthStatusUnhealthy:
+		return ExitFailed
+	default:
+		return ExitWarning
+	}
+}
+
+// exitCodeForInspection maps an inspection result to an exit code: a Failed Dataset
+// is failed, and a Dataset that is not Bound, has unready components or resources that
+// could not be read is a warning
+func exitCodeForInspection(result *types.InspectionResult) int {
+	switch result.Dataset.Phase {
+	case "Failed":
+		return ExitFailed
+	case "Bound":
+	default:
+		return ExitWarning
+	}
+
+	res := &result.Resources
+	if (res.MasterStatefulSet != nil && !res.MasterStatefulSet.Healthy) ||
+		(res.WorkerStatefulSet != nil && !res.WorkerStatefulSet.Healthy) ||
+		(res.FuseDaemonSet != nil && !res.FuseDaemonSet.Healthy) ||
+		(res.PVC != nil && res.PVC.Phase != "Bound") {
+		return ExitWarning
+	}
+	// Resources that could not be read are unknown rather than absent
+	if len(result.Warnings) > 0 {
+		return ExitWarning
+	}
+	return ExitHealthy
+}
+
+// exitCodeForInspections returns the exit code of the least healthy inspection result
+func exitCodeForInspections(results []*types.InspectionResult) int {
+	code := ExitHealthy
+	for _, result := range results {
+		if c := exitCodeForInspection(result); c > code {
+			code = c
+		}
+	}
+	return code
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s/fake"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+func TestExitCode(t *testing.T) {
+	// A live not-found error as returned through the diagnoser
+	_, notFound := diagnose.NewDatasetDiagnoser(fake.NewClient()).Diagnose(context.Background(), "default", "missing")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"success", nil, ExitHealthy},
+		{"health warning", healthExit(ExitWarning), ExitWarning},
+		{"dataset not found", fmt.Errorf("failed to diagnose dataset: %w", notFound), ExitNotFound},
+		{"timeout", fmt.Errorf("failed to inspect dataset: %w", context.DeadlineExceeded), ExitUnreachable},
+		{"other error", errors.New("boom"), ExitCommandError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodeForInspection(t *testing.T) {
+	tests := []struct {
+		name   string
+		result types.InspectionResult
+		want   int
+	}{
+		{
+			name:   "bound and healthy",
+			result: types.InspectionResult{Dataset: types.DatasetInfo{Phase: "Bound"}},
+			want:   ExitHealthy,
+		},
+		{
+			name:   "not bound",
+			result: types.InspectionResult{Dataset: types.DatasetInfo{Phase: "NotBound"}},
+			want:   ExitWarning,
+		},
+		{
+			name: "bound with unready workers",
+			result: types.InspectionResult{
+				Dataset:   types.DatasetInfo{Phase: "Bound"},
+				Resources: types.ResourceStatus{WorkerStatefulSet: &types.StatefulSetStatus{Healthy: false}},
+			},
+			want: ExitWarning,
+		},
+		{
+			name: "bound with unreadable resources",
+			result: types.InspectionResult{
+				Dataset:  types.DatasetInfo{Phase: "Bound"},
+				Warnings: []string{"failed to get pvc default/demo: forbidden"},
+			},
+			want: ExitWarning,
+		},
+		{
+			name:   "failed",
+			result: types.InspectionResult{Dataset: types.DatasetInfo{Phase: "Failed"}},
+			want:   ExitFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCodeForInspection(&tt.result); got != tt.want {
+				t.Errorf("exit code = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodeForInspections(t *testing.T) {
+	results := []*types.InspectionResult{
+		{Dataset: types.DatasetInfo{Phase: "Bound"}},
+		{Dataset: types.DatasetInfo{Phase: "NotBound"}},
+	}
+	if got := exitCodeForInspections(results); got != ExitWarning {
+		t.Errorf("exit code = %d, want %d", got, ExitWarning)
+	}
+	if got := exitCodeForInspections(nil); got != ExitHealthy {
+		t.Errorf("exit code for no datasets = %d, want %d", got, ExitHealthy)
+	}
+}
+
+func TestExitCodeForHealth(t *testing.T) {
+	want := map[types.HealthStatus]int{
+		types.HealthStatusHealthy:   ExitHealthy,
+		types.HealthStatusDegraded:  ExitWarning,
+		types.HealthStatusUnhealthy: ExitFailed,
+		types.HealthStatusUnknown:   ExitWarning,
+	}
+	for status, code := range want {
+		if got := exitCodeForHealth(status); got != code {
+			t.Errorf("exitCodeForHealth(%s) = %d, want %d", status, got, code)
+		}
+	}
+}
@@ -29,8 +29,9 @@ func NewInspectCommand() *cobra.Command {
 including their underlying Kubernetes components.`,
 	}
 
-	// Add dataset subcommand
+	// Add dataset subcommands
 	cmd.AddCommand(NewInspectDatasetCommand())
+	cmd.AddCommand(NewInspectDatasetsCommand())
 
 	return cmd
 }
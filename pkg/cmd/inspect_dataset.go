@@ -29,6 +29,7 @@ import (
 type inspectDatasetOptions struct {
 	namespace  string
 	kubeconfig string
+	outputFmt  string
 }
 
 // NewInspectDatasetCommand creates the 'inspect dataset' subcommand
@@ -48,7 +49,10 @@ The command fetches:
 - DaemonSet (fuse)
 - PersistentVolumeClaim
 
-Output includes ready/desired counts and highlights any issues.`,
+Output includes ready/desired counts and highlights any issues.
+
+Use -o json or -o yaml for machine-readable output (schema documented in
+docs/inspect-output.md) and -o wide for a single-line table.`,
 		Example: `  # Inspect a dataset named "demo-data" in the default namespace
   kubectl fluid inspect dataset demo-data
 
@@ -56,37 +60,65 @@ Output includes ready/desired counts and highlights any issues.`,
   kubectl fluid inspect dataset demo-data -n fluid-system
 
   # Inspect using a specific kubeconfig
-  kubectl fluid inspect dataset demo-data --kubeconfig ~/.kube/custom-config`,
+  kubectl fluid inspect dataset demo-data --kubeconfig ~/.kube/custom-config
+
+  # Machine-readable output for scripts
+  kubectl fluid inspect dataset demo-data -o json
+
+  # One-line summary with cache columns
+  kubectl fluid inspect dataset demo-data -o wide`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runInspectDataset(args[0], opts)
+			return runInspectDataset(cmd, args[0], opts)
 		},
 	}
 
 	// Add flags
 	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default", "The namespace of the dataset")
 	cmd.Flags().StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file (defaults to $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVarP(&opts.outputFmt, "output", "o", "text", "Output format: text, json, yaml, wide")
 
 	return cmd
 }
 
-func runInspectDataset(name string, opts *inspectDatasetOptions) error {
+func runInspectDataset(cmd *cobra.Command, name string, opts *inspectDatasetOptions) error {
+	switch opts.outputFmt {
+	case "text", "json", "yaml", "wide":
+	default:
+		return fmt.Errorf("unknown output format %q (must be text, json, yaml or wide)", opts.outputFmt)
+	}
+
 	// Create Kubernetes client
 	client, err := k8s.NewClient(opts.kubeconfig)
 	if err != nil {
 		return fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
 
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
 	// Create inspector and run inspection
 	inspector := inspect.NewDatasetInspector(client)
-	result, err := inspector.Inspect(opts.namespace, name)
+	result, err := inspector.Inspect(ctx, opts.namespace, name)
 	if err != nil {
 		return fmt.Errorf("failed to inspect dataset: %w", err)
 	}
 
 	// Output the result
-	printer := output.NewTextPrinter(os.Stdout)
-	printer.Print(result)
+	switch opts.outputFmt {
+	case "json":
+		err = output.PrintJSON(os.Stdout, result)
+	case "yaml":
+		err = output.PrintYAML(os.Stdout, result)
+	case "wide":
+		err = output.NewTablePrinter(os.Stdout, true).Print(result)
+	default:
+		printer := output.NewTextPrinter(os.Stdout)
+		printer.Print(result)
+	}
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return healthExit(exitCodeForInspection(result))
 }
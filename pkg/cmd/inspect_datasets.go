@@ -0,0 +1,160 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/inspect"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/output"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+type inspectDatasetsOptions struct {
+	namespace     string
+	allNamespaces bool
+	selector      string
+	fieldSelector string
+	sortBy        string
+	kubeconfig    string
+	outputFmt     string
+}
+
+// NewInspectDatasetsCommand creates the 'inspect datasets' subcommand
+func NewInspectDatasetsCommand() *cobra.Command {
+	opts := &inspectDatasetsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "datasets",
+		Short: "List Fluid Datasets with their runtime and cache status",
+		Long: `List every Fluid Dataset in a namespace, or in all namespaces with -A, with its
+phase, runtime type, master/worker/fuse ready counts, PVC phase and cached percentage.
+
+Datasets can be selected by label with -l and filtered with --field-selector,
+which is evaluated on the inspection results and supports the fields:
+  ` + strings.Join(inspect.SelectorFields, ", ") + `
+
+The exit code reports the least healthy listed Dataset, see "Exit Codes" in README.md.`,
+		Example: `  # List datasets in the default namespace
+  kubectl fluid inspect datasets
+
+  # List datasets in all namespaces
+  kubectl fluid inspect datasets -A
+
+  # Only datasets of one team that are not bound
+  kubectl fluid inspect datasets -A -l team=ml --field-selector status.phase!=Bound
+
+  # Least cached datasets first
+  kubectl fluid inspect datasets -n fluid-system --sort-by cached
+
+  # Machine-readable output
+  kubectl fluid inspect datasets -A -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInspectDatasets(cmd, opts)
+		},
+	}
+
+	// Add flags
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default", "The namespace to list datasets in")
+	cmd.Flags().BoolVarP(&opts.allNamespaces, "all-namespaces", "A", false, "List datasets in all namespaces")
+	cmd.Flags().StringVarP(&opts.selector, "selector", "l", "", "Label selector to filter datasets (e.g. team=ml)")
+	cmd.Flags().StringVar(&opts.fieldSelector, "field-selector", "", "Field selector to filter datasets (e.g. status.phase!=Bound)")
+	cmd.Flags().StringVar(&opts.sortBy, "sort-by", inspect.SortByNamespace, "Sort by: "+strings.Join(inspect.SortKeys, ", "))
+	cmd.Flags().StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file (defaults to $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVarP(&opts.outputFmt, "output", "o", "table", "Output format: table, wide, json, yaml")
+
+	return cmd
+}
+
+func runInspectDatasets(cmd *cobra.Command, opts *inspectDatasetsOptions) error {
+	switch opts.outputFmt {
+	case "table", "wide", "json", "yaml":
+	default:
+		return fmt.Errorf("unknown output format %q (must be table, wide, json or yaml)", opts.outputFmt)
+	}
+
+	fieldSelector, err := inspect.ParseFieldSelector(opts.fieldSelector)
+	if err != nil {
+		return err
+	}
+	// Reject an unknown sort key before contacting the cluster
+	if err := inspect.SortResults(nil, opts.sortBy); err != nil {
+		return err
+	}
+
+	namespace := opts.namespace
+	if opts.allNamespaces {
+		namespace = ""
+	}
+
+	// Create Kubernetes client
+	client, err := k8s.NewClient(opts.kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	inspector := inspect.NewDatasetInspector(client)
+	results, inspectErr := inspector.InspectAll(ctx, namespace, opts.selector)
+	if results == nil && inspectErr != nil {
+		return fmt.Errorf("failed to list datasets: %w", inspectErr)
+	}
+
+	filtered := results[:0]
+	for _, result := range results {
+		if inspect.MatchesFieldSelector(result, fieldSelector) {
+			filtered = append(filtered, result)
+		}
+	}
+	if err := inspect.SortResults(filtered, opts.sortBy); err != nil {
+		return err
+	}
+
+	// Output the results
+	switch opts.outputFmt {
+	case "json":
+		err = output.PrintJSON(os.Stdout, &types.InspectionList{Items: filtered})
+	case "yaml":
+		err = output.PrintYAML(os.Stdout, &types.InspectionList{Items: filtered})
+	default:
+		if len(filtered) == 0 {
+			if opts.allNamespaces {
+				fmt.Fprintln(os.Stderr, "No datasets found.")
+			} else {
+				fmt.Fprintf(os.Stderr, "No datasets found in %s namespace.\n", opts.namespace)
+			}
+			return inspectErr
+		}
+		err = output.NewTablePrinter(os.Stdout, opts.outputFmt == "wide").WithNamespace(opts.allNamespaces).Print(filtered...)
+	}
+	if err != nil {
+		return err
+	}
+	// The Datasets that could be inspected are listed; the failed ones fail the command
+	if inspectErr != nil {
+		return inspectErr
+	}
+
+	return healthExit(exitCodeForInspections(filtered))
+}
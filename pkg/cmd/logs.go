@@ -0,0 +1,111 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/config"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose"
+	"github.com/spf13/cobra"
+)
+
+// logOptions holds the flags selecting the logs collected by diagnose
+type logOptions struct {
+	tail        int64
+	since       time.Duration
+	limitBytes  int64
+	allPods     bool
+	maxPods     map[string]int
+	preferNodes []string
+}
+
+func (o *logOptions) addFlags(cmd *cobra.Command) {
+	defaults := diagnose.DefaultLogPolicy()
+	cmd.Flags().Int64Var(&o.tail, "tail", defaults.TailLines,
+		"Lines of recent log to collect per container, -1 for the whole log")
+	cmd.Flags().DurationVar(&o.since, "since", 0,
+		"Only collect log lines newer than a relative duration like 5s, 2m or 3h")
+	cmd.Flags().Int64Var(&o.limitBytes, "limit-bytes", 0,
+		"Maximum bytes of log to collect per container (0 for no limit)")
+	cmd.Flags().BoolVar(&o.allPods, "all-pods", false,
+		"Collect logs from every runtime pod instead of a sample")
+	cmd.Flags().StringToIntVar(&o.maxPods, "max-pods", nil,
+		"Pods sampled per component, e.g. worker=3,fuse=5 (default master=1,worker=2,fuse=2)")
+	cmd.Flags().StringSliceVar(&o.preferNodes, "prefer-node", nil,
+		"Nodes whose pods are sampled first, e.g. the nodes running the application")
+}
+
+// buildPolicy returns the default log policy, configured by the config file, then by flags
+func (o *logOptions) buildPolicy(cmd *cobra.Command) (diagnose.LogPolicy, error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return diagnose.LogPolicy{}, err
+	}
+
+	policy := diagnose.DefaultLogPolicy()
+	if err := configureLogs(&policy, cfg.Logs); err != nil {
+		return diagnose.LogPolicy{}, fmt.Errorf("invalid logs config: %w", err)
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("tail") {
+		policy.TailLines = o.tail
+	}
+	if flags.Changed("since") {
+		policy.Since = o.since
+	}
+	if flags.Changed("limit-bytes") {
+		policy.LimitBytes = o.limitBytes
+	}
+	if flags.Changed("all-pods") {
+		policy.AllPods = o.allPods
+	}
+	for component, n := range o.maxPods {
+		policy.MaxPods[component] = n
+	}
+	policy.PreferNodes = o.preferNodes
+
+	if err := policy.Validate(); err != nil {
+		return diagnose.LogPolicy{}, err
+	}
+	return policy, nil
+}
+
+func configureLogs(policy *diagnose.LogPolicy, cfg config.LogsConfig) error {
+	if cfg.Tail != nil {
+		policy.TailLines = *cfg.Tail
+	}
+	if cfg.Since != "" {
+		since, err := time.ParseDuration(cfg.Since)
+		if err != nil {
+			return fmt.Errorf("invalid since %q: %w", cfg.Since, err)
+		}
+		policy.Since = since
+	}
+	if cfg.LimitBytes != nil {
+		policy.LimitBytes = *cfg.LimitBytes
+	}
+	if cfg.AllPods != nil {
+		policy.AllPods = *cfg.AllPods
+	}
+	for component, n := range cfg.MaxPods {
+		policy.MaxPods[component] = n
+	}
+	return policy.Validate()
+}
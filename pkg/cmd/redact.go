@@ -0,0 +1,54 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/redact"
+	"github.com/spf13/cobra"
+)
+
+// redactOptions holds the flags configuring the masking of secrets
+type redactOptions struct {
+	patterns []string
+	allow    []string
+}
+
+func (o *redactOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&o.patterns, "redact-pattern", nil,
+		`Regular expression of a secret to mask in addition to the built-in rules; only the group named "value" is masked when present`)
+	cmd.Flags().StringArrayVar(&o.allow, "redact-allow", nil,
+		"Regular expression of values that are never masked")
+}
+
+// buildPolicy returns the redaction policy of the config file patterns and flags
+func (o *redactOptions) buildPolicy(cmd *cobra.Command) (*redact.Policy, error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := redact.NewPolicy(redact.Options{
+		Patterns: append(append([]string(nil), cfg.Redact.Patterns...), o.patterns...),
+		Allow:    append(append([]string(nil), cfg.Redact.Allow...), o.allow...),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid redaction settings: %w", err)
+	}
+	return policy, nil
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
@@ -25,6 +28,9 @@ var (
 	Version = "dev"
 )
 
+// defaultTimeout bounds a command talking to the cluster
+const defaultTimeout = 2 * time.Minute
+
 // NewRootCommand creates the root command for kubectl-fluid
 func NewRootCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -39,6 +45,8 @@ state of your Fluid datasets, identify issues, and troubleshoot problems.
 Commands:
   inspect  - Quick status overview of a Dataset and Runtime
   diagnose - Comprehensive debugging with logs, events, and failure analysis
+  rules    - List and configure the diagnostic rules
+  doctor   - Check the Fluid installation and the permissions of the current user
 
 Examples:
   # Quick inspect of a dataset
@@ -51,13 +59,41 @@ Examples:
   kubectl fluid diagnose dataset demo-data --archive
 
   # Export AI-ready diagnostic context
-  kubectl fluid diagnose dataset demo-data --output json`,
+  kubectl fluid diagnose dataset demo-data --output json
+
+  # Check the Fluid installation
+  kubectl fluid doctor`,
 		Version: Version,
+		// Errors are reported by Execute, which also maps them to exit codes
+		SilenceErrors: true,
+		SilenceUsage:  true,
 	}
 
+	cmd.PersistentFlags().String("config", "",
+		"Path to the config file (default $XDG_CONFIG_HOME/kubectl-fluid/config.yaml or ~/.config/kubectl-fluid/config.yaml)")
+	cmd.PersistentFlags().Duration("timeout", defaultTimeout,
+		"Maximum duration of the cluster calls of a command (0 for no limit)")
+
 	// Add subcommands
 	cmd.AddCommand(NewInspectCommand())
 	cmd.AddCommand(NewDiagnoseCommand())
+	cmd.AddCommand(NewRulesCommand())
+	cmd.AddCommand(NewDoctorCommand())
 
 	return cmd
 }
+
+// commandContext returns the context of a command, bounded by the --timeout flag
+func commandContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// The flag is absent when the command is run outside of the root command
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil || timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
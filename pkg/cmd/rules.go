@@ -0,0 +1,283 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/config"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose"
+	"github.com/spf13/cobra"
+)
+
+// ruleOptions holds the rule flags shared by every command that runs analysis
+type ruleOptions struct {
+	rulesDir string
+	disabled []string
+	severity map[string]string
+}
+
+func (o *ruleOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.rulesDir, "rules-dir", "",
+		"Directory of declarative rule files (default rules.dir of the config file, else $XDG_CONFIG_HOME/kubectl-fluid/rules or ~/.config/kubectl-fluid/rules)")
+	cmd.Flags().StringSliceVar(&o.disabled, "disable-rule", nil,
+		"IDs of diagnostic rules to disable (see 'kubectl fluid rules list')")
+	cmd.Flags().StringToStringVar(&o.severity, "rule-severity", nil,
+		"Override rule severities, e.g. worker-not-ready=critical")
+}
+
+// buildRegistry returns the built-in and declarative rules, configured by the config file, then by flags
+func (o *ruleOptions) buildRegistry(cmd *cobra.Command) (*diagnose.RuleRegistry, error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := diagnose.DefaultRuleRegistry()
+
+	dir, explicit := o.resolveRulesDir(cfg)
+	if dir != "" {
+		rules, err := diagnose.LoadRulesDir(dir)
+		if err != nil && (explicit || !errors.Is(err, fs.ErrNotExist)) {
+			return nil, err
+		}
+		for _, rule := range rules {
+			if err := registry.Register(rule); err != nil {
+				return nil, fmt.Errorf("failed to load rules from %s: %w", dir, err)
+			}
+		}
+	}
+
+	if err := configureRules(registry, cfg.Rules.Disabled, cfg.Rules.Severity); err != nil {
+		return nil, fmt.Errorf("invalid rules config: %w", err)
+	}
+	if err := configureRules(registry, o.disabled, o.severity); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// resolveRulesDir returns the rules directory given by --rules-dir, the config file,
+// or the default location, and whether it was explicitly configured
+func (o *ruleOptions) resolveRulesDir(cfg *config.Config) (string, bool) {
+	if o.rulesDir != "" {
+		return o.rulesDir, true
+	}
+	if cfg.Rules.Dir != "" {
+		return cfg.Rules.Dir, true
+	}
+	return config.DefaultRulesDir(), false
+}
+
+func configureRules(registry *diagnose.RuleRegistry, disabled []string, severity map[string]string) error {
+	for _, id := range disabled {
+		if err := registry.Disable(id); err != nil {
+			return err
+		}
+	}
+
+	// Apply overrides in a stable order so the first error reported is deterministic
+	ids := make([]string, 0, len(severity))
+	for id := range severity {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := registry.SetSeverity(id, strings.ToLower(severity[id])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadConfig loads the file given by the persistent --config flag, or the default config file
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	// The flag is absent when the command is run outside of the root command
+	path, _ := cmd.Flags().GetString("config")
+	return config.Load(path)
+}
+
+// NewRulesCommand creates the rules subcommand
+func NewRulesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Manage the diagnostic rules used by diagnose",
+		Long: `Diagnostic rules analyze the collected data and produce the failure hints
+shown by 'diagnose'. Every rule has a stable ID that can be used to disable it
+or override its severity with --disable-rule and --rule-severity, or in the
+rules section of the config file.
+
+Site-specific rules can be declared in YAML files loaded from --rules-dir or
+~/.config/kubectl-fluid/rules/*.yaml.`,
+	}
+
+	cmd.AddCommand(NewRulesListCommand())
+	cmd.AddCommand(NewRulesValidateCommand())
+
+	return cmd
+}
+
+// NewRulesListCommand creates the 'rules list' subcommand
+func NewRulesListCommand() *cobra.Command {
+	opts := &ruleOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the diagnostic rules and their effective configuration",
+		Example: `  # List all rules
+  kubectl fluid rules list
+
+  # Preview the effect of overrides
+  kubectl fluid rules list --disable-rule high-restart-count --rule-severity worker-not-ready=critical`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRulesList(cmd, opts)
+		},
+	}
+
+	opts.addFlags(cmd)
+
+	return cmd
+}
+
+func runRulesList(cmd *cobra.Command, opts *ruleOptions) error {
+	registry, err := opts.buildRegistry(cmd)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSEVERITY\tENABLED\tRUNTIMES\tTITLE")
+	for _, rule := range registry.Rules() {
+		runtimes := "all"
+		if len(rule.RuntimeTypes()) > 0 {
+			runtimes = strings.Join(rule.RuntimeTypes(), ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\n",
+			rule.ID(),
+			registry.EffectiveSeverity(rule),
+			!registry.IsDisabled(rule.ID()),
+			runtimes,
+			rule.Title(),
+		)
+	}
+	return w.Flush()
+}
+
+// NewRulesValidateCommand creates the 'rules validate' subcommand
+func NewRulesValidateCommand() *cobra.Command {
+	opts := &ruleOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate [file|dir]...",
+		Short: "Lint declarative rule files",
+		Long: `Validate declarative rule files: schema, rule IDs, severities, regular
+expressions and hint templates. Rule IDs must not clash with built-in rules or
+with each other.
+
+Without arguments, the rules directory is validated.`,
+		Example: `  # Validate the rules directory
+  kubectl fluid rules validate
+
+  # Validate a rule file before installing it
+  kubectl fluid rules validate ./oss-timeout.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRulesValidate(cmd, args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.rulesDir, "rules-dir", "",
+		"Directory of declarative rule files (default rules.dir of the config file, else $XDG_CONFIG_HOME/kubectl-fluid/rules or ~/.config/kubectl-fluid/rules)")
+
+	return cmd
+}
+
+func runRulesValidate(cmd *cobra.Command, args []string, opts *ruleOptions) error {
+	paths := args
+	if len(paths) == 0 {
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		dir, _ := opts.resolveRulesDir(cfg)
+		paths = []string{dir}
+	}
+
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to read rules: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		dirFiles, err := diagnose.RuleFiles(path)
+		if err != nil {
+			return fmt.Errorf("failed to read rules directory: %w", err)
+		}
+		files = append(files, dirFiles...)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no rule files found in %s", strings.Join(paths, ", "))
+	}
+
+	registry := diagnose.DefaultRuleRegistry()
+	invalid := 0
+	for _, file := range files {
+		if err := validateRuleFile(registry, file); err != nil {
+			invalid++
+			fmt.Printf("✗ %s\n", file)
+			for _, line := range strings.Split(err.Error(), "\n") {
+				fmt.Printf("    %s\n", line)
+			}
+			continue
+		}
+		fmt.Printf("✓ %s\n", file)
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d rule files are invalid", invalid, len(files))
+	}
+	return nil
+}
+
+func validateRuleFile(registry *diagnose.RuleRegistry, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	rules, err := diagnose.ParseRules(data)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, rule := range rules {
+		if err := registry.Register(rule); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the user configuration of the plugin, read from config.yaml
+type Config struct {
+	Rules   RulesConfig   `json:"rules,omitempty"`
+	Logs    LogsConfig    `json:"logs,omitempty"`
+	Redact  RedactConfig  `json:"redact,omitempty"`
+	Archive ArchiveConfig `json:"archive,omitempty"`
+}
+
+// RulesConfig configures the diagnostic rules
+type RulesConfig struct {
+	// Dir is the directory declarative rule files are loaded from
+	Dir string `json:"dir,omitempty"`
+	// Disabled lists the IDs of rules that are not evaluated
+	Disabled []string `json:"disabled,omitempty"`
+	// Severity overrides the severity of rules by ID
+	Severity map[string]string `json:"severity,omitempty"`
+}
+
+// LogsConfig configures the logs collected by diagnose. Unset fields keep the defaults.
+type LogsConfig struct {
+	// Tail is the number of lines kept from the end of each log, -1 for the whole log
+	Tail *int64 `json:"tail,omitempty"`
+	// Since keeps only the log lines newer than this duration, e.g. "1h"
+	Since string `json:"since,omitempty"`
+	// LimitBytes is the maximum size of each log
+	LimitBytes *int64 `json:"limitBytes,omitempty"`
+	// AllPods collects logs from every pod of the runtime
+	AllPods *bool `json:"allPods,omitempty"`
+	// MaxPods is the number of pods sampled per component: master, worker, fuse
+	MaxPods map[string]int `json:"maxPods,omitempty"`
+}
+
+// RedactConfig configures the masking of secrets in diagnose output and archives
+type RedactConfig struct {
+	// Patterns are extra regular expressions of secrets; the group named "value" is masked
+	Patterns []string `json:"patterns,omitempty"`
+	// Allow are regular expressions of values that are never masked
+	Allow []string `json:"allow,omitempty"`
+}
+
+// ArchiveConfig configures the diagnostic archives
+type ArchiveConfig struct {
+	// Dir is the directory archives are written to, the current directory by default
+	Dir string `json:"dir,omitempty"`
+	// Format is the archive format: tar.gz (default), zip or dir
+	Format string `json:"format,omitempty"`
+	// EncryptTo lists files of age public keys every archive is encrypted to
+	EncryptTo []string `json:"encryptTo,omitempty"`
+	// ExcludeManifests lists the manifest categories left out of archives, e.g. nodes
+	ExcludeManifests []string `json:"excludeManifests,omitempty"`
+}
+
+// Dir returns the plugin configuration directory, $XDG_CONFIG_HOME/kubectl-fluid
+// or ~/.config/kubectl-fluid
+func Dir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "kubectl-fluid")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "kubectl-fluid")
+}
+
+// DefaultPath returns the path of the default configuration file
+func DefaultPath() string {
+	dir := Dir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "config.yaml")
+}
+
+// DefaultRulesDir returns the default directory of declarative rule files
+func DefaultRulesDir() string {
+	dir := Dir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "rules")
+}
+
+// Load reads the configuration file at path. An empty path loads the default
+// file, which is optional; an explicitly given file must exist.
+func Load(path string) (*Config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = DefaultPath()
+		if path == "" {
+			return &Config{}, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"context"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// mountEventReasons are the event reasons reporting that a pod's volumes cannot be attached or mounted
+var mountEventReasons = map[string]bool{
+	"FailedMount":        true,
+	"FailedAttachVolume": true,
+	"FailedMapVolume":    true,
+}
+
+// collectApps reports the application pods mounting the Dataset's PVC, their mount
+// failures, and whether a Ready fuse pod runs on their node
+func (d *DatasetDiagnoser) collectApps(ctx context.Context, namespace, name string, result *types.DiagnosticResult) error {
+	claim := name
+	if result.Resources.PVC != nil {
+		claim = result.Resources.PVC.Name
+	}
+	pods, err := d.client.ListPodsMountingPVC(ctx, namespace, claim)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		return nil
+	}
+
+	fuseNodes := map[string]bool{}
+	if fuse := result.Resources.Fuse; fuse != nil {
+		for _, pod := range fuse.Pods {
+			fuseNodes[pod.NodeName] = true
+		}
+	}
+
+	refs := make([]types.ResourceRef, 0, len(pods))
+	for _, pod := range pods {
+		refs = append(refs, types.ResourceRef{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name, UID: string(pod.UID)})
+	}
+	// The pods are reported even when their events cannot be listed
+	events, eventsErr := d.client.GetAllRelatedEvents(ctx, refs)
+	mountEvents := map[string][]types.EventInfo{}
+	for _, event := range events {
+		if mountEventReasons[event.Reason] {
+			mountEvents[event.ObjectName] = append(mountEvents[event.ObjectName], event)
+		}
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		result.Resources.Apps = append(result.Resources.Apps, types.AppPodStatus{
+			PodStatus:   d.extractPodStatus(pod),
+			Volume:      k8s.PodVolumeForClaim(pod, claim),
+			FuseReady:   pod.Spec.NodeName != "" && fuseNodes[pod.Spec.NodeName],
+			MountEvents: mountEvents[pod.Name],
+		})
+	}
+	return eventsErr
+}
+
+// appNodes returns the nodes running the application pods mounting the Dataset
+func appNodes(result *types.DiagnosticResult) []string {
+	var nodes []string
+	seen := map[string]bool{}
+	for _, app := range result.Resources.Apps {
+		if app.NodeName != "" && !seen[app.NodeName] {
+			seen[app.NodeName] = true
+			nodes = append(nodes, app.NodeName)
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Container states
+const (
+	containerWaiting    = "Waiting"
+	containerRunning    = "Running"
+	containerTerminated = "Terminated"
+)
+
+// containerStatuses returns the state of every init, sidecar and main container of a pod
+func containerStatuses(pod *corev1.Pod) []types.ContainerStatus {
+	sidecars := map[string]bool{}
+	for _, c := range pod.Spec.InitContainers {
+		if c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			sidecars[c.Name] = true
+		}
+	}
+
+	var statuses []types.ContainerStatus
+	for _, cs := range pod.Status.InitContainerStatuses {
+		containerType := types.ContainerTypeInit
+		if sidecars[cs.Name] {
+			containerType = types.ContainerTypeSidecar
+		}
+		statuses = append(statuses, containerStatus(cs, containerType))
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		statuses = append(statuses, containerStatus(cs, types.ContainerTypeMain))
+	}
+	return statuses
+}
+
+func containerStatus(cs corev1.ContainerStatus, containerType string) types.ContainerStatus {
+	status := types.ContainerStatus{
+		Name:         cs.Name,
+		Type:         containerType,
+		Ready:        cs.Ready,
+		RestartCount: cs.RestartCount,
+	}
+
+	switch {
+	case cs.State.Waiting != nil:
+		status.State = containerWaiting
+		status.Reason = cs.State.Waiting.Reason
+		status.Message = cs.State.Waiting.Message
+	case cs.State.Terminated != nil:
+		status.State = containerTerminated
+		status.Reason = cs.State.Terminated.Reason
+		status.Message = cs.State.Terminated.Message
+		status.ExitCode = cs.State.Terminated.ExitCode
+	case cs.State.Running != nil:
+		status.State = containerRunning
+	}
+
+	if last := cs.LastTerminationState.Terminated; last != nil {
+		status.LastTerminationReason = last.Reason
+		status.LastExitCode = last.ExitCode
+	}
+	return status
+}
+
+// blockingContainer returns the container keeping a pod from being ready: an init
+// container that has not completed, else the first container that is not ready.
+// It returns the first main container when every container is ready.
+func blockingContainer(statuses []types.ContainerStatus) *types.ContainerStatus {
+	for i, c := range statuses {
+		if c.Type == types.ContainerTypeInit && !completed(c) {
+			return &statuses[i]
+		}
+	}
+	for i, c := range statuses {
+		if c.Type != types.ContainerTypeInit && !c.Ready {
+			return &statuses[i]
+		}
+	}
+	for i, c := range statuses {
+		if c.Type == types.ContainerTypeMain {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// completed reports whether an init container ran to completion
+func completed(c types.ContainerStatus) bool {
+	return c.State == containerTerminated && c.ExitCode == 0
+}
+
+// podLogFetches returns the logs to collect from a pod: the current logs of its main
+// container, sidecars and init containers that did not complete or restarted, and the
+// previous logs of every container that restarted. The main container of the component
+// comes first. Pods without container statuses fall back to the component's main container.
+func podLogFetches(component, namespace string, pod types.PodStatus, mainContainer string) []logFetch {
+	if pod.Namespace != "" {
+		namespace = pod.Namespace
+	}
+	newFetch := func(c types.ContainerStatus, previous bool) logFetch {
+		return logFetch{
+			component:     component,
+			namespace:     namespace,
+			pod:           pod.Name,
+			container:     c.Name,
+			containerType: c.Type,
+			previous:      previous,
+		}
+	}
+
+	if len(pod.Containers) == 0 {
+		return []logFetch{newFetch(types.ContainerStatus{Name: mainContainer, Type: types.ContainerTypeMain}, false)}
+	}
+
+	// The component's main container first, keeping the pod's order otherwise
+	containers := make([]types.ContainerStatus, 0, len(pod.Containers))
+	for _, c := range pod.Containers {
+		if c.Name == mainContainer {
+			containers = append(containers, c)
+		}
+	}
+	for _, c := range pod.Containers {
+		if c.Name != mainContainer {
+			containers = append(containers, c)
+		}
+	}
+
+	var fetches []logFetch
+	for _, c := range containers {
+		if c.Type != types.ContainerTypeInit || !completed(c) || c.RestartCount > 0 {
+			fetches = append(fetches, newFetch(c, false))
+		}
+		if c.RestartCount > 0 {
+			fetches = append(fetches, newFetch(c, true))
+		}
+	}
+	return fetches
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s/fake"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestExtractPodStatusContainers(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	pod := fake.Pod("default", "demo-fuse-abcde", "demo", "alluxio-fuse", false)
+	pod.Spec.InitContainers = []corev1.Container{{Name: "mount-prep"}, {Name: "log-shipper", RestartPolicy: &always}}
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{
+		{Name: "mount-prep", RestartCount: 4, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			Reason: "Error", ExitCode: 1,
+		}}},
+		{Name: "log-shipper", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+	}
+	pod.Status.ContainerStatuses[0].State = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}}
+
+	status := NewDatasetDiagnoser(nil).extractPodStatus(pod)
+
+	wantTypes := map[string]string{
+		"mount-prep":   types.ContainerTypeInit,
+		"log-shipper":  types.ContainerTypeSidecar,
+		"alluxio-fuse": types.ContainerTypeMain,
+	}
+	if len(status.Containers) != len(wantTypes) {
+		t.Fatalf("containers = %+v, want %d", status.Containers, len(wantTypes))
+	}
+	for _, c := range status.Containers {
+		if c.Type != wantTypes[c.Name] {
+			t.Errorf("container %s type = %q, want %q", c.Name, c.Type, wantTypes[c.Name])
+		}
+	}
+	if status.Reason != "Error" || status.ContainerState != containerTerminated {
+		t.Errorf("pod state = %s/%s, want the failed init container's Terminated/Error", status.ContainerState, status.Reason)
+	}
+	if status.RestartCount != 4 {
+		t.Errorf("restart count = %d, want 4", status.RestartCount)
+	}
+}
+
+func TestPodLogFetches(t *testing.T) {
+	pod := types.PodStatus{
+		Name:      "demo-fuse-abcde",
+		Namespace: "fluid-system",
+		Containers: []types.ContainerStatus{
+			{Name: "mount-prep", Type: types.ContainerTypeInit, State: containerTerminated},
+			{Name: "log-shipper", Type: types.ContainerTypeSidecar, State: containerRunning},
+			{Name: "alluxio-fuse", Type: types.ContainerTypeMain, State: containerWaiting, RestartCount: 2},
+		},
+	}
+
+	fetches := podLogFetches(ComponentFuse, "default", pod, "alluxio-fuse")
+
+	want := []logFetch{
+		{ComponentFuse, "fluid-system", pod.Name, "alluxio-fuse", types.ContainerTypeMain, false},
+		{ComponentFuse, "fluid-system", pod.Name, "alluxio-fuse", types.ContainerTypeMain, true},
+		{ComponentFuse, "fluid-system", pod.Name, "log-shipper", types.ContainerTypeSidecar, false},
+	}
+	if len(fetches) != len(want) {
+		t.Fatalf("fetches = %+v, want %+v", fetches, want)
+	}
+	for i := range want {
+		if fetches[i] != want[i] {
+			t.Errorf("fetch %d = %+v, want %+v", i, fetches[i], want[i])
+		}
+	}
+
+	// Without container statuses the component's main container is used
+	fetches = podLogFetches(ComponentFuse, "default", types.PodStatus{Name: "pending"}, "alluxio-fuse")
+	if len(fetches) != 1 || fetches[0].container != "alluxio-fuse" || fetches[0].namespace != "default" {
+		t.Errorf("fetches = %+v, want the current alluxio-fuse logs", fetches)
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/parallel"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+const (
+	// controlPlaneLogTail is the number of lines of each control-plane log searched for the Dataset
+	controlPlaneLogTail = 2000
+	// controlPlaneLogPods is the number of pods per control-plane component logs are collected from
+	controlPlaneLogPods = 2
+)
+
+// controlPlaneRoles orders the control-plane components
+var controlPlaneRoles = []string{
+	types.ControlPlaneDatasetController,
+	types.ControlPlaneRuntimeController,
+	types.ControlPlaneWebhook,
+	types.ControlPlaneCSINodePlugin,
+}
+
+// collectControlPlane reports the Fluid controllers, webhook, CSI nodeplugin and CSIDriver,
+// whether a ready CSI nodeplugin runs on every node of an application pod, and the lines
+// of the control-plane logs mentioning the Dataset
+func (d *DatasetDiagnoser) collectControlPlane(ctx context.Context, namespace, name string, manifests *manifestCollector, result *types.DiagnosticResult) error {
+	status := &types.ControlPlaneStatus{Namespace: d.fluidNamespace}
+	graph, listErr := d.client.DiscoverControlPlane(ctx, d.fluidNamespace)
+	errs := []error{listErr}
+
+	// The runtime controller is named after the runtime resource, e.g. alluxioruntime-controller
+	runtimeController := ""
+	if result.RuntimeType != "" {
+		runtimeController = strings.TrimSuffix(result.RuntimeType, "s") + "-controller"
+	}
+	var csiPods []types.PodStatus
+	for _, w := range graph.Workloads {
+		role := k8s.ControlPlaneRole(w.Name)
+		component := types.ControlPlaneComponent{
+			Role:           role,
+			Relevant:       role != types.ControlPlaneRuntimeController || w.Name == runtimeController,
+			PodGroupStatus: *d.podGroupStatus(w, w.Pods),
+		}
+		for _, pods := range [][]types.PodStatus{component.Pods, component.FailingPods} {
+			for _, pod := range pods {
+				for _, c := range pod.Containers {
+					component.Restarts += c.RestartCount
+				}
+			}
+		}
+		if role == types.ControlPlaneCSINodePlugin {
+			csiPods = append(append(csiPods, component.Pods...), component.FailingPods...)
+		}
+		status.Components = append(status.Components, component)
+	}
+	sort.SliceStable(status.Components, func(i, j int) bool {
+		a, b := status.Components[i], status.Components[j]
+		if a.Role != b.Role {
+			return roleIndex(a.Role) < roleIndex(b.Role)
+		}
+		return a.Name < b.Name
+	})
+
+	driver, err := d.client.GetCSIDriver(ctx, k8s.CSIDriverName)
+	switch {
+	case err == nil:
+		manifests.add("CSIDriver", driver)
+		status.CSIDriver = &types.CSIDriverStatus{
+			Name:           driver.Name,
+			Found:          true,
+			AttachRequired: driver.Spec.AttachRequired != nil && *driver.Spec.AttachRequired,
+			PodInfoOnMount: driver.Spec.PodInfoOnMount != nil && *driver.Spec.PodInfoOnMount,
+		}
+	case k8s.IsNotFound(err):
+		status.CSIDriver = &types.CSIDriverStatus{Name: k8s.CSIDriverName}
+	default:
+		errs = append(errs, err)
+	}
+
+	// Without a control-plane listing, the CSI plugin of the nodes is unknown
+	consumerNodes := appNodes(result)
+	if listErr == nil || len(graph.Workloads) > 0 {
+		for _, node := range consumerNodes {
+			csiNode := types.CSINodeStatus{Node: node}
+			for _, pod := range csiPods {
+				if pod.NodeName == node && (csiNode.Pod == "" || pod.Ready) {
+					csiNode.Pod = pod.Name
+					csiNode.Ready = pod.Ready
+				}
+			}
+			status.CSINodes = append(status.CSINodes, csiNode)
+		}
+	}
+	result.Resources.ControlPlane = status
+
+	result.Logs.ControlPlane = d.collectControlPlaneLogs(ctx, newDatasetLogFilter(namespace, name), status, consumerNodes)
+	return errors.Join(errs...)
+}
+
+// collectControlPlaneLogs fetches the logs of the relevant control-plane pods and keeps the
+// lines mentioning the Dataset. CSI nodeplugin logs are taken from the application nodes.
+func (d *DatasetDiagnoser) collectControlPlaneLogs(ctx context.Context, filter *datasetLogFilter, status *types.ControlPlaneStatus, consumerNodes []string) []types.LogEntry {
+	policy := LogPolicy{PreferNodes: consumerNodes, MaxPods: map[string]int{}}
+	var fetches []logFetch
+	for i := range status.Components {
+		component := &status.Components[i]
+		if !component.Relevant {
+			continue
+		}
+		var pods []types.PodStatus
+		if component.Role == types.ControlPlaneCSINodePlugin {
+			// Only the plugins serving the application pods are of interest
+			for _, pod := range append(append([]types.PodStatus(nil), component.FailingPods...), component.Pods...) {
+				if contains(consumerNodes, pod.NodeName) {
+					pods = append(pods, pod)
+				}
+			}
+		} else {
+			policy.MaxPods[component.Role] = controlPlaneLogPods
+			pods = policy.selectLogPods(component.Role, &component.PodGroupStatus)
+		}
+		for _, pod := range pods {
+			for _, c := range pod.Containers {
+				if c.Type != types.ContainerTypeInit {
+					fetches = append(fetches, logFetch{
+						component:     component.Role,
+						namespace:     status.Namespace,
+						pod:           pod.Name,
+						container:     c.Name,
+						containerType: c.Type,
+					})
+				}
+			}
+		}
+	}
+
+	entries := make([]*types.LogEntry, len(fetches))
+	parallel.ForEach(ctx, len(fetches), d.concurrency, func(ctx context.Context, i int) {
+		entry := d.collectFilteredLogs(ctx, fetches[i], filter)
+		entries[i] = &entry
+	})
+
+	var logs []types.LogEntry
+	for _, entry := range entries {
+		// Logs not mentioning the Dataset are left out
+		if entry != nil && (entry.Logs != "" || entry.Error != "") {
+			logs = append(logs, *entry)
+		}
+	}
+	return logs
+}
+
+// collectFilteredLogs fetches the last controlPlaneLogTail lines of a container's log and
+// keeps the lines matching filter, at most as many as the log policy's tail
+func (d *DatasetDiagnoser) collectFilteredLogs(ctx context.Context, fetch logFetch, filter *datasetLogFilter) types.LogEntry {
+	policy := &d.logPolicy
+	var tail int64 = controlPlaneLogTail
+	if policy.TailLines < 0 {
+		tail = -1
+	}
+	entry := types.LogEntry{
+		PodName:       fetch.pod,
+		ContainerName: fetch.container,
+		ContainerType: fetch.containerType,
+		TailLines:     tail,
+		Filter:        filter.String(),
+	}
+
+	logs, err := d.client.GetPodLogs(ctx, fetch.namespace, fetch.pod, k8s.LogOptions{
+		Container:  fetch.container,
+		TailLines:  tail,
+		Since:      policy.Since,
+		LimitBytes: policy.LimitBytes,
+	})
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+
+	var matched []string
+	for _, line := range strings.Split(logs, "\n") {
+		if filter.match(line) {
+			matched = append(matched, line)
+		}
+	}
+	entry.Truncated = logTruncated(logs, tail, policy.LimitBytes)
+	if policy.TailLines >= 0 && int64(len(matched)) > policy.TailLines {
+		matched = matched[int64(len(matched))-policy.TailLines:]
+		entry.Truncated = true
+	}
+	if len(matched) > 0 {
+		entry.Logs = strings.Join(matched, "\n") + "\n"
+	}
+	return entry
+}
+
+// datasetLogFilter matches the log lines about one Dataset: the lines naming it as
+// <namespace>/<name>, or naming both its name and its namespace as whole words, as in
+// {"name": "demo", "namespace": "default"}. The name alone would match the lines of
+// every Dataset whose name contains it, in any namespace.
+type datasetLogFilter struct {
+	ref            *regexp.Regexp
+	name           *regexp.Regexp
+	namespace      *regexp.Regexp
+	namespacedName string
+}
+
+func newDatasetLogFilter(namespace, name string) *datasetLogFilter {
+	return &datasetLogFilter{
+		ref:            wholeWord(namespace + "/" + name),
+		name:           wholeWord(name),
+		namespace:      wholeWord(namespace),
+		namespacedName: namespace + "/" + name,
+	}
+}
+
+// wholeWord matches s when not surrounded by characters of Kubernetes object names
+func wholeWord(s string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|[^A-Za-z0-9_.-])` + regexp.QuoteMeta(s) + `($|[^A-Za-z0-9_.-])`)
+}
+
+func (f *datasetLogFilter) match(line string) bool {
+	return f.ref.MatchString(line) || f.name.MatchString(line) && f.namespace.MatchString(line)
+}
+
+// String returns the <namespace>/<name> recorded as the filter of the log entries
+func (f *datasetLogFilter) String() string {
+	return f.namespacedName
+}
+
+func roleIndex(role string) int {
+	for i, r := range controlPlaneRoles {
+		if r == role {
+			return i
+		}
+	}
+	return len(controlPlaneRoles)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
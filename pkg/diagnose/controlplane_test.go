@@ -0,0 +1,154 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s/fake"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	appsv1 "k8s.io/api/apps/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// controllerLogsClient serves the same controller log for every container
+type controllerLogsClient struct {
+	k8s.Interface
+}
+
+func (c controllerLogsClient) GetPodLogs(ctx context.Context, namespace, podName string, opts k8s.LogOptions) (string, error) {
+	return "I reconciling dataset default/demo\nI reconciling dataset default/other\nI reconciling dataset default/demo-old\n" +
+		"I reconciling dataset prod/demo\nE failed to setup dataset default/demo: timeout\n" +
+		"I synced {\"name\": \"demo\", \"namespace\": \"default\"}\n", nil
+}
+
+func TestDiagnoseControlPlane(t *testing.T) {
+	// A dataset with an application pod on node-2, where no CSI nodeplugin runs
+	withApp := append(alluxioObjects(1), fake.AppPod(testNamespace, "train-0", testDataset, "node-2", true))
+
+	tests := []struct {
+		name           string
+		objects        []runtime.Object
+		fluidNamespace string
+		wantRules      []string
+		check          func(t *testing.T, result *types.DiagnosticResult)
+	}{
+		{
+			name:    "healthy control plane",
+			objects: alluxioObjects(1),
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				cp := result.Resources.ControlPlane
+				if cp == nil || len(cp.Components) != 4 || cp.CSIDriver == nil || !cp.CSIDriver.Found {
+					t.Fatalf("control plane = %+v, want four components and the CSIDriver", cp)
+				}
+				if cp.Components[0].Role != types.ControlPlaneDatasetController || !cp.Components[1].Relevant {
+					t.Errorf("components = %+v, want the dataset controller first and a relevant runtime controller", cp.Components)
+				}
+				logs := result.Logs.ControlPlane
+				if len(logs) != 3 || logs[0].Filter != testNamespace+"/"+testDataset {
+					t.Fatalf("control-plane logs = %+v, want one filtered log per controller and the webhook", logs)
+				}
+				want := "I reconciling dataset default/demo\nE failed to setup dataset default/demo: timeout\n" +
+					"I synced {\"name\": \"demo\", \"namespace\": \"default\"}\n"
+				if logs[0].Logs != want {
+					t.Errorf("logs = %q, want %q", logs[0].Logs, want)
+				}
+			},
+		},
+		{
+			name:      "application node without CSI nodeplugin",
+			objects:   withApp,
+			wantRules: []string{RuleCSINodePluginMissing},
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				nodes := result.Resources.ControlPlane.CSINodes
+				if len(nodes) != 1 || nodes[0].Node != "node-2" || nodes[0].Pod != "" {
+					t.Errorf("CSI nodes = %+v, want node-2 without a nodeplugin", nodes)
+				}
+			},
+		},
+		{
+			name: "webhook down, other runtime controller and no CSIDriver",
+			objects: func() []runtime.Object {
+				var objects []runtime.Object
+				for _, obj := range alluxioObjects(1) {
+					switch o := obj.(type) {
+					case *storagev1.CSIDriver:
+						continue
+					case *appsv1.Deployment:
+						if o.Name == "fluid-webhook" {
+							obj = fake.Deployment(k8s.FluidNamespace, "fluid-webhook", 1, 0)
+						} else if o.Name == "alluxioruntime-controller" {
+							obj = fake.Deployment(k8s.FluidNamespace, "juicefsruntime-controller", 0, 0)
+						}
+					}
+					objects = append(objects, obj)
+				}
+				return objects
+			}(),
+			wantRules: []string{RuleControlPlaneNotReady, RuleCSIDriverMissing},
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				components := map[string]bool{}
+				for _, h := range result.FailureHints {
+					if h.RuleID == RuleControlPlaneNotReady {
+						components[h.Component] = true
+					}
+				}
+				if !components[types.ControlPlaneWebhook] || !components[types.ControlPlaneRuntimeController] {
+					t.Errorf("failure hints = %+v, want the webhook and the missing alluxio controller", result.FailureHints)
+				}
+			},
+		},
+		{
+			name:           "fluid installed in another namespace",
+			objects:        alluxioObjects(1),
+			fluidNamespace: "fluid",
+			wantRules:      []string{RuleControlPlaneNotFound},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnoser := NewDatasetDiagnoser(controllerLogsClient{fake.NewClient(tt.objects...)})
+			if tt.fluidNamespace != "" {
+				diagnoser.WithFluidNamespace(tt.fluidNamespace)
+			}
+			result, err := diagnoser.Diagnose(context.Background(), testNamespace, testDataset)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			rules := map[string]bool{}
+			for _, h := range result.FailureHints {
+				rules[h.RuleID] = true
+			}
+			for _, rule := range tt.wantRules {
+				if !rules[rule] {
+					t.Errorf("failure hints = %+v, want %s", result.FailureHints, rule)
+				}
+			}
+			if len(tt.wantRules) == 0 && len(result.FailureHints) != 0 {
+				t.Errorf("failure hints = %+v, want none", result.FailureHints)
+			}
+			if tt.check != nil {
+				tt.check(t, result)
+			}
+		})
+	}
+}
@@ -20,35 +20,90 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/inspect"
 	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/parallel"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/redact"
 	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
 )
 
-const (
-	defaultTailLines = 100
-	maxLogsPerGroup  = 2
-)
-
 // DatasetDiagnoser performs comprehensive diagnosis of a Dataset
 type DatasetDiagnoser struct {
-	client    *k8s.Client
-	tailLines int64
+	client         k8s.Interface
+	logPolicy      LogPolicy
+	rules          *RuleRegistry
+	stepTimeout    time.Duration
+	concurrency    int
+	fluidNamespace string
+	redaction      *redact.Policy
+	manifests      []string
 }
 
 // NewDatasetDiagnoser creates a new DatasetDiagnoser
-func NewDatasetDiagnoser(client *k8s.Client) *DatasetDiagnoser {
+func NewDatasetDiagnoser(client k8s.Interface) *DatasetDiagnoser {
 	return &DatasetDiagnoser{
-		client:    client,
-		tailLines: defaultTailLines,
+		client:         client,
+		logPolicy:      DefaultLogPolicy(),
+		rules:          DefaultRuleRegistry(),
+		concurrency:    parallel.DefaultLimit,
+		fluidNamespace: k8s.FluidNamespace,
+		redaction:      redact.DefaultPolicy(),
 	}
 }
 
-// Diagnose performs a complete diagnosis of a Dataset
+// WithRules sets the rule registry used to analyze collected results
+func (d *DatasetDiagnoser) WithRules(rules *RuleRegistry) *DatasetDiagnoser {
+	d.rules = rules
+	return d
+}
+
+// WithLogPolicy sets which pods logs are collected from and how much of each log
+func (d *DatasetDiagnoser) WithLogPolicy(policy LogPolicy) *DatasetDiagnoser {
+	d.logPolicy = policy
+	return d
+}
+
+// WithStepTimeout limits the duration of each collection step. A step that times out
+// keeps what it collected and is reported as partial. Zero means no limit.
+func (d *DatasetDiagnoser) WithStepTimeout(timeout time.Duration) *DatasetDiagnoser {
+	d.stepTimeout = timeout
+	return d
+}
+
+// WithConcurrency sets the maximum number of concurrent API calls of a collection step
+func (d *DatasetDiagnoser) WithConcurrency(n int) *DatasetDiagnoser {
+	d.concurrency = n
+	return d
+}
+
+// WithFluidNamespace sets the namespace Fluid's controllers, webhook and CSI plugin run in
+func (d *DatasetDiagnoser) WithFluidNamespace(namespace string) *DatasetDiagnoser {
+	d.fluidNamespace = namespace
+	return d
+}
+
+// WithRedaction sets the policy masking secrets in the collected CRs, events and logs
+func (d *DatasetDiagnoser) WithRedaction(policy *redact.Policy) *DatasetDiagnoser {
+	d.redaction = policy
+	return d
+}
+
+// WithManifests sets the categories of the objects whose full YAML is collected into
+// result.Manifests, see ManifestCategories. None are collected by default.
+func (d *DatasetDiagnoser) WithManifests(categories []string) *DatasetDiagnoser {
+	d.manifests = categories
+	return d
+}
+
+// Diagnose performs a complete diagnosis of a Dataset. Events are collected concurrently
+// with the resource status and logs. When ctx or a step times out, the diagnosis returns
+// what was collected and records the partial steps in CollectionSteps and FailureHints.
 func (d *DatasetDiagnoser) Diagnose(ctx context.Context, namespace, name string) (*types.DiagnosticResult, error) {
 	result := &types.DiagnosticResult{
 		CollectedAt: time.Now(),
@@ -56,75 +111,289 @@ func (d *DatasetDiagnoser) Diagnose(ctx context.Context, namespace, name string)
 		Namespace:   namespace,
 	}
 
+	// Review the permissions first, so that steps lacking a required one are skipped.
+	// A failed review is recorded in the report and skips nothing.
+	result.Permissions, _ = d.CheckPermissions(ctx, namespace)
+	denied := deniedByStep(result.Permissions)
+	manifests := newManifestCollector(d.manifests)
+	result.Collection = d.collectionInfo()
+
 	// Step 1: Fetch and clean CR snapshots
-	if err := d.collectCRSnapshots(ctx, namespace, name, result); err != nil {
-		return nil, fmt.Errorf("failed to collect CR snapshots: %w", err)
+	var dataset, runtime *unstructured.Unstructured
+	snapshots := d.runStep(ctx, ComponentSnapshots, denied, func(ctx context.Context) error {
+		var err error
+		dataset, runtime, err = d.collectCRSnapshots(ctx, namespace, name, result)
+		return err
+	})
+	if snapshots.err != nil {
+		return nil, fmt.Errorf("failed to collect CR snapshots: %w", snapshots.err)
 	}
 
-	// Step 2: Collect Kubernetes events
-	if err := d.collectEvents(ctx, namespace, name, result); err != nil {
-		// Non-fatal, continue with diagnosis
-		result.FailureHints = append(result.FailureHints, types.FailureHint{
-			Severity:   "warning",
-			Component:  "events",
-			Issue:      "Failed to collect events",
-			Suggestion: "Check RBAC permissions for event access",
-			Evidence:   err.Error(),
-		})
+	// The runtime profile drives every collection step below
+	profile := LookupRuntimeProfile(result.RuntimeType)
+
+	// Step 2: Discover the objects backing the runtime
+	var graph *k8s.ResourceGraph
+	discovery := d.runStep(ctx, ComponentDiscovery, denied, func(ctx context.Context) error {
+		var err error
+		graph, err = d.client.DiscoverResources(ctx, namespace, name, runtime, nil)
+		return err
+	})
+	if graph == nil {
+		graph = &k8s.ResourceGraph{}
 	}
+	result.Resources.Discovered = graph.Refs()
+	manifests.addGraph(graph)
 
-	// Step 3: Collect runtime resource status
-	if err := d.collectResourceStatus(ctx, namespace, name, result); err != nil {
-		// Non-fatal, continue with diagnosis
-		result.FailureHints = append(result.FailureHints, types.FailureHint{
-			Severity:   "warning",
-			Component:  "resources",
-			Issue:      "Failed to collect resource status",
-			Suggestion: "Check RBAC permissions for pod/statefulset/daemonset access",
-			Evidence:   err.Error(),
+	// Events are collected for the Dataset and Runtime CRs and every discovered object
+	eventObjects := []types.ResourceRef{k8s.RefOf(dataset)}
+	if runtime != nil {
+		eventObjects = append(eventObjects, k8s.RefOf(runtime))
+	}
+	eventObjects = append(eventObjects, result.Resources.Discovered...)
+
+	// Step 3: Collect Kubernetes events, concurrently with
+	// Step 4: Collect runtime resource status, then
+	// Step 5: Collect the application pods mounting the Dataset, and then
+	// Step 6: Evaluate the nodes of the pods found in steps 4 and 5, concurrently with
+	// Step 7: Check the Fluid control plane and the CSI plugin on the application nodes, and
+	// Step 8: Collect logs of the pods found in steps 4 and 5
+	var events, resources, apps, nodes, controlPlane, logs stepRun
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		events = d.runStep(ctx, ComponentEvents, denied, func(ctx context.Context) error {
+			return d.collectEvents(ctx, eventObjects, result)
 		})
+	}()
+	resources = d.runStep(ctx, ComponentResources, denied, func(ctx context.Context) error {
+		return d.collectResourceStatus(ctx, namespace, name, profile, graph, manifests, result)
+	})
+	apps = d.runStep(ctx, ComponentApps, denied, func(ctx context.Context) error {
+		return d.collectApps(ctx, namespace, name, result)
+	})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		nodes = d.runStep(ctx, ComponentNodes, denied, func(ctx context.Context) error {
+			return d.collectNodes(ctx, graph, manifests, result)
+		})
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		controlPlane = d.runStep(ctx, ComponentControlPlane, denied, func(ctx context.Context) error {
+			return d.collectControlPlane(ctx, namespace, name, manifests, result)
+		})
+	}()
+	logs = d.runStep(ctx, ComponentLogs, denied, func(ctx context.Context) error {
+		return d.collectLogs(ctx, namespace, profile, result)
+	})
+	wg.Wait()
+
+	result.Manifests = manifests.sorted()
+	result.CollectionSteps = []types.CollectionStep{snapshots.step, discovery.step, events.step, resources.step, apps.step, nodes.step, controlPlane.step, logs.step}
+
+	// Failed and partial steps are non-fatal, continue with diagnosis
+	discovery.addHint(result, "Failed to discover some runtime resources",
+		"Check RBAC permissions to list statefulsets, daemonsets, deployments, services, configmaps and pods")
+	events.addHint(result, "Failed to collect events",
+		"Check RBAC permissions for event access")
+	resources.addHint(result, "Failed to collect resource status",
+		"Check RBAC permissions for pod/statefulset/daemonset access")
+	apps.addHint(result, "Failed to collect the application pods mounting the Dataset",
+		"Check RBAC permissions to list pods and events in the Dataset namespace")
+	nodes.addHint(result, "Failed to collect some node information",
+		"Check RBAC permissions to get and list nodes and to list pods in all namespaces")
+	controlPlane.addHint(result, "Failed to check some Fluid control-plane components",
+		fmt.Sprintf("Check RBAC permissions to list deployments, daemonsets and pods and get pod logs in %s, and to get csidrivers", d.fluidNamespace))
+	logs.addHint(result, "Failed to collect some logs",
+		"Check RBAC permissions for pod/log access")
+
+	// Mask secrets before the rules quote the collected data as evidence
+	redactor := d.redaction.NewRedactor()
+	redactor.Result(result)
+	result.Redaction = redactor.Report()
+
+	// Analyze and generate failure hints, then determine overall health status
+	Analyze(result, d.rules)
+
+	return result, nil
+}
+
+// stepRun is the outcome of a collection step
+type stepRun struct {
+	step types.CollectionStep
+	err  error
+}
+
+// collectionInfo records the settings of the diagnosis and the version of the cluster.
+// The version is left empty when it cannot be read.
+func (d *DatasetDiagnoser) collectionInfo() *types.CollectionInfo {
+	info := &types.CollectionInfo{
+		Options: types.CollectionOptions{
+			StepTimeout:    d.stepTimeout.String(),
+			FluidNamespace: d.fluidNamespace,
+			TailLines:      d.logPolicy.TailLines,
+			LimitBytes:     d.logPolicy.LimitBytes,
+			AllPods:        d.logPolicy.AllPods,
+			MaxPods:        d.logPolicy.MaxPods,
+			PreferNodes:    d.logPolicy.PreferNodes,
+			Manifests:      d.manifests,
+		},
+	}
+	if d.logPolicy.Since > 0 {
+		info.Options.Since = d.logPolicy.Since.String()
+	}
+	if version, err := d.client.ServerVersion(); err == nil {
+		info.ServerVersion = version
+	}
+	return info
+}
+
+// runStep runs a collection step under the step timeout and records its outcome.
+// A step whose context is done before it returns is partial, and a step denied a
+// permission it requires is skipped.
+func (d *DatasetDiagnoser) runStep(ctx context.Context, name string, denied map[string]*stepDenial, collect func(ctx context.Context) error) stepRun {
+	var missing []string
+	if denial := denied[name]; denial != nil {
+		missing = denial.missing
+		if len(denial.required) > 0 {
+			err := fmt.Errorf("missing permission: %s", strings.Join(denial.required, ", "))
+			return stepRun{
+				step: types.CollectionStep{
+					Name:               name,
+					Status:             types.StepStatusSkipped,
+					Duration:           "0s",
+					Error:              err.Error(),
+					MissingPermissions: missing,
+				},
+				err: err,
+			}
+		}
+	}
+
+	if d.stepTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.stepTimeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := collect(ctx)
+	run := stepRun{
+		step: types.CollectionStep{
+			Name:               name,
+			Status:             types.StepStatusComplete,
+			Duration:           time.Since(start).Round(time.Millisecond).String(),
+			MissingPermissions: missing,
+		},
+		err: err,
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		run.step.Status = types.StepStatusPartial
+		run.step.Error = fmt.Sprintf("timed out after %s", run.step.Duration)
+		if err == nil {
+			run.err = ctx.Err()
+		}
+	case err != nil:
+		run.step.Status = types.StepStatusFailed
+		run.step.Error = err.Error()
 	}
+	return run
+}
 
-	// Step 4: Collect logs
-	if err := d.collectLogs(ctx, namespace, name, result); err != nil {
-		// Non-fatal, continue with diagnosis
+// addHint records a failed, partial or skipped step as a collection hint. When the step
+// was denied permissions, the suggestion names them.
+func (r *stepRun) addHint(result *types.DiagnosticResult, issue, suggestion string) {
+	if len(r.step.MissingPermissions) > 0 {
+		suggestion = "Ask a cluster administrator to grant: " + strings.Join(r.step.MissingPermissions, ", ")
+	}
+	switch r.step.Status {
+	case types.StepStatusSkipped:
 		result.FailureHints = append(result.FailureHints, types.FailureHint{
-			Severity:   "warning",
-			Component:  "logs",
-			Issue:      "Failed to collect some logs",
-			Suggestion: "Check RBAC permissions for pod/log access",
-			Evidence:   err.Error(),
+			Severity:   SeverityWarning,
+			Component:  r.step.Name,
+			Issue:      fmt.Sprintf("Skipped the %s step: the current user lacks a permission it requires", r.step.Name),
+			Suggestion: suggestion,
+			Evidence:   r.err.Error(),
+		})
+	case types.StepStatusFailed:
+		result.FailureHints = append(result.FailureHints, types.FailureHint{
+			Severity:   SeverityWarning,
+			Component:  r.step.Name,
+			Issue:      issue,
+			Suggestion: suggestion,
+			Evidence:   r.err.Error(),
+		})
+	case types.StepStatusPartial:
+		result.FailureHints = append(result.FailureHints, types.FailureHint{
+			Severity:   SeverityWarning,
+			Component:  r.step.Name,
+			Issue:      fmt.Sprintf("Collection of %s %s; results are partial", r.step.Name, r.step.Error),
+			Suggestion: "Increase --step-timeout or --timeout, or check the API server latency",
+			Evidence:   r.err.Error(),
 		})
 	}
+}
 
-	// Analyze and generate failure hints
-	d.analyzeAndGenerateHints(result)
+// Analyze generates failure hints and the overall health status for a collected result.
+// Hints recorded by failed collection steps are kept and every other hint is recomputed,
+// so results loaded from older archives are re-evaluated with the current analysis.
+// A nil registry runs the built-in rules.
+func Analyze(result *types.DiagnosticResult, rules *RuleRegistry) {
+	if rules == nil {
+		rules = DefaultRuleRegistry()
+	}
 
-	// Determine overall health status
-	result.HealthStatus = d.determineHealthStatus(result)
+	var collectionHints []types.FailureHint
+	for _, hint := range result.FailureHints {
+		if isCollectionHint(hint) {
+			collectionHints = append(collectionHints, hint)
+		}
+	}
+	result.CacheStatus = inspect.ParseCacheStatus(parseObjectYAML(result.DatasetYAML), parseObjectYAML(result.RuntimeYAML))
+	result.FailureHints = append(collectionHints, rules.Evaluate(result)...)
+	result.HealthStatus = determineHealthStatus(result)
+}
 
-	return result, nil
+// isCollectionHint reports whether a hint was recorded by a failed collection step or lookup
+func isCollectionHint(hint types.FailureHint) bool {
+	switch hint.Component {
+	case ComponentSnapshots, ComponentDiscovery, ComponentEvents, ComponentResources, ComponentApps, ComponentNodes, ComponentControlPlane, ComponentLogs:
+		return true
+	}
+	return false
 }
 
-// collectCRSnapshots fetches and cleans Dataset and Runtime CRs
-func (d *DatasetDiagnoser) collectCRSnapshots(ctx context.Context, namespace, name string, result *types.DiagnosticResult) error {
+// collectCRSnapshots fetches and cleans Dataset and Runtime CRs. It returns both, with a nil Runtime if there is none.
+func (d *DatasetDiagnoser) collectCRSnapshots(ctx context.Context, namespace, name string, result *types.DiagnosticResult) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
 	// Get Dataset
 	dataset, err := d.client.GetDataset(ctx, namespace, name)
 	if err != nil {
-		return fmt.Errorf("failed to get dataset: %w", err)
+		return nil, nil, fmt.Errorf("failed to get dataset: %w", err)
 	}
 
 	cleanedDataset := cleanCRForDiagnosis(dataset)
 	datasetYAML, err := yaml.Marshal(cleanedDataset)
 	if err != nil {
-		return fmt.Errorf("failed to marshal dataset: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal dataset: %w", err)
 	}
 	result.DatasetYAML = string(datasetYAML)
 
-	// Try to find Runtime
-	runtime, runtimeType, err := d.client.TryFindRuntime(ctx, namespace, name)
+	// Find the bound Runtime. Lookup failures are non-fatal and reported as a hint.
+	runtime, runtimeType, err := d.client.FindRuntime(ctx, dataset)
 	if err != nil {
-		return fmt.Errorf("failed to find runtime: %w", err)
+		result.FailureHints = append(result.FailureHints, types.FailureHint{
+			Severity:   SeverityWarning,
+			Component:  ComponentSnapshots,
+			Issue:      "Failed to look up the Runtime of the Dataset",
+			Suggestion: "Check that the Fluid CRDs are installed and RBAC allows reading data.fluid.io runtimes",
+			Evidence:   err.Error(),
+		})
 	}
 
 	if runtime != nil {
@@ -132,74 +401,45 @@ func (d *DatasetDiagnoser) collectCRSnapshots(ctx context.Context, namespace, na
 		cleanedRuntime := cleanCRForDiagnosis(runtime)
 		runtimeYAML, err := yaml.Marshal(cleanedRuntime)
 		if err != nil {
-			return fmt.Errorf("failed to marshal runtime: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal runtime: %w", err)
 		}
 		result.RuntimeYAML = string(runtimeYAML)
 	}
 
-	return nil
+	return dataset, runtime, nil
 }
 
-// collectEvents fetches all related events
-func (d *DatasetDiagnoser) collectEvents(ctx context.Context, namespace, name string, result *types.DiagnosticResult) error {
-	events, err := d.client.GetAllRelatedEvents(ctx, namespace, name)
-	if err != nil {
-		return err
-	}
+// collectEvents fetches the events of the Dataset, its Runtime and the discovered objects,
+// keeping the events of the namespaces that could be listed
+func (d *DatasetDiagnoser) collectEvents(ctx context.Context, objects []types.ResourceRef, result *types.DiagnosticResult) error {
+	events, err := d.client.GetAllRelatedEvents(ctx, objects)
 	result.Events = events
-	return nil
+	return err
 }
 
-// collectResourceStatus fetches status of all runtime resources
-func (d *DatasetDiagnoser) collectResourceStatus(ctx context.Context, namespace, name string, result *types.DiagnosticResult) error {
-	// Master StatefulSet
-	masterSts, _ := d.client.GetStatefulSet(ctx, namespace, name+"-master")
-	if masterSts != nil {
-		result.Resources.Master = &types.PodGroupStatus{
-			Name:        masterSts.Name,
-			Kind:        "StatefulSet",
-			Desired:     *masterSts.Spec.Replicas,
-			Ready:       masterSts.Status.ReadyReplicas,
-			Available:   masterSts.Status.AvailableReplicas,
-			Unavailable: *masterSts.Spec.Replicas - masterSts.Status.ReadyReplicas,
-			Healthy:     masterSts.Status.ReadyReplicas == *masterSts.Spec.Replicas,
-		}
-		d.collectPodStatus(ctx, namespace, name, "master", result.Resources.Master)
-	}
-
-	// Worker StatefulSet
-	workerSts, _ := d.client.GetStatefulSet(ctx, namespace, name+"-worker")
-	if workerSts != nil {
-		result.Resources.Workers = &types.PodGroupStatus{
-			Name:        workerSts.Name,
-			Kind:        "StatefulSet",
-			Desired:     *workerSts.Spec.Replicas,
-			Ready:       workerSts.Status.ReadyReplicas,
-			Available:   workerSts.Status.AvailableReplicas,
-			Unavailable: *workerSts.Spec.Replicas - workerSts.Status.ReadyReplicas,
-			Healthy:     workerSts.Status.ReadyReplicas == *workerSts.Spec.Replicas,
-		}
-		d.collectPodStatus(ctx, namespace, name, "worker", result.Resources.Workers)
-	}
-
-	// Fuse DaemonSet
-	fuseDaemonSet, _ := d.client.GetDaemonSet(ctx, namespace, name+"-fuse")
-	if fuseDaemonSet != nil {
-		result.Resources.Fuse = &types.PodGroupStatus{
-			Name:        fuseDaemonSet.Name,
-			Kind:        "DaemonSet",
-			Desired:     fuseDaemonSet.Status.DesiredNumberScheduled,
-			Ready:       fuseDaemonSet.Status.NumberReady,
-			Available:   fuseDaemonSet.Status.NumberAvailable,
-			Unavailable: fuseDaemonSet.Status.NumberUnavailable,
-			Healthy:     fuseDaemonSet.Status.NumberReady == fuseDaemonSet.Status.DesiredNumberScheduled,
-		}
-		d.collectPodStatus(ctx, namespace, name, "fuse", result.Resources.Fuse)
+// collectResourceStatus fetches the volume status and reports the discovered runtime workloads
+func (d *DatasetDiagnoser) collectResourceStatus(ctx context.Context, namespace, name string, profile *RuntimeProfile, graph *k8s.ResourceGraph, manifests *manifestCollector, result *types.DiagnosticResult) error {
+	if profile.Master != nil {
+		result.Resources.Master = d.workloadStatus(graph, profile.Master)
+	}
+	if profile.Worker != nil {
+		result.Resources.Workers = d.workloadStatus(graph, profile.Worker)
+	}
+	if profile.Fuse != nil {
+		result.Resources.Fuse = d.workloadStatus(graph, profile.Fuse)
 	}
+	return d.collectVolumeStatus(ctx, namespace, name, manifests, result)
+}
 
-	// PVC
-	pvc, _ := d.client.GetPVC(ctx, namespace, name)
+// collectVolumeStatus fetches the PVC of the Dataset and its bound PV. A missing PVC
+// or PV is not an error: it is reported by the analysis.
+func (d *DatasetDiagnoser) collectVolumeStatus(ctx context.Context, namespace, name string, manifests *manifestCollector, result *types.DiagnosticResult) error {
+	pvc, err := d.client.GetPVC(ctx, namespace, name)
+	if err != nil && !k8s.IsNotFound(err) {
+		return err
+	}
 	if pvc != nil {
+		manifests.add("PersistentVolumeClaim", pvc)
 		capacity := ""
 		if pvc.Status.Capacity != nil {
 			if storage, ok := pvc.Status.Capacity["storage"]; ok {
@@ -220,8 +460,12 @@ func (d *DatasetDiagnoser) collectResourceStatus(ctx context.Context, namespace,
 
 		// Get PV if bound
 		if pvc.Spec.VolumeName != "" {
-			pv, _ := d.client.GetPV(ctx, pvc.Spec.VolumeName)
+			pv, err := d.client.GetPV(ctx, pvc.Spec.VolumeName)
+			if err != nil && !k8s.IsNotFound(err) {
+				return err
+			}
 			if pv != nil {
+				manifests.add("PersistentVolume", pv)
 				pvCapacity := ""
 				if pv.Spec.Capacity != nil {
 					if storage, ok := pv.Spec.Capacity["storage"]; ok {
@@ -238,19 +482,32 @@ func (d *DatasetDiagnoser) collectResourceStatus(ctx context.Context, namespace,
 			}
 		}
 	}
-
 	return nil
 }
 
-// collectPodStatus collects status of pods for a component
-func (d *DatasetDiagnoser) collectPodStatus(ctx context.Context, namespace, datasetName, role string, group *types.PodGroupStatus) {
-	labelSelector := fmt.Sprintf("release=%s,role=alluxio-%s", datasetName, role)
-	pods, err := d.client.GetPodsByLabel(ctx, namespace, labelSelector)
-	if err != nil {
-		return
+// workloadStatus reports the discovered workload backing a component and the status of its pods.
+// It returns nil if no workload was discovered.
+func (d *DatasetDiagnoser) workloadStatus(graph *k8s.ResourceGraph, component *ComponentProfile) *types.PodGroupStatus {
+	w := graph.Component(component.Name)
+	if w == nil {
+		return nil
+	}
+	return d.podGroupStatus(w, graph.ComponentPods(component.Name))
+}
+
+// podGroupStatus reports the status of a workload and of the given pods
+func (d *DatasetDiagnoser) podGroupStatus(w *k8s.Workload, pods []corev1.Pod) *types.PodGroupStatus {
+	group := &types.PodGroupStatus{
+		Name:        w.Name,
+		Kind:        w.Kind,
+		Desired:     w.Desired,
+		Ready:       w.Ready,
+		Available:   w.Available,
+		Unavailable: w.Unavailable,
+		Healthy:     w.Ready == w.Desired,
 	}
 
-	for _, pod := range pods.Items {
+	for _, pod := range pods {
 		status := d.extractPodStatus(&pod)
 		if status.Ready {
 			group.Pods = append(group.Pods, status)
@@ -258,34 +515,31 @@ func (d *DatasetDiagnoser) collectPodStatus(ctx context.Context, namespace, data
 			group.FailingPods = append(group.FailingPods, status)
 		}
 	}
+	return group
 }
 
 // extractPodStatus extracts status from a pod
 func (d *DatasetDiagnoser) extractPodStatus(pod *corev1.Pod) types.PodStatus {
 	status := types.PodStatus{
-		Name:     pod.Name,
-		Phase:    string(pod.Status.Phase),
-		NodeName: pod.Spec.NodeName,
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+		Phase:     string(pod.Status.Phase),
+		NodeName:  pod.Spec.NodeName,
 	}
 
 	// Check if ready
 	status.Ready = isPodReady(pod)
 
-	// Get restart count and container state
-	if len(pod.Status.ContainerStatuses) > 0 {
-		cs := pod.Status.ContainerStatuses[0]
-		status.RestartCount = cs.RestartCount
-
-		if cs.State.Waiting != nil {
-			status.ContainerState = "Waiting"
-			status.Reason = cs.State.Waiting.Reason
-			status.Message = cs.State.Waiting.Message
-		} else if cs.State.Terminated != nil {
-			status.ContainerState = "Terminated"
-			status.Reason = cs.State.Terminated.Reason
-			status.Message = cs.State.Terminated.Message
-		} else if cs.State.Running != nil {
-			status.ContainerState = "Running"
+	// Get the state of every container, and summarize the one holding the pod back
+	status.Containers = containerStatuses(pod)
+	if c := blockingContainer(status.Containers); c != nil {
+		status.ContainerState = c.State
+		status.Reason = c.Reason
+		status.Message = c.Message
+	}
+	for _, c := range status.Containers {
+		if c.RestartCount > status.RestartCount {
+			status.RestartCount = c.RestartCount
 		}
 	}
 
@@ -300,227 +554,15 @@ func (d *DatasetDiagnoser) extractPodStatus(pod *corev1.Pod) types.PodStatus {
 	return status
 }
 
-// collectLogs fetches logs from relevant pods
-func (d *DatasetDiagnoser) collectLogs(ctx context.Context, namespace, name string, result *types.DiagnosticResult) error {
-	// Collect master logs
-	if result.Resources.Master != nil && len(result.Resources.Master.Pods) > 0 {
-		pod := result.Resources.Master.Pods[0]
-		logs, err := d.client.GetPodLogs(ctx, namespace, pod.Name, "alluxio-master", d.tailLines)
-		if err != nil {
-			result.Logs.Master = &types.LogEntry{
-				PodName:   pod.Name,
-				Error:     err.Error(),
-				TailLines: d.tailLines,
-			}
-		} else {
-			result.Logs.Master = &types.LogEntry{
-				PodName:       pod.Name,
-				ContainerName: "alluxio-master",
-				Logs:          logs,
-				TailLines:     d.tailLines,
-				Truncated:     len(logs) > 0,
-			}
-		}
-	}
-
-	// Collect worker logs (one healthy, one failing if available)
-	if result.Resources.Workers != nil {
-		// Collect from a healthy pod
-		if len(result.Resources.Workers.Pods) > 0 {
-			pod := result.Resources.Workers.Pods[0]
-			logs, err := d.client.GetPodLogs(ctx, namespace, pod.Name, "alluxio-worker", d.tailLines)
-			entry := types.LogEntry{
-				PodName:       pod.Name,
-				ContainerName: "alluxio-worker",
-				TailLines:     d.tailLines,
-			}
-			if err != nil {
-				entry.Error = err.Error()
-			} else {
-				entry.Logs = logs
-				entry.Truncated = len(logs) > 0
-			}
-			result.Logs.Workers = append(result.Logs.Workers, entry)
-		}
-
-		// Collect from a failing pod
-		if len(result.Resources.Workers.FailingPods) > 0 {
-			pod := result.Resources.Workers.FailingPods[0]
-			logs, err := d.client.GetPodLogs(ctx, namespace, pod.Name, "alluxio-worker", d.tailLines)
-			entry := types.LogEntry{
-				PodName:       pod.Name,
-				ContainerName: "alluxio-worker",
-				TailLines:     d.tailLines,
-			}
-			if err != nil {
-				entry.Error = err.Error()
-			} else {
-				entry.Logs = logs
-				entry.Truncated = len(logs) > 0
-			}
-			result.Logs.Workers = append(result.Logs.Workers, entry)
-		}
-	}
-
-	// Collect fuse logs (failing pods only)
-	if result.Resources.Fuse != nil && len(result.Resources.Fuse.FailingPods) > 0 {
-		for i, pod := range result.Resources.Fuse.FailingPods {
-			if i >= maxLogsPerGroup {
-				break
-			}
-			logs, err := d.client.GetPodLogs(ctx, namespace, pod.Name, "alluxio-fuse", d.tailLines)
-			entry := types.LogEntry{
-				PodName:       pod.Name,
-				ContainerName: "alluxio-fuse",
-				TailLines:     d.tailLines,
-			}
-			if err != nil {
-				entry.Error = err.Error()
-			} else {
-				entry.Logs = logs
-				entry.Truncated = len(logs) > 0
-			}
-			result.Logs.Fuse = append(result.Logs.Fuse, entry)
-		}
-	}
-
-	return nil
-}
-
-// analyzeAndGenerateHints analyzes the diagnostic data and generates failure hints
-func (d *DatasetDiagnoser) analyzeAndGenerateHints(result *types.DiagnosticResult) {
-	// Check Dataset phase
-	datasetPhase := extractPhaseFromYAML(result.DatasetYAML)
-	if datasetPhase == "Pending" {
-		result.FailureHints = append(result.FailureHints, types.FailureHint{
-			Severity:   "warning",
-			Component:  "dataset",
-			Issue:      "Dataset is in Pending phase",
-			Suggestion: "Check if a matching Runtime CR exists and is healthy",
-		})
-	} else if datasetPhase == "Failed" {
-		result.FailureHints = append(result.FailureHints, types.FailureHint{
-			Severity:   "critical",
-			Component:  "dataset",
-			Issue:      "Dataset is in Failed phase",
-			Suggestion: "Check events and conditions for failure reason",
-		})
-	}
-
-	// Check Master status
-	if result.Resources.Master != nil && !result.Resources.Master.Healthy {
-		result.FailureHints = append(result.FailureHints, types.FailureHint{
-			Severity:   "critical",
-			Component:  "master",
-			Issue:      fmt.Sprintf("Master not healthy: %d/%d ready", result.Resources.Master.Ready, result.Resources.Master.Desired),
-			Suggestion: "Check master pod logs and events for errors",
-		})
-	}
-
-	// Check Worker status
-	if result.Resources.Workers != nil && !result.Resources.Workers.Healthy {
-		result.FailureHints = append(result.FailureHints, types.FailureHint{
-			Severity:   "warning",
-			Component:  "worker",
-			Issue:      fmt.Sprintf("Workers not healthy: %d/%d ready", result.Resources.Workers.Ready, result.Resources.Workers.Desired),
-			Suggestion: "Check worker pod logs and node resources",
-		})
-	}
-
-	// Check Fuse status
-	if result.Resources.Fuse != nil && !result.Resources.Fuse.Healthy {
-		result.FailureHints = append(result.FailureHints, types.FailureHint{
-			Severity:   "warning",
-			Component:  "fuse",
-			Issue:      fmt.Sprintf("Fuse not healthy: %d/%d ready", result.Resources.Fuse.Ready, result.Resources.Fuse.Desired),
-			Suggestion: "Check fuse pod logs and node selectors/tolerations",
-		})
-	}
-
-	// Check PVC status
-	if result.Resources.PVC != nil && result.Resources.PVC.Phase != "Bound" {
-		result.FailureHints = append(result.FailureHints, types.FailureHint{
-			Severity:   "critical",
-			Component:  "pvc",
-			Issue:      fmt.Sprintf("PVC is not bound: %s", result.Resources.PVC.Phase),
-			Suggestion: "Check if the Dataset is bound to a Runtime",
-		})
-	}
-
-	// Analyze events for patterns
-	warningCount := 0
-	for _, event := range result.Events {
-		if event.Type == "Warning" {
-			warningCount++
-			if strings.Contains(event.Message, "ImagePullBackOff") ||
-				strings.Contains(event.Message, "ErrImagePull") {
-				result.FailureHints = append(result.FailureHints, types.FailureHint{
-					Severity:   "critical",
-					Component:  event.ObjectKind,
-					Issue:      "Image pull failure detected",
-					Suggestion: "Check image name, tag, and registry credentials",
-					Evidence:   event.Message,
-				})
-			}
-			if strings.Contains(event.Message, "Insufficient") {
-				result.FailureHints = append(result.FailureHints, types.FailureHint{
-					Severity:   "warning",
-					Component:  event.ObjectKind,
-					Issue:      "Resource insufficiency detected",
-					Suggestion: "Check node resources and pod resource requests",
-					Evidence:   event.Message,
-				})
-			}
-			if strings.Contains(event.Message, "FailedMount") ||
-				strings.Contains(event.Message, "MountVolume") {
-				result.FailureHints = append(result.FailureHints, types.FailureHint{
-					Severity:   "critical",
-					Component:  event.ObjectKind,
-					Issue:      "Volume mount failure detected",
-					Suggestion: "Check PVC binding and CSI driver status",
-					Evidence:   event.Message,
-				})
-			}
-		}
-	}
-
-	// Check for high restart counts
-	checkRestartCounts := func(pods []types.PodStatus, component string) {
-		for _, pod := range pods {
-			if pod.RestartCount > 3 {
-				result.FailureHints = append(result.FailureHints, types.FailureHint{
-					Severity:   "warning",
-					Component:  component,
-					Issue:      fmt.Sprintf("High restart count (%d) for pod %s", pod.RestartCount, pod.Name),
-					Suggestion: "Check pod logs for crash reasons",
-				})
-			}
-		}
-	}
-
-	if result.Resources.Master != nil {
-		checkRestartCounts(result.Resources.Master.Pods, "master")
-		checkRestartCounts(result.Resources.Master.FailingPods, "master")
-	}
-	if result.Resources.Workers != nil {
-		checkRestartCounts(result.Resources.Workers.Pods, "worker")
-		checkRestartCounts(result.Resources.Workers.FailingPods, "worker")
-	}
-	if result.Resources.Fuse != nil {
-		checkRestartCounts(result.Resources.Fuse.Pods, "fuse")
-		checkRestartCounts(result.Resources.Fuse.FailingPods, "fuse")
-	}
-}
-
 // determineHealthStatus determines overall health based on analysis
-func (d *DatasetDiagnoser) determineHealthStatus(result *types.DiagnosticResult) types.HealthStatus {
+func determineHealthStatus(result *types.DiagnosticResult) types.HealthStatus {
 	hasCritical := false
 	hasWarning := false
 
 	for _, hint := range result.FailureHints {
-		if hint.Severity == "critical" {
+		if hint.Severity == SeverityCritical {
 			hasCritical = true
-		} else if hint.Severity == "warning" {
+		} else if hint.Severity == SeverityWarning {
 			hasWarning = true
 		}
 	}
@@ -556,11 +598,19 @@ func (d *DatasetDiagnoser) determineHealthStatus(result *types.DiagnosticResult)
 
 // ToContext converts the diagnostic result to an AI-ready context
 func (d *DatasetDiagnoser) ToContext(result *types.DiagnosticResult) *types.DiagnosticContext {
+	return ToContext(result)
+}
+
+// ToContext converts a diagnostic result, live or loaded from an archive, to an AI-ready context.
+// The result is expected to be redacted already, see redact.Redactor.Result.
+func ToContext(result *types.DiagnosticResult) *types.DiagnosticContext {
 	ctx := &types.DiagnosticContext{
 		DatasetYAML:  result.DatasetYAML,
 		RuntimeYAML:  result.RuntimeYAML,
+		CacheStatus:  result.CacheStatus,
 		Events:       result.Events,
 		FailureHints: result.FailureHints,
+		Apps:         result.Resources.Apps,
 		CollectedAt:  result.CollectedAt,
 		Version:      "1.0",
 		Logs:         make(map[string]string),
@@ -588,6 +638,21 @@ func (d *DatasetDiagnoser) ToContext(result *types.DiagnosticResult) *types.Diag
 	if result.Resources.PVC != nil {
 		ctx.Summary.PVCStatus = result.Resources.PVC.Phase
 	}
+	if apps := result.Resources.Apps; len(apps) > 0 {
+		ready := 0
+		for _, app := range apps {
+			if app.Ready {
+				ready++
+			}
+		}
+		ctx.Summary.AppsReady = fmt.Sprintf("%d/%d", ready, len(apps))
+	}
+
+	for _, step := range result.CollectionSteps {
+		if step.Status != types.StepStatusComplete || len(step.MissingPermissions) > 0 {
+			ctx.CollectionSteps = append(ctx.CollectionSteps, step)
+		}
+	}
 
 	// Count events
 	for _, event := range result.Events {
@@ -595,22 +660,41 @@ func (d *DatasetDiagnoser) ToContext(result *types.DiagnosticResult) *types.Diag
 			ctx.Summary.WarningCount++
 		}
 	}
-	ctx.Summary.ErrorCount = len(result.FailureHints)
+	// Count hints by severity; info hints are not counted
+	for _, hint := range result.FailureHints {
+		switch hint.Severity {
+		case SeverityCritical:
+			ctx.Summary.ErrorCount++
+		case SeverityWarning:
+			ctx.Summary.WarningHintCount++
+		}
+	}
 
-	// Normalize and truncate logs
+	// Add logs
 	if result.Logs.Master != nil && result.Logs.Master.Logs != "" {
-		ctx.Logs["master"] = normalizeLogs(result.Logs.Master.Logs)
+		ctx.Logs[result.Logs.Master.ContextKey("master")] = result.Logs.Master.Logs
+	}
+	for i, entry := range result.Logs.MasterExtra {
+		if entry.Logs != "" {
+			key := entry.ContextKey(fmt.Sprintf("master-%d", i+1))
+			ctx.Logs[key] = entry.Logs
+		}
 	}
 	for i, entry := range result.Logs.Workers {
 		if entry.Logs != "" {
-			key := fmt.Sprintf("worker-%d", i)
-			ctx.Logs[key] = normalizeLogs(entry.Logs)
+			key := entry.ContextKey(fmt.Sprintf("worker-%d", i))
+			ctx.Logs[key] = entry.Logs
 		}
 	}
 	for i, entry := range result.Logs.Fuse {
 		if entry.Logs != "" {
-			key := fmt.Sprintf("fuse-%d", i)
-			ctx.Logs[key] = normalizeLogs(entry.Logs)
+			key := entry.ContextKey(fmt.Sprintf("fuse-%d", i))
+			ctx.Logs[key] = entry.Logs
+		}
+	}
+	for i, entry := range result.Logs.ControlPlane {
+		if entry.Logs != "" {
+			ctx.Logs[fmt.Sprintf("controlplane-%d", i)] = entry.Logs
 		}
 	}
 
@@ -656,23 +740,3 @@ func isPodReady(pod *corev1.Pod) bool {
 	}
 	return false
 }
-
-func normalizeLogs(logs string) string {
-	// Remove potential secrets/sensitive data
-	lines := strings.Split(logs, "\n")
-	var cleaned []string
-	for _, line := range lines {
-		// Skip lines that might contain secrets
-		lower := strings.ToLower(line)
-		if strings.Contains(lower, "password") ||
-			strings.Contains(lower, "secret") ||
-			strings.Contains(lower, "token") ||
-			strings.Contains(lower, "api_key") ||
-			strings.Contains(lower, "apikey") {
-			cleaned = append(cleaned, "[REDACTED]")
-		} else {
-			cleaned = append(cleaned, line)
-		}
-	}
-	return strings.Join(cleaned, "\n")
-}
@@ -0,0 +1,578 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s/fake"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/redact"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	testNamespace = "default"
+	testDataset   = "demo"
+)
+
+// alluxioObjects returns the objects of an AlluxioRuntime-backed dataset with one
+// ready pod per component, and a ready Fluid control plane. Worker replicas beyond
+// the first are never ready.
+func alluxioObjects(workerReplicas int32) []runtime.Object {
+	objects := []runtime.Object{
+		fake.Dataset(testNamespace, testDataset, "Bound"),
+		fake.Runtime("AlluxioRuntime", testNamespace, testDataset, nil),
+		fake.StatefulSet(testNamespace, testDataset+"-master", 1, 1),
+		fake.StatefulSet(testNamespace, testDataset+"-worker", workerReplicas, 1),
+		fake.DaemonSet(testNamespace, testDataset+"-fuse", 1, 1),
+		fake.Pod(testNamespace, testDataset+"-master-0", testDataset, "alluxio-master", true),
+		fake.Pod(testNamespace, testDataset+"-worker-0", testDataset, "alluxio-worker", true),
+		fake.Pod(testNamespace, testDataset+"-fuse-abcde", testDataset, "alluxio-fuse", true),
+		fake.PVC(testNamespace, testDataset, "Bound", "default-demo"),
+		fake.PV("default-demo"),
+	}
+	return append(objects, fake.ControlPlane(k8s.FluidNamespace, "alluxioruntime-controller")...)
+}
+
+// labelledFuse returns a fuse DaemonSet and a failing fuse pod in namespace,
+// linked to the test dataset only by the Fluid dataset labels
+func labelledFuse(namespace string) []runtime.Object {
+	labels := map[string]string{k8s.LabelDataset: testDataset, k8s.LabelDatasetNamespace: testNamespace}
+	ds := fake.DaemonSet(namespace, testDataset+"-fuse", 1, 0)
+	ds.Labels = labels
+	pod := fake.Pod(namespace, testDataset+"-fuse-abcde", "", "alluxio-fuse", false)
+	for k, v := range labels {
+		pod.Labels[k] = v
+	}
+	return []runtime.Object{ds, pod}
+}
+
+// taintedNode returns a ready node with a NoSchedule taint
+func taintedNode(name, key, value string) *corev1.Node {
+	node := fake.Node(name, true)
+	node.Spec.Taints = []corev1.Taint{{Key: key, Value: value, Effect: corev1.TaintEffectNoSchedule}}
+	return node
+}
+
+// unscheduledWorkerObjects returns a dataset whose second worker is pending: the first
+// worker's node is cordoned and the other node lacks the worker node selector label
+func unscheduledWorkerObjects() []runtime.Object {
+	objects := alluxioObjects(2)
+	worker := objects[3].(*appsv1.StatefulSet)
+	worker.Spec.Template.Spec.NodeSelector = map[string]string{"fluid.io/worker": "true"}
+
+	cordoned := fake.Node("node-1", true)
+	cordoned.Labels["fluid.io/worker"] = "true"
+	cordoned.Spec.Unschedulable = true
+
+	pending := fake.Pod(testNamespace, testDataset+"-worker-1", testDataset, "alluxio-worker", false)
+	pending.Spec.NodeName = ""
+	pending.Status.Phase = corev1.PodPending
+
+	return append(objects, cordoned, fake.Node("node-2", true), pending)
+}
+
+func hasHint(hints []types.FailureHint, severity, component string) bool {
+	for _, h := range hints {
+		if h.Severity == severity && h.Component == component {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name       string
+		objects    []runtime.Object
+		wantErr    bool
+		wantHealth types.HealthStatus
+		check      func(t *testing.T, result *types.DiagnosticResult)
+	}{
+		{
+			name:    "dataset not found",
+			wantErr: true,
+		},
+		{
+			name:       "healthy alluxio dataset",
+			objects:    alluxioObjects(1),
+			wantHealth: types.HealthStatusHealthy,
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				if result.RuntimeType != "alluxioruntimes" {
+					t.Errorf("runtime type = %q, want alluxioruntimes", result.RuntimeType)
+				}
+				if len(result.FailureHints) != 0 {
+					t.Errorf("failure hints = %+v, want none", result.FailureHints)
+				}
+				if result.Resources.PV == nil || result.Resources.PV.Phase != "Bound" {
+					t.Errorf("pv = %+v, want Bound", result.Resources.PV)
+				}
+				if result.Logs.Master == nil || result.Logs.Master.ContainerName != "alluxio-master" {
+					t.Errorf("master logs = %+v, want alluxio-master container", result.Logs.Master)
+				}
+				if len(result.Logs.Fuse) != 0 {
+					t.Errorf("fuse logs = %+v, want none for healthy fuse", result.Logs.Fuse)
+				}
+			},
+		},
+		{
+			name: "crash looping worker",
+			objects: append(alluxioObjects(2),
+				fake.Pod(testNamespace, testDataset+"-worker-1", testDataset, "alluxio-worker", false),
+			),
+			wantHealth: types.HealthStatusDegraded,
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				workers := result.Resources.Workers
+				if workers == nil || len(workers.FailingPods) != 1 {
+					t.Fatalf("workers = %+v, want one failing pod", workers)
+				}
+				if workers.FailingPods[0].Reason != "CrashLoopBackOff" {
+					t.Errorf("failing pod reason = %q, want CrashLoopBackOff", workers.FailingPods[0].Reason)
+				}
+				if !hasHint(result.FailureHints, "warning", "worker") {
+					t.Errorf("failure hints = %+v, want worker warning", result.FailureHints)
+				}
+				if len(result.Logs.Workers) != 2 {
+					t.Errorf("worker logs = %d entries, want healthy and failing pod", len(result.Logs.Workers))
+				}
+			},
+		},
+		{
+			name: "pvc not bound",
+			objects: []runtime.Object{
+				fake.Dataset(testNamespace, testDataset, "NotBound"),
+				fake.PVC(testNamespace, testDataset, "Pending", ""),
+			},
+			wantHealth: types.HealthStatusUnhealthy,
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				if result.RuntimeYAML != "" {
+					t.Error("expected no runtime snapshot")
+				}
+				if !hasHint(result.FailureHints, "critical", "pvc") {
+					t.Errorf("failure hints = %+v, want critical pvc hint", result.FailureHints)
+				}
+			},
+		},
+		{
+			name: "mount failure event",
+			objects: append(alluxioObjects(1),
+				fake.Event(testNamespace, "Pod", testDataset+"-fuse-abcde", "Warning", "FailedMount",
+					"MountVolume.SetUp failed for volume \"data\""),
+				fake.Event(testNamespace, "Pod", "unrelated", "Warning", "BackOff", "unrelated pod"),
+			),
+			wantHealth: types.HealthStatusUnhealthy,
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				if len(result.Events) != 1 {
+					t.Errorf("events = %+v, want only the fuse pod event", result.Events)
+				}
+				if !hasHint(result.FailureHints, "critical", "Pod") {
+					t.Errorf("failure hints = %+v, want critical mount hint", result.FailureHints)
+				}
+			},
+		},
+		{
+			name: "juicefs runtime uses juicefs profile",
+			objects: []runtime.Object{
+				fake.Dataset(testNamespace, testDataset, "Bound"),
+				fake.Runtime("JuiceFSRuntime", testNamespace, testDataset, nil),
+				fake.StatefulSet(testNamespace, testDataset+"-worker", 1, 1),
+				fake.DaemonSet(testNamespace, testDataset+"-fuse", 1, 0),
+				fake.Pod(testNamespace, testDataset+"-worker-0", testDataset, "juicefs-worker", true),
+				fake.Pod(testNamespace, testDataset+"-fuse-abcde", testDataset, "juicefs-fuse", false),
+			},
+			wantHealth: types.HealthStatusDegraded,
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				if result.Resources.Master != nil {
+					t.Errorf("master = %+v, want nil for juicefs", result.Resources.Master)
+				}
+				if result.Resources.Workers == nil || len(result.Resources.Workers.Pods) != 1 {
+					t.Fatalf("workers = %+v, want one ready pod", result.Resources.Workers)
+				}
+				if len(result.Logs.Workers) != 1 || result.Logs.Workers[0].ContainerName != "juicefs-worker" {
+					t.Errorf("worker logs = %+v, want juicefs-worker container", result.Logs.Workers)
+				}
+				if len(result.Logs.Fuse) != 1 || result.Logs.Fuse[0].ContainerName != "juicefs-fuse" {
+					t.Errorf("fuse logs = %+v, want juicefs-fuse container", result.Logs.Fuse)
+				}
+			},
+		},
+		{
+			name: "thin runtime only has fuse",
+			objects: []runtime.Object{
+				fake.Dataset(testNamespace, testDataset, "Bound"),
+				fake.Runtime("ThinRuntime", testNamespace, testDataset, nil),
+				// A stray StatefulSet must not be attributed to a runtime without workers
+				fake.StatefulSet(testNamespace, testDataset+"-worker", 1, 0),
+				fake.DaemonSet(testNamespace, testDataset+"-fuse", 1, 1),
+				fake.Pod(testNamespace, testDataset+"-fuse-abcde", testDataset, "thin-fuse", true),
+			},
+			wantHealth: types.HealthStatusHealthy,
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				if result.Resources.Master != nil || result.Resources.Workers != nil {
+					t.Errorf("resources = %+v, want fuse only", result.Resources)
+				}
+				if result.Resources.Fuse == nil || len(result.Resources.Fuse.Pods) != 1 {
+					t.Errorf("fuse = %+v, want one ready pod", result.Resources.Fuse)
+				}
+			},
+		},
+		{
+			name: "application pod on a node without fuse",
+			objects: append(alluxioObjects(1),
+				fake.AppPod(testNamespace, "train-0", testDataset, "node-2", false),
+				fake.AppPod(testNamespace, "other", "other-pvc", "node-2", false),
+				fake.Event(testNamespace, "Pod", "train-0", "Warning", "FailedMount",
+					"MountVolume.SetUp failed for volume \"data\""),
+			),
+			wantHealth: types.HealthStatusUnhealthy,
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				apps := result.Resources.Apps
+				if len(apps) != 1 || apps[0].Name != "train-0" || apps[0].Volume != "data" {
+					t.Fatalf("apps = %+v, want train-0 mounting volume data", apps)
+				}
+				if apps[0].FuseReady || apps[0].Reason != "ContainerCreating" || len(apps[0].MountEvents) != 1 {
+					t.Errorf("app = %+v, want ContainerCreating without fuse and one mount event", apps[0])
+				}
+				for _, rule := range []string{RuleAppMountFailure, RuleAppWithoutFuse} {
+					found := false
+					for _, h := range result.FailureHints {
+						found = found || (h.RuleID == rule && h.Component == ComponentApp)
+					}
+					if !found {
+						t.Errorf("failure hints = %+v, want %s", result.FailureHints, rule)
+					}
+				}
+			},
+		},
+		{
+			name: "application pod on a fuse node",
+			objects: append(alluxioObjects(1),
+				fake.AppPod(testNamespace, "train-0", testDataset, "node-1", true),
+			),
+			wantHealth: types.HealthStatusHealthy,
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				apps := result.Resources.Apps
+				if len(apps) != 1 || !apps[0].FuseReady {
+					t.Fatalf("apps = %+v, want train-0 with a ready fuse", apps)
+				}
+				if len(result.Logs.Fuse) != 1 || result.Logs.Fuse[0].PodName != testDataset+"-fuse-abcde" {
+					t.Errorf("fuse logs = %+v, want the fuse pod on the application's node", result.Logs.Fuse)
+				}
+			},
+		},
+		{
+			name: "application pod on a node the fuse does not tolerate",
+			objects: append(alluxioObjects(1),
+				fake.Node("node-1", true),
+				taintedNode("node-2", "dedicated", "gpu"),
+				fake.AppPod(testNamespace, "train-0", testDataset, "node-2", false),
+			),
+			wantHealth: types.HealthStatusUnhealthy,
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				if len(result.Resources.Nodes) != 2 {
+					t.Fatalf("nodes = %+v, want node-1 and node-2", result.Resources.Nodes)
+				}
+				node := findNode(result.Resources.Nodes, "node-2")
+				if node == nil || node.Fuse == nil || node.Fuse.CanRun || len(node.AppPods) != 1 {
+					t.Fatalf("node-2 = %+v, want train-0 and no fuse placement", node)
+				}
+				for _, h := range result.FailureHints {
+					if h.RuleID == RuleAppWithoutFuse {
+						if !strings.Contains(h.Evidence, "taint dedicated=gpu:NoSchedule not tolerated") {
+							t.Errorf("evidence = %q, want the untolerated taint", h.Evidence)
+						}
+						return
+					}
+				}
+				t.Errorf("failure hints = %+v, want %s", result.FailureHints, RuleAppWithoutFuse)
+			},
+		},
+		{
+			name:       "unscheduled worker",
+			objects:    unscheduledWorkerObjects(),
+			wantHealth: types.HealthStatusDegraded,
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				want := map[string]string{
+					RuleNodeUnhealthy:       "Node node-1 is cordoned",
+					RuleWorkerUnschedulable: "node-2: node selector fluid.io/worker=true not matched",
+				}
+				for _, h := range result.FailureHints {
+					if text, ok := want[h.RuleID]; ok && strings.Contains(h.Issue+"\n"+h.Evidence, text) {
+						delete(want, h.RuleID)
+					}
+				}
+				if len(want) != 0 {
+					t.Errorf("failure hints = %+v, missing %v", result.FailureHints, want)
+				}
+			},
+		},
+		{
+			name:       "fuse discovered by labels in another namespace",
+			objects:    append(alluxioObjects(1)[:4], labelledFuse("fluid-system")...),
+			wantHealth: types.HealthStatusDegraded,
+			check: func(t *testing.T, result *types.DiagnosticResult) {
+				fuse := result.Resources.Fuse
+				if fuse == nil || fuse.Name != "demo-fuse" || len(fuse.FailingPods) != 1 {
+					t.Fatalf("fuse = %+v, want demo-fuse with one failing pod", fuse)
+				}
+				if ns := fuse.FailingPods[0].Namespace; ns != "fluid-system" {
+					t.Errorf("fuse pod namespace = %q, want fluid-system", ns)
+				}
+				if len(result.Logs.Fuse) != 1 || result.Logs.Fuse[0].Error != "" {
+					t.Errorf("fuse logs = %+v, want logs of the fluid-system pod", result.Logs.Fuse)
+				}
+				found := false
+				for _, ref := range result.Resources.Discovered {
+					if ref.Kind == "DaemonSet" && ref.Namespace == "fluid-system" && ref.Via == types.DiscoveredByLabel {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("discovered = %+v, want the labelled fuse DaemonSet", result.Resources.Discovered)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnoser := NewDatasetDiagnoser(fake.NewClient(tt.objects...))
+			result, err := diagnoser.Diagnose(context.Background(), testNamespace, testDataset)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.HealthStatus != tt.wantHealth {
+				t.Errorf("health = %s, want %s (hints: %+v)", result.HealthStatus, tt.wantHealth, result.FailureHints)
+			}
+			if tt.check != nil {
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+// hangingLogsClient never returns logs before its context is done
+type hangingLogsClient struct {
+	k8s.Interface
+}
+
+func (c hangingLogsClient) GetPodLogs(ctx context.Context, namespace, podName string, opts k8s.LogOptions) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func TestDiagnoseStepTimeout(t *testing.T) {
+	client := hangingLogsClient{fake.NewClient(alluxioObjects(1)...)}
+	diagnoser := NewDatasetDiagnoser(client).WithStepTimeout(50 * time.Millisecond)
+
+	result, err := diagnoser.Diagnose(context.Background(), testNamespace, testDataset)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	steps := map[string]types.StepStatus{}
+	for _, step := range result.CollectionSteps {
+		steps[step.Name] = step.Status
+	}
+	want := map[string]types.StepStatus{
+		ComponentSnapshots: types.StepStatusComplete,
+		ComponentEvents:    types.StepStatusComplete,
+		ComponentResources: types.StepStatusComplete,
+		ComponentLogs:      types.StepStatusPartial,
+	}
+	for name, status := range want {
+		if steps[name] != status {
+			t.Errorf("step %s = %q, want %q", name, steps[name], status)
+		}
+	}
+
+	if !hasHint(result.FailureHints, SeverityWarning, ComponentLogs) {
+		t.Errorf("expected a partial logs hint: %+v", result.FailureHints)
+	}
+	if result.Resources.Master == nil || result.Resources.Workers == nil {
+		t.Errorf("resources collected before the timeout are missing: %+v", result.Resources)
+	}
+}
+
+func TestDiagnoseRuntimeLookupError(t *testing.T) {
+	client := fake.NewClientDenying([]fake.Denied{{Verb: "get", Resource: "jindoruntimes"}}, alluxioObjects(1)...)
+	result, err := NewDatasetDiagnoser(client).Diagnose(context.Background(), testNamespace, testDataset)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.RuntimeType != "alluxioruntimes" {
+		t.Errorf("runtime type = %q, want alluxioruntimes", result.RuntimeType)
+	}
+	if !hasHint(result.FailureHints, SeverityWarning, ComponentSnapshots) {
+		t.Errorf("failure hints = %+v, want a runtime lookup warning", result.FailureHints)
+	}
+	if result.HealthStatus != types.HealthStatusDegraded {
+		t.Errorf("health = %s, want %s", result.HealthStatus, types.HealthStatusDegraded)
+	}
+}
+
+func TestDiagnoseRedactsSecrets(t *testing.T) {
+	objects := alluxioObjects(1)
+	dataset := objects[0].(*unstructured.Unstructured)
+	mount := dataset.Object["spec"].(map[string]interface{})["mounts"].([]interface{})[0].(map[string]interface{})
+	mount["options"] = map[string]interface{}{
+		"fs.s3a.endpoint":   "minio.local",
+		"fs.s3a.secret.key": "plain-secret",
+	}
+
+	policy, err := redact.NewPolicy(redact.Options{Patterns: []string{`minio\.local`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := NewDatasetDiagnoser(fake.NewClient(objects...)).WithRedaction(policy).
+		Diagnose(context.Background(), testNamespace, testDataset)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, leaked := range []string{"plain-secret", "minio.local"} {
+		if strings.Contains(result.DatasetYAML, leaked) {
+			t.Errorf("dataset YAML contains %q:\n%s", leaked, result.DatasetYAML)
+		}
+	}
+	if result.Redaction == nil || result.Redaction.Total != 2 {
+		t.Errorf("redaction report = %+v, want 2 masked values", result.Redaction)
+	}
+	if ctx := ToContext(result); ctx.DatasetYAML != result.DatasetYAML {
+		t.Error("context dataset YAML differs from the redacted result")
+	}
+}
+
+func TestToContextCountsHintsBySeverity(t *testing.T) {
+	result := &types.DiagnosticResult{
+		Events: []types.EventInfo{{Type: "Warning"}, {Type: "Normal"}},
+		FailureHints: []types.FailureHint{
+			{Severity: SeverityCritical},
+			{Severity: SeverityWarning},
+			{Severity: SeverityWarning},
+			{Severity: SeverityInfo},
+		},
+	}
+
+	summary := ToContext(result).Summary
+	if summary.ErrorCount != 1 || summary.WarningHintCount != 2 || summary.WarningCount != 1 {
+		t.Errorf("errors = %d, warning hints = %d, warning events = %d, want 1, 2 and 1",
+			summary.ErrorCount, summary.WarningHintCount, summary.WarningCount)
+	}
+}
+
+func TestDiagnoseManifests(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []string
+		want       []string
+	}{
+		{
+			name:       "all categories",
+			categories: ManifestCategories,
+			want: []string{
+				"manifests/csidrivers/" + k8s.CSIDriverName + ".yaml",
+				"manifests/daemonsets/default/demo-fuse.yaml",
+				"manifests/persistentvolumeclaims/default/demo.yaml",
+				"manifests/persistentvolumes/default-demo.yaml",
+				"manifests/pods/default/demo-fuse-abcde.yaml",
+				"manifests/pods/default/demo-master-0.yaml",
+				"manifests/pods/default/demo-worker-0.yaml",
+				"manifests/statefulsets/default/demo-master.yaml",
+				"manifests/statefulsets/default/demo-worker.yaml",
+			},
+		},
+		{
+			name:       "workloads only",
+			categories: []string{ManifestWorkloads},
+			want: []string{
+				"manifests/daemonsets/default/demo-fuse.yaml",
+				"manifests/statefulsets/default/demo-master.yaml",
+				"manifests/statefulsets/default/demo-worker.yaml",
+			},
+		},
+		{
+			name: "none by default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewDatasetDiagnoser(fake.NewClient(alluxioObjects(1)...)).WithManifests(tt.categories).
+				Diagnose(context.Background(), testNamespace, testDataset)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, m := range result.Manifests {
+				got = append(got, m.Path())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("manifests = %v, want %v", got, tt.want)
+			}
+			for _, m := range result.Manifests {
+				if m.Kind == k8s.KindStatefulSet && !strings.Contains(m.YAML, "apiVersion: apps/v1\nkind: StatefulSet\n") {
+					t.Errorf("%s has no apiVersion and kind:\n%s", m.Path(), m.YAML)
+				}
+				if strings.Contains(m.YAML, "managedFields") || strings.Contains(m.YAML, "resourceVersion") {
+					t.Errorf("%s is not cleaned:\n%s", m.Path(), m.YAML)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyzeKeepsCollectionHints(t *testing.T) {
+	result := &types.DiagnosticResult{
+		DatasetYAML: "status:\n  phase: Failed\n",
+		FailureHints: []types.FailureHint{
+			{Severity: "warning", Component: ComponentLogs, Issue: "Failed to collect some logs"},
+			{Severity: "critical", Component: "stale", Issue: "Hint from an older analysis"},
+		},
+	}
+
+	Analyze(result, nil)
+
+	if !hasHint(result.FailureHints, "warning", ComponentLogs) {
+		t.Errorf("collection hint was dropped: %+v", result.FailureHints)
+	}
+	if hasHint(result.FailureHints, "critical", "stale") {
+		t.Errorf("stale analysis hint was kept: %+v", result.FailureHints)
+	}
+	if !hasHint(result.FailureHints, "critical", "dataset") {
+		t.Errorf("expected failed dataset hint: %+v", result.FailureHints)
+	}
+	if result.HealthStatus != types.HealthStatusUnhealthy {
+		t.Errorf("health = %s, want Unhealthy", result.HealthStatus)
+	}
+}
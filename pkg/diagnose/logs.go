@@ -0,0 +1,248 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/parallel"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// LogPolicy controls which pods logs are collected from and how much of each log
+type LogPolicy struct {
+	// TailLines is the number of lines kept from the end of each log; negative keeps the whole log
+	TailLines int64
+	// Since keeps only the log lines newer than this duration; zero means no limit
+	Since time.Duration
+	// LimitBytes is the maximum size of each log; zero means no limit
+	LimitBytes int64
+	// AllPods collects logs from every pod, ignoring MaxPods
+	AllPods bool
+	// MaxPods is the number of pods sampled per component (master, worker, fuse)
+	MaxPods map[string]int
+	// PreferNodes lists nodes whose pods are sampled first. The nodes running the
+	// application pods mounting the Dataset are always preferred.
+	PreferNodes []string
+}
+
+// DefaultLogPolicy returns the policy used unless configured: the last 100 lines of
+// the master, two workers and two fuse pods
+func DefaultLogPolicy() LogPolicy {
+	return LogPolicy{
+		TailLines: 100,
+		MaxPods: map[string]int{
+			ComponentMaster: 1,
+			ComponentWorker: 2,
+			ComponentFuse:   2,
+		},
+	}
+}
+
+// Validate checks that the policy only uses known components and non-negative limits
+func (p *LogPolicy) Validate() error {
+	if p.Since < 0 {
+		return fmt.Errorf("since must not be negative, got %s", p.Since)
+	}
+	if p.LimitBytes < 0 {
+		return fmt.Errorf("limit bytes must not be negative, got %d", p.LimitBytes)
+	}
+	for component, n := range p.MaxPods {
+		switch component {
+		case ComponentMaster, ComponentWorker, ComponentFuse:
+		default:
+			return fmt.Errorf("unknown component %q in max pods, must be one of %s, %s, %s",
+				component, ComponentMaster, ComponentWorker, ComponentFuse)
+		}
+		if n < 0 {
+			return fmt.Errorf("max pods of %s must not be negative, got %d", component, n)
+		}
+	}
+	return nil
+}
+
+// maxPods returns the number of pods sampled for a component
+func (p *LogPolicy) maxPods(component string) int {
+	if n, ok := p.MaxPods[component]; ok {
+		return n
+	}
+	return DefaultLogPolicy().MaxPods[component]
+}
+
+// selectLogPods picks the pods of a component to collect logs from. Failing pods come
+// first, ranked by pods on preferred nodes, then by restart count. For the master and
+// workers one ready pod is kept for comparison when the limit allows; ready fuse pods
+// are only sampled on preferred nodes, where the application mounts the Dataset.
+func (p *LogPolicy) selectLogPods(component string, group *types.PodGroupStatus) []types.PodStatus {
+	if group == nil {
+		return nil
+	}
+
+	preferred := make(map[string]bool, len(p.PreferNodes))
+	for _, node := range p.PreferNodes {
+		preferred[node] = true
+	}
+	rank := func(pods []types.PodStatus) []types.PodStatus {
+		ranked := append([]types.PodStatus(nil), pods...)
+		sort.SliceStable(ranked, func(i, j int) bool {
+			a, b := ranked[i], ranked[j]
+			if preferred[a.NodeName] != preferred[b.NodeName] {
+				return preferred[a.NodeName]
+			}
+			if a.RestartCount != b.RestartCount {
+				return a.RestartCount > b.RestartCount
+			}
+			return a.Name < b.Name
+		})
+		return ranked
+	}
+
+	failing := rank(group.FailingPods)
+	ready := rank(group.Pods)
+	if component == ComponentFuse {
+		var onPreferred []types.PodStatus
+		for _, pod := range ready {
+			if preferred[pod.NodeName] {
+				onPreferred = append(onPreferred, pod)
+			}
+		}
+		if !p.AllPods {
+			ready = onPreferred
+		}
+	}
+
+	if p.AllPods {
+		return append(failing, ready...)
+	}
+
+	max := p.maxPods(component)
+	reserved := 0
+	if len(ready) > 0 && max > 1 && component != ComponentFuse {
+		reserved = 1
+	}
+	if len(failing) > max-reserved {
+		failing = failing[:max-reserved]
+	}
+	selected := failing
+	for _, pod := range ready {
+		if len(selected) >= max {
+			break
+		}
+		selected = append(selected, pod)
+	}
+	return selected
+}
+
+// logFetch is a container log to collect for a runtime component
+type logFetch struct {
+	component     string
+	namespace     string
+	pod           string
+	container     string
+	containerType string
+	previous      bool
+}
+
+// collectLogs fetches logs from the pods selected by the log policy concurrently
+func (d *DatasetDiagnoser) collectLogs(ctx context.Context, namespace string, profile *RuntimeProfile, result *types.DiagnosticResult) error {
+	policy := d.logPolicy
+	policy.PreferNodes = append(append([]string(nil), policy.PreferNodes...), appNodes(result)...)
+
+	var fetches []logFetch
+	groups := map[string]*types.PodGroupStatus{
+		ComponentMaster: result.Resources.Master,
+		ComponentWorker: result.Resources.Workers,
+		ComponentFuse:   result.Resources.Fuse,
+	}
+	for _, component := range profile.Components() {
+		for _, pod := range policy.selectLogPods(component.Name, groups[component.Name]) {
+			fetches = append(fetches, podLogFetches(component.Name, namespace, pod, component.ContainerName)...)
+		}
+	}
+
+	entries := make([]*types.LogEntry, len(fetches))
+	parallel.ForEach(ctx, len(fetches), d.concurrency, func(ctx context.Context, i int) {
+		entry := d.collectContainerLogs(ctx, fetches[i])
+		entries[i] = &entry
+	})
+
+	// Fetches not started before the step timed out leave no entry
+	for i, f := range fetches {
+		if entries[i] == nil {
+			continue
+		}
+		switch f.component {
+		case ComponentMaster:
+			if result.Logs.Master == nil && !f.previous && f.containerType == types.ContainerTypeMain {
+				result.Logs.Master = entries[i]
+			} else {
+				result.Logs.MasterExtra = append(result.Logs.MasterExtra, *entries[i])
+			}
+		case ComponentWorker:
+			result.Logs.Workers = append(result.Logs.Workers, *entries[i])
+		case ComponentFuse:
+			result.Logs.Fuse = append(result.Logs.Fuse, *entries[i])
+		}
+	}
+
+	return nil
+}
+
+// collectContainerLogs fetches a container's logs into a LogEntry, within the limits of the log policy
+func (d *DatasetDiagnoser) collectContainerLogs(ctx context.Context, fetch logFetch) types.LogEntry {
+	policy := &d.logPolicy
+	entry := types.LogEntry{
+		PodName:       fetch.pod,
+		ContainerName: fetch.container,
+		ContainerType: fetch.containerType,
+		Previous:      fetch.previous,
+		TailLines:     policy.TailLines,
+	}
+
+	logs, err := d.client.GetPodLogs(ctx, fetch.namespace, fetch.pod, k8s.LogOptions{
+		Container:  fetch.container,
+		TailLines:  policy.TailLines,
+		Since:      policy.Since,
+		LimitBytes: policy.LimitBytes,
+		Previous:   fetch.previous,
+	})
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Logs = logs
+		entry.Truncated = logTruncated(logs, policy.TailLines, policy.LimitBytes)
+	}
+
+	return entry
+}
+
+// logTruncated reports whether a log returned under the given limits hit one of them.
+// A log of exactly tailLines lines counts as truncated, since older lines may exist.
+func logTruncated(logs string, tailLines, limitBytes int64) bool {
+	if limitBytes > 0 && int64(len(logs)) >= limitBytes {
+		return true
+	}
+	if tailLines < 0 || logs == "" {
+		return false
+	}
+	return int64(strings.Count(strings.TrimSuffix(logs, "\n"), "\n")+1) >= tailLines
+}
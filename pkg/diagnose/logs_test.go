@@ -0,0 +1,129 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+func TestSelectLogPods(t *testing.T) {
+	pod := func(name, node string, restarts int32) types.PodStatus {
+		return types.PodStatus{Name: name, NodeName: node, RestartCount: restarts}
+	}
+	workers := &types.PodGroupStatus{
+		Pods: []types.PodStatus{pod("worker-0", "node-a", 0), pod("worker-1", "node-b", 1)},
+		FailingPods: []types.PodStatus{
+			pod("worker-2", "node-a", 1), pod("worker-3", "node-c", 7), pod("worker-4", "node-b", 3),
+		},
+	}
+	fuse := &types.PodGroupStatus{
+		Pods:        []types.PodStatus{pod("fuse-a", "node-a", 0), pod("fuse-b", "node-b", 0)},
+		FailingPods: []types.PodStatus{pod("fuse-c", "node-c", 2)},
+	}
+
+	tests := []struct {
+		name      string
+		policy    LogPolicy
+		component string
+		group     *types.PodGroupStatus
+		want      []string
+	}{
+		{
+			name:      "most restarted failing pods and a ready pod",
+			policy:    DefaultLogPolicy(),
+			component: ComponentWorker,
+			group:     workers,
+			want:      []string{"worker-3", "worker-1"},
+		},
+		{
+			name:      "larger sample",
+			policy:    LogPolicy{MaxPods: map[string]int{ComponentWorker: 4}},
+			component: ComponentWorker,
+			group:     workers,
+			want:      []string{"worker-3", "worker-4", "worker-2", "worker-1"},
+		},
+		{
+			name:      "preferred nodes first",
+			policy:    LogPolicy{MaxPods: map[string]int{ComponentWorker: 3}, PreferNodes: []string{"node-a"}},
+			component: ComponentWorker,
+			group:     workers,
+			want:      []string{"worker-2", "worker-3", "worker-0"},
+		},
+		{
+			name:      "ready fuse pods only on preferred nodes",
+			policy:    LogPolicy{PreferNodes: []string{"node-b"}},
+			component: ComponentFuse,
+			group:     fuse,
+			want:      []string{"fuse-c", "fuse-b"},
+		},
+		{
+			name:      "all pods",
+			policy:    LogPolicy{AllPods: true},
+			component: ComponentFuse,
+			group:     fuse,
+			want:      []string{"fuse-c", "fuse-a", "fuse-b"},
+		},
+		{
+			name:      "disabled component",
+			policy:    LogPolicy{MaxPods: map[string]int{ComponentWorker: 0}},
+			component: ComponentWorker,
+			group:     workers,
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, p := range tt.policy.selectLogPods(tt.component, tt.group) {
+				got = append(got, p.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selected pods = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogTruncated(t *testing.T) {
+	tests := []struct {
+		name       string
+		logs       string
+		tailLines  int64
+		limitBytes int64
+		want       bool
+	}{
+		{"fewer lines than the tail", "a\nb\n", 3, 0, false},
+		{"tail reached", "a\nb\nc\n", 3, 0, true},
+		{"tail reached without trailing newline", "a\nb\nc", 3, 0, true},
+		{"empty log", "", 3, 0, false},
+		{"whole log", "a\nb\nc\n", -1, 0, false},
+		{"byte limit reached", "abcdef", -1, 6, true},
+		{"under byte limit", "abc\n", 10, 6, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := logTruncated(tt.logs, tt.tailLines, tt.limitBytes); got != tt.want {
+				t.Errorf("logTruncated(%q, %d, %d) = %t, want %t", tt.logs, tt.tailLines, tt.limitBytes, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// Categories of the manifests collected for archives
+const (
+	ManifestWorkloads  = "workloads"  // StatefulSets, DaemonSets and Deployments
+	ManifestPods       = "pods"       // Runtime pods
+	ManifestConfigMaps = "configmaps" // ConfigMaps such as <dataset>-<runtime>-values
+	ManifestServices   = "services"   // Services such as the master Service
+	ManifestVolumes    = "volumes"    // The PVC of the Dataset and its PV
+	ManifestNodes      = "nodes"      // The nodes evaluated by the nodes step
+	ManifestCSIDriver  = "csidriver"  // The Fluid CSIDriver
+)
+
+// ManifestCategories lists every manifest category
+var ManifestCategories = []string{
+	ManifestWorkloads, ManifestPods, ManifestConfigMaps, ManifestServices,
+	ManifestVolumes, ManifestNodes, ManifestCSIDriver,
+}
+
+// manifestKind is the API version and category of a kind of collected object
+type manifestKind struct {
+	apiVersion string
+	category   string
+}
+
+var manifestKinds = map[string]manifestKind{
+	k8s.KindStatefulSet:     {"apps/v1", ManifestWorkloads},
+	k8s.KindDaemonSet:       {"apps/v1", ManifestWorkloads},
+	k8s.KindDeployment:      {"apps/v1", ManifestWorkloads},
+	"Pod":                   {"v1", ManifestPods},
+	"ConfigMap":             {"v1", ManifestConfigMaps},
+	"Service":               {"v1", ManifestServices},
+	"PersistentVolumeClaim": {"v1", ManifestVolumes},
+	"PersistentVolume":      {"v1", ManifestVolumes},
+	"Node":                  {"v1", ManifestNodes},
+	"CSIDriver":             {"storage.k8s.io/v1", ManifestCSIDriver},
+}
+
+// ValidateManifestCategories checks that every category is known
+func ValidateManifestCategories(categories []string) error {
+	for _, category := range categories {
+		if !contains(ManifestCategories, category) {
+			return fmt.Errorf("unknown manifest category %q (known: %s)", category, strings.Join(ManifestCategories, ", "))
+		}
+	}
+	return nil
+}
+
+// SelectManifests returns the manifests of the given categories
+func SelectManifests(manifests []types.Manifest, categories []string) []types.Manifest {
+	var selected []types.Manifest
+	for _, m := range manifests {
+		if contains(categories, manifestKinds[m.Kind].category) {
+			selected = append(selected, m)
+		}
+	}
+	return selected
+}
+
+// manifestCollector gathers the manifests of a diagnosis from concurrent steps. A nil
+// collector collects nothing.
+type manifestCollector struct {
+	categories []string
+
+	mu        sync.Mutex
+	manifests []types.Manifest
+}
+
+func newManifestCollector(categories []string) *manifestCollector {
+	if len(categories) == 0 {
+		return nil
+	}
+	return &manifestCollector{categories: categories}
+}
+
+// add records the cleaned YAML of an object if its category is collected. Objects read
+// through the clientset carry no apiVersion and kind, so both are set from the kind.
+func (c *manifestCollector) add(kind string, obj interface{}) {
+	info, ok := manifestKinds[kind]
+	if c == nil || !ok || !contains(c.categories, info.category) {
+		return
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		// API objects always convert
+		return
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetAPIVersion(info.apiVersion)
+	u.SetKind(kind)
+
+	cleaned := cleanManifest(u)
+	data, err := yaml.Marshal(cleaned)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.manifests = append(c.manifests, types.Manifest{Kind: kind, Namespace: u.GetNamespace(), Name: u.GetName(), YAML: string(data)})
+}
+
+// addGraph records the discovered workloads, pods, services and configmaps
+func (c *manifestCollector) addGraph(graph *k8s.ResourceGraph) {
+	for _, w := range graph.Workloads {
+		if w.Object != nil {
+			c.add(w.Kind, w.Object)
+		}
+	}
+	for i := range graph.Pods {
+		c.add("Pod", &graph.Pods[i])
+	}
+	for i := range graph.Services {
+		c.add("Service", &graph.Services[i])
+	}
+	for i := range graph.ConfigMaps {
+		c.add("ConfigMap", &graph.ConfigMaps[i])
+	}
+}
+
+// sorted returns the manifests sorted by archive path
+func (c *manifestCollector) sorted() []types.Manifest {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sort.Slice(c.manifests, func(i, j int) bool {
+		return c.manifests[i].Path() < c.manifests[j].Path()
+	})
+	return c.manifests
+}
+
+// cleanManifest removes the noisy metadata of an object, its last-applied configuration,
+// which repeats the object, and the image list of a node
+func cleanManifest(obj *unstructured.Unstructured) map[string]interface{} {
+	cleaned := cleanCRForDiagnosis(obj)
+	unstructured.RemoveNestedField(cleaned, "metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration")
+	if annotations, found, _ := unstructured.NestedMap(cleaned, "metadata", "annotations"); found && len(annotations) == 0 {
+		unstructured.RemoveNestedField(cleaned, "metadata", "annotations")
+	}
+	if obj.GetKind() == "Node" {
+		unstructured.RemoveNestedField(cleaned, "status", "images")
+	}
+	return cleaned
+}
@@ -47,6 +47,16 @@ func MockDiagnosticResult(datasetName, namespace string) *types.DiagnosticResult
 		RuntimeYAML: generateMockRuntimeYAML(datasetName, namespace),
 		RuntimeType: "alluxioruntimes",
 
+		// Cache statistics from the Dataset status and the tiered store
+		CacheStatus: &types.CacheStatus{
+			CacheCapacity:    "8.00GiB",
+			Cached:           "3.20GiB",
+			CachedPercentage: "2.5%",
+			CacheHitRatio:    "62.0%",
+			LowWaterMark:     "0.7",
+			HighWaterMark:    "0.95",
+		},
+
 		// Simulated events
 		Events: generateMockEvents(datasetName, now),
 
@@ -54,13 +64,33 @@ func MockDiagnosticResult(datasetName, namespace string) *types.DiagnosticResult
 		Resources: generateMockResources(datasetName),
 
 		// Collected logs
-		Logs: generateMockLogs(datasetName),
+		Logs: generateMockLogs(datasetName, namespace),
 
 		// Pre-computed failure hints
 		FailureHints: generateMockFailureHints(),
 
 		// Overall health
 		HealthStatus: types.HealthStatusDegraded,
+
+		// Every permission granted
+		Permissions: generateMockPermissions(namespace),
+
+		// Manifests of the objects backing the Dataset
+		Manifests: generateMockManifests(datasetName, namespace),
+
+		// Default collection settings on a recent cluster
+		Collection: &types.CollectionInfo{
+			ServerVersion: "v1.30.2",
+			Options: types.CollectionOptions{
+				StepTimeout:    "30s",
+				FluidNamespace: "fluid-system",
+				TailLines:      100,
+				MaxPods:        map[string]int{"master": 1, "worker": 2, "fuse": 2},
+			},
+		},
+
+		// Every step completed
+		CollectionSteps: generateMockSteps(),
 	}
 }
 
@@ -70,23 +100,27 @@ func MockDiagnosticContext(datasetName, namespace string) *types.DiagnosticConte
 
 	return &types.DiagnosticContext{
 		Summary: types.ContextSummary{
-			DatasetName:  datasetName,
-			Namespace:    namespace,
-			DatasetPhase: "Bound",
-			RuntimeType:  "alluxioruntimes",
-			HealthStatus: types.HealthStatusDegraded,
-			MasterReady:  "1/1",
-			WorkersReady: "1/2",
-			FuseReady:    "2/3",
-			PVCStatus:    "Bound",
-			ErrorCount:   3,
-			WarningCount: 5,
+			DatasetName:      datasetName,
+			Namespace:        namespace,
+			DatasetPhase:     "Bound",
+			RuntimeType:      "alluxioruntimes",
+			HealthStatus:     types.HealthStatusDegraded,
+			MasterReady:      "1/1",
+			WorkersReady:     "1/2",
+			FuseReady:        "2/3",
+			PVCStatus:        "Bound",
+			AppsReady:        "1/1",
+			ErrorCount:       2,
+			WarningCount:     4,
+			WarningHintCount: 3,
 		},
 		DatasetYAML:  result.DatasetYAML,
 		RuntimeYAML:  result.RuntimeYAML,
+		CacheStatus:  result.CacheStatus,
 		Events:       result.Events,
 		Logs:         convertLogsToMap(result.Logs),
 		FailureHints: result.FailureHints,
+		Apps:         result.Resources.Apps,
 		CollectedAt:  result.CollectedAt,
 		Version:      "1.0",
 	}
@@ -139,6 +173,11 @@ status:
     type: alluxio
   ufsTotal: 128.5GiB
   fileNum: "54321"
+  cacheStates:
+    cacheCapacity: 8.00GiB
+    cached: 3.20GiB
+    cachedPercentage: 2.5%%
+    cacheHitRatio: 62.0%%
 `, name, namespace, name, namespace, name, namespace)
 }
 
@@ -406,10 +445,201 @@ func generateMockResources(datasetName string) types.DiagnosticResources {
 			Capacity:      "100Gi",
 			ReclaimPolicy: "Delete",
 		},
+		Apps: []types.AppPodStatus{
+			{
+				PodStatus: types.PodStatus{
+					Name:           "train-job-0",
+					Phase:          "Running",
+					Ready:          true,
+					NodeName:       "node-1",
+					ContainerState: "Running",
+				},
+				Volume:    "data",
+				FuseReady: true,
+			},
+		},
+		ControlPlane: &types.ControlPlaneStatus{
+			Namespace: "fluid-system",
+			Components: []types.ControlPlaneComponent{
+				mockControlPlaneComponent(types.ControlPlaneDatasetController, "dataset-controller", "Deployment", 1),
+				mockControlPlaneComponent(types.ControlPlaneRuntimeController, "alluxioruntime-controller", "Deployment", 1),
+				mockControlPlaneComponent(types.ControlPlaneWebhook, "fluid-webhook", "Deployment", 1),
+				mockControlPlaneComponent(types.ControlPlaneCSINodePlugin, "csi-nodeplugin-fluid", "DaemonSet", 3),
+			},
+			CSIDriver: &types.CSIDriverStatus{Name: "fuse.csi.fluid.io", Found: true},
+			CSINodes: []types.CSINodeStatus{
+				{Node: "node-1", Pod: "csi-nodeplugin-fluid-2xkq7", Ready: true},
+			},
+		},
+		Nodes: []types.NodeDiagnostic{
+			{
+				Name:        "node-1",
+				Ready:       true,
+				CPU:         "3500m/4",
+				Memory:      "14Gi/16Gi",
+				RuntimePods: []string{datasetName + "-master-0", datasetName + "-worker-0", datasetName + "-fuse-abc12"},
+				AppPods:     []string{"train-job-0"},
+				Fuse:        &types.NodePlacement{Running: true, CanRun: true},
+				Worker:      &types.NodePlacement{Running: true, CanRun: true},
+			},
+			{
+				Name:        "node-2",
+				Ready:       true,
+				CPU:         "3/4",
+				Memory:      "15Gi/16Gi",
+				RuntimePods: []string{datasetName + "-fuse-def34"},
+				Fuse:        &types.NodePlacement{Running: true, CanRun: true},
+				Worker: &types.NodePlacement{
+					Reasons: []string{"insufficient memory: requests 4Gi, 1Gi free"},
+				},
+			},
+			{
+				Name:   "node-3",
+				Ready:  true,
+				CPU:    "500m/4",
+				Memory: "2Gi/16Gi",
+				Fuse: &types.NodePlacement{
+					Reasons: []string{"taint fluid.io/cache:NoSchedule not tolerated"},
+				},
+				Worker: &types.NodePlacement{
+					Reasons: []string{"taint fluid.io/cache:NoSchedule not tolerated"},
+				},
+			},
+		},
+	}
+}
+
+// mockControlPlaneComponent returns a healthy Fluid control-plane component
+func mockControlPlaneComponent(role, name, kind string, replicas int32) types.ControlPlaneComponent {
+	return types.ControlPlaneComponent{
+		Role:     role,
+		Relevant: true,
+		PodGroupStatus: types.PodGroupStatus{
+			Name:      name,
+			Kind:      kind,
+			Desired:   replicas,
+			Ready:     replicas,
+			Available: replicas,
+			Healthy:   true,
+		},
+	}
+}
+
+func generateMockPermissions(namespace string) *types.PermissionReport {
+	checks := []types.PermissionCheck{
+		{Permission: "get datasets.data.fluid.io -n " + namespace, Steps: []string{"snapshots"}},
+		{Permission: "get alluxioruntimes.data.fluid.io -n " + namespace, Steps: []string{"snapshots"}},
+		{Permission: "list statefulsets.apps -n " + namespace, Steps: []string{"discovery"}},
+		{Permission: "list daemonsets.apps -n " + namespace, Steps: []string{"discovery"}},
+		{Permission: "list pods -n " + namespace, Steps: []string{"discovery", "apps"}, RequiredBy: []string{"apps"}},
+		{Permission: "list events.events.k8s.io -n " + namespace, Steps: []string{"events", "apps"}, RequiredBy: []string{"events"}},
+		{Permission: "get persistentvolumeclaims -n " + namespace, Steps: []string{"resources"}},
+		{Permission: "get persistentvolumes", Steps: []string{"resources"}},
+		{Permission: "get nodes", Steps: []string{"nodes"}, RequiredBy: []string{"nodes"}},
+		{Permission: "list deployments.apps -n fluid-system", Steps: []string{"controlplane"}},
+		{Permission: "get pods/log -n " + namespace, Steps: []string{"logs"}, RequiredBy: []string{"logs"}},
+	}
+	for i := range checks {
+		checks[i].Allowed = true
+	}
+	return &types.PermissionReport{Checks: checks}
+}
+
+func generateMockManifests(datasetName, namespace string) []types.Manifest {
+	manifest := func(kind, ns, name, body string) types.Manifest {
+		header := fmt.Sprintf("kind: %s\nmetadata:\n  name: %s\n", kind, name)
+		if ns != "" {
+			header += fmt.Sprintf("  namespace: %s\n", ns)
+		}
+		return types.Manifest{Kind: kind, Namespace: ns, Name: name, YAML: header + body}
+	}
+	pvName := namespace + "-" + datasetName
+
+	return []types.Manifest{
+		manifest("ConfigMap", namespace, datasetName+"-alluxio-values", `apiVersion: v1
+data:
+  data: |
+    fullnameOverride: `+datasetName+`
+    properties:
+      alluxio.master.mount.table.root.ufs: cos://my-bucket.cos.ap-guangzhou.myqcloud.com/data
+      alluxio.user.file.writetype.default: MUST_CACHE
+`),
+		manifest("CSIDriver", "", "fuse.csi.fluid.io", `apiVersion: storage.k8s.io/v1
+spec:
+  attachRequired: false
+  podInfoOnMount: true
+`),
+		manifest("DaemonSet", namespace, datasetName+"-fuse", `apiVersion: apps/v1
+spec:
+  template:
+    spec:
+      nodeSelector:
+        fluid.io/f-`+namespace+`-`+datasetName+`: "true"
+status:
+  desiredNumberScheduled: 2
+  numberReady: 1
+`),
+		manifest("Node", "", "node-1", `apiVersion: v1
+status:
+  allocatable:
+    cpu: "8"
+    memory: 32Gi
+  conditions:
+  - status: "True"
+    type: Ready
+`),
+		manifest("PersistentVolume", "", pvName, `apiVersion: v1
+spec:
+  capacity:
+    storage: 100Pi
+  csi:
+    driver: fuse.csi.fluid.io
+    volumeHandle: `+pvName+`
+  persistentVolumeReclaimPolicy: Retain
+status:
+  phase: Bound
+`),
+		manifest("PersistentVolumeClaim", namespace, datasetName, `apiVersion: v1
+spec:
+  volumeName: `+pvName+`
+status:
+  phase: Bound
+`),
+		manifest("Service", namespace, datasetName+"-master-0", `apiVersion: v1
+spec:
+  clusterIP: None
+  ports:
+  - name: rpc
+    port: 19998
+`),
+		manifest("StatefulSet", namespace, datasetName+"-master", `apiVersion: apps/v1
+spec:
+  replicas: 1
+status:
+  readyReplicas: 1
+`),
+		manifest("StatefulSet", namespace, datasetName+"-worker", `apiVersion: apps/v1
+spec:
+  replicas: 2
+status:
+  readyReplicas: 1
+`),
+	}
+}
+
+func generateMockSteps() []types.CollectionStep {
+	durations := []struct{ name, duration string }{
+		{"snapshots", "41ms"}, {"discovery", "118ms"}, {"events", "96ms"}, {"resources", "73ms"},
+		{"apps", "88ms"}, {"nodes", "152ms"}, {"controlplane", "204ms"}, {"logs", "611ms"},
 	}
+	steps := make([]types.CollectionStep, len(durations))
+	for i, d := range durations {
+		steps[i] = types.CollectionStep{Name: d.name, Status: types.StepStatusComplete, Duration: d.duration}
+	}
+	return steps
 }
 
-func generateMockLogs(datasetName string) types.DiagnosticLogs {
+func generateMockLogs(datasetName, namespace string) types.DiagnosticLogs {
 	return types.DiagnosticLogs{
 		Master: &types.LogEntry{
 			PodName:       datasetName + "-master-0",
@@ -474,6 +704,19 @@ func generateMockLogs(datasetName string) types.DiagnosticLogs {
 2026-02-07 18:30:41 ERROR [main] AlluxioFuse - Cause: Unable to reach master, check network connectivity and master status`,
 			},
 		},
+		ControlPlane: []types.LogEntry{
+			{
+				PodName:       "alluxioruntime-controller-5d8f-x7k2p",
+				ContainerName: "manager",
+				ContainerType: types.ContainerTypeMain,
+				TailLines:     2000,
+				Filter:        namespace + "/" + datasetName,
+				Logs: `2026-02-07 18:30:02 INFO  alluxioctl Reconciling AlluxioRuntime {"request": "` + namespace + `/` + datasetName + `"}
+2026-02-07 18:30:03 INFO  alluxioctl Worker StatefulSet is not ready: 1/2 {"name": "` + datasetName + `", "namespace": "` + namespace + `"}
+2026-02-07 18:30:33 INFO  alluxioctl Requeue after 30s {"request": "` + namespace + `/` + datasetName + `"}
+`,
+			},
+		},
 	}
 }
 
@@ -0,0 +1,418 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/parallel"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// maxNodes bounds the nodes evaluated when every node is a candidate for unscheduled worker pods
+const maxNodes = 50
+
+// conditionTaints are the taints derived from node conditions and cordoning. They are
+// reported through the node status rather than as untolerated taints.
+var conditionTaints = map[string]bool{
+	corev1.TaintNodeNotReady:           true,
+	corev1.TaintNodeUnreachable:        true,
+	corev1.TaintNodeUnschedulable:      true,
+	corev1.TaintNodeMemoryPressure:     true,
+	corev1.TaintNodeDiskPressure:       true,
+	corev1.TaintNodePIDPressure:        true,
+	corev1.TaintNodeNetworkUnavailable: true,
+}
+
+// pressureConditions are the node conditions reporting resource pressure
+var pressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+}
+
+// collectNodes reports the nodes running the Dataset's runtime and application pods, and
+// whether fuse and worker pods can run on them. When worker pods are unscheduled, every
+// node is a candidate and up to maxNodes nodes are evaluated.
+func (d *DatasetDiagnoser) collectNodes(ctx context.Context, graph *k8s.ResourceGraph, manifests *manifestCollector, result *types.DiagnosticResult) error {
+	runtimePods := map[string][]string{}
+	fusePods := map[string]bool{}
+	workerPods := map[string]bool{}
+	unscheduled := false
+	for _, component := range []string{ComponentMaster, ComponentWorker, ComponentFuse} {
+		group := podGroup(&result.Resources, component)
+		if group == nil {
+			continue
+		}
+		for _, pods := range [][]types.PodStatus{group.Pods, group.FailingPods} {
+			for _, pod := range pods {
+				if pod.NodeName == "" {
+					unscheduled = unscheduled || component == ComponentWorker
+					continue
+				}
+				runtimePods[pod.NodeName] = append(runtimePods[pod.NodeName], pod.Name)
+				fusePods[pod.NodeName] = fusePods[pod.NodeName] || component == ComponentFuse
+				workerPods[pod.NodeName] = workerPods[pod.NodeName] || component == ComponentWorker
+			}
+		}
+	}
+	appPods := map[string][]string{}
+	for _, app := range result.Resources.Apps {
+		if app.NodeName != "" {
+			appPods[app.NodeName] = append(appPods[app.NodeName], app.Name)
+		}
+	}
+
+	relevant := map[string]bool{}
+	for name := range runtimePods {
+		relevant[name] = true
+	}
+	for name := range appPods {
+		relevant[name] = true
+	}
+
+	nodes, err := d.fetchNodes(ctx, relevant, unscheduled)
+	if len(nodes) == 0 {
+		return err
+	}
+	errs := []error{err}
+	for i := range nodes {
+		manifests.add("Node", &nodes[i])
+	}
+
+	var fuseSpec, workerSpec *corev1.PodSpec
+	if w := graph.Component(ComponentFuse); w != nil {
+		fuseSpec = &w.PodSpec
+	}
+	if w := graph.Component(ComponentWorker); w != nil {
+		workerSpec = &w.PodSpec
+	}
+
+	diagnostics := make([]types.NodeDiagnostic, len(nodes))
+	nodeErrs := make([]error, len(nodes))
+	parallel.ForEach(ctx, len(nodes), d.concurrency, func(ctx context.Context, i int) {
+		node := &nodes[i]
+		diag := nodeStatus(node)
+		diag.RuntimePods = runtimePods[node.Name]
+		diag.AppPods = appPods[node.Name]
+
+		// Without the pods of the node, resources are not checked
+		var free corev1.ResourceList
+		pods, err := d.client.ListPodsOnNode(ctx, node.Name)
+		if err != nil {
+			nodeErrs[i] = err
+		} else {
+			var requested corev1.ResourceList
+			free, requested = freeResources(node, pods)
+			diag.CPU = resourceUsage(requested, node.Status.Allocatable, corev1.ResourceCPU)
+			diag.Memory = resourceUsage(requested, node.Status.Allocatable, corev1.ResourceMemory)
+		}
+
+		if fuseSpec != nil {
+			diag.Fuse = evaluatePlacement(fuseSpec, true, node, free, fusePods[node.Name])
+		}
+		if workerSpec != nil {
+			diag.Worker = evaluatePlacement(workerSpec, false, node, free, workerPods[node.Name])
+		}
+		diagnostics[i] = diag
+	})
+
+	for i := range nodes {
+		// Nodes not evaluated before the step timed out are left out
+		if diagnostics[i].Name != "" {
+			result.Resources.Nodes = append(result.Resources.Nodes, diagnostics[i])
+		}
+	}
+	return errors.Join(append(errs, nodeErrs...)...)
+}
+
+// fetchNodes gets the relevant nodes, or lists every node when all are candidates.
+// Listed nodes are ordered relevant first, then by name, and capped at maxNodes.
+func (d *DatasetDiagnoser) fetchNodes(ctx context.Context, relevant map[string]bool, all bool) ([]corev1.Node, error) {
+	if all {
+		nodes, err := d.client.ListNodes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(nodes, func(i, j int) bool {
+			a, b := nodes[i].Name, nodes[j].Name
+			if relevant[a] != relevant[b] {
+				return relevant[a]
+			}
+			return a < b
+		})
+		if len(nodes) > maxNodes {
+			nodes = nodes[:maxNodes]
+		}
+		return nodes, nil
+	}
+
+	names := make([]string, 0, len(relevant))
+	for name := range relevant {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fetched := make([]*corev1.Node, len(names))
+	errs := make([]error, len(names))
+	parallel.ForEach(ctx, len(names), d.concurrency, func(ctx context.Context, i int) {
+		fetched[i], errs[i] = d.client.GetNode(ctx, names[i])
+		// A deleted node leaves its pods behind until they are garbage collected
+		if k8s.IsNotFound(errs[i]) {
+			errs[i] = nil
+		}
+	})
+
+	var nodes []corev1.Node
+	for _, node := range fetched {
+		if node != nil {
+			nodes = append(nodes, *node)
+		}
+	}
+	return nodes, errors.Join(errs...)
+}
+
+// nodeStatus reports the readiness, cordoning and resource pressure of a node
+func nodeStatus(node *corev1.Node) types.NodeDiagnostic {
+	diag := types.NodeDiagnostic{
+		Name:          node.Name,
+		Unschedulable: node.Spec.Unschedulable,
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			diag.Ready = cond.Status == corev1.ConditionTrue
+		}
+	}
+	for _, pressure := range pressureConditions {
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == pressure && cond.Status == corev1.ConditionTrue {
+				diag.Pressure = append(diag.Pressure, string(pressure))
+			}
+		}
+	}
+	return diag
+}
+
+// evaluatePlacement explains whether a pod with the given spec can run on a node. DaemonSet
+// pods tolerate cordoned nodes and resource pressure, as the DaemonSet controller adds the
+// matching tolerations. free is nil when the node's resource usage is unknown.
+func evaluatePlacement(spec *corev1.PodSpec, daemonSet bool, node *corev1.Node, free corev1.ResourceList, running bool) *types.NodePlacement {
+	var reasons []string
+	status := nodeStatus(node)
+
+	if !status.Ready {
+		reasons = append(reasons, "node is not ready")
+	}
+	if !daemonSet {
+		if status.Unschedulable {
+			reasons = append(reasons, "node is cordoned")
+		}
+		for _, pressure := range status.Pressure {
+			reasons = append(reasons, "node has "+pressure)
+		}
+	}
+
+	for key, value := range spec.NodeSelector {
+		if node.Labels[key] != value {
+			reasons = append(reasons, fmt.Sprintf("node selector %s=%s not matched", key, value))
+		}
+	}
+	if !matchesRequiredAffinity(spec.Affinity, node) {
+		reasons = append(reasons, "required node affinity not matched")
+	}
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule || conditionTaints[taint.Key] {
+			continue
+		}
+		if !tolerates(spec.Tolerations, taint) {
+			reasons = append(reasons, fmt.Sprintf("taint %s not tolerated", taintString(taint)))
+		}
+	}
+
+	// A running pod's requests are already counted in the node's usage
+	if free != nil && !running {
+		requests := podRequests(spec)
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			request, ok := requests[name]
+			if !ok || request.IsZero() {
+				continue
+			}
+			available := free[name]
+			if request.Cmp(available) > 0 {
+				reasons = append(reasons, fmt.Sprintf("insufficient %s: requests %s, %s free", name, request.String(), available.String()))
+			}
+		}
+	}
+
+	return &types.NodePlacement{
+		Running: running,
+		CanRun:  len(reasons) == 0,
+		Reasons: reasons,
+	}
+}
+
+// matchesRequiredAffinity reports whether a node satisfies the required node affinity of a
+// pod: any of its node selector terms, each requiring all of its expressions
+func matchesRequiredAffinity(affinity *corev1.Affinity, node *corev1.Node) bool {
+	if affinity == nil || affinity.NodeAffinity == nil ||
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if matchesNodeSelectorTerm(&term, node) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesNodeSelectorTerm(term *corev1.NodeSelectorTerm, node *corev1.Node) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+	for _, expr := range term.MatchExpressions {
+		if !matchesRequirement(expr, labels.Set(node.Labels)) {
+			return false
+		}
+	}
+	for _, field := range term.MatchFields {
+		if field.Key != "metadata.name" || !matchesRequirement(field, labels.Set{field.Key: node.Name}) {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeSelectorOperators maps node selector operators to label selector operators
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+func matchesRequirement(expr corev1.NodeSelectorRequirement, set labels.Set) bool {
+	op, ok := nodeSelectorOperators[expr.Operator]
+	if !ok {
+		return false
+	}
+	req, err := labels.NewRequirement(expr.Key, op, expr.Values)
+	if err != nil {
+		return false
+	}
+	return req.Matches(set)
+}
+
+// tolerates reports whether any of the tolerations tolerates the taint
+func tolerates(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for _, t := range tolerations {
+		if t.Effect != "" && t.Effect != taint.Effect {
+			continue
+		}
+		if t.Key != "" && t.Key != taint.Key {
+			continue
+		}
+		switch t.Operator {
+		case corev1.TolerationOpExists:
+			return true
+		case "", corev1.TolerationOpEqual:
+			if t.Key != "" && t.Value == taint.Value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func taintString(taint *corev1.Taint) string {
+	if taint.Value == "" {
+		return fmt.Sprintf("%s:%s", taint.Key, taint.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect)
+}
+
+// podRequests returns the CPU and memory requested by a pod's containers, or by its
+// largest init container when that is higher
+func podRequests(spec *corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, c := range spec.Containers {
+		for name, q := range c.Resources.Requests {
+			sum := requests[name]
+			sum.Add(q)
+			requests[name] = sum
+		}
+	}
+	for _, c := range spec.InitContainers {
+		for name, q := range c.Resources.Requests {
+			if current, ok := requests[name]; !ok || q.Cmp(current) > 0 {
+				requests[name] = q.DeepCopy()
+			}
+		}
+	}
+	return requests
+}
+
+// freeResources returns the allocatable resources of a node not requested by its pods, and the requested resources
+func freeResources(node *corev1.Node, pods []corev1.Pod) (free, requested corev1.ResourceList) {
+	requested = corev1.ResourceList{}
+	for i := range pods {
+		for name, q := range podRequests(&pods[i].Spec) {
+			sum := requested[name]
+			sum.Add(q)
+			requested[name] = sum
+		}
+	}
+
+	free = corev1.ResourceList{}
+	for name, allocatable := range node.Status.Allocatable {
+		q := allocatable.DeepCopy()
+		if r, ok := requested[name]; ok {
+			q.Sub(r)
+		}
+		free[name] = q
+	}
+	return free, requested
+}
+
+// resourceUsage formats the requested over allocatable amount of a resource, e.g. 1500m/4
+func resourceUsage(requested, allocatable corev1.ResourceList, name corev1.ResourceName) string {
+	total, ok := allocatable[name]
+	if !ok {
+		return ""
+	}
+	used := requested[name]
+	return used.String() + "/" + total.String()
+}
+
+// placementReasons returns the reasons a component cannot run on a node, joined for display
+func placementReasons(placement *types.NodePlacement) string {
+	if placement == nil {
+		return ""
+	}
+	return strings.Join(placement.Reasons, "; ")
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestEvaluatePlacement(t *testing.T) {
+	gpuNode := func() *corev1.Node {
+		node := fake.Node("node-1", true)
+		node.Labels["accelerator"] = "a100"
+		node.Spec.Taints = []corev1.Taint{
+			{Key: "nvidia.com/gpu", Effect: corev1.TaintEffectNoSchedule},
+			{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule},
+		}
+		return node
+	}
+	affinity := func(op corev1.NodeSelectorOperator, values ...string) *corev1.Affinity {
+		return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "accelerator", Operator: op, Values: values}},
+				}},
+			},
+		}}
+	}
+	tolerateGPU := []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}}
+	requesting := func(memory string) corev1.PodSpec {
+		return corev1.PodSpec{
+			Tolerations: tolerateGPU,
+			Containers: []corev1.Container{{Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)},
+			}}},
+		}
+	}
+	free := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("4Gi"),
+	}
+
+	tests := []struct {
+		name      string
+		spec      corev1.PodSpec
+		daemonSet bool
+		node      func() *corev1.Node
+		running   bool
+		want      []string
+	}{
+		{
+			name: "untolerated taint",
+			spec: corev1.PodSpec{},
+			node: gpuNode,
+			want: []string{"taint nvidia.com/gpu:NoSchedule not tolerated"},
+		},
+		{
+			name: "tolerated taint and matching selector",
+			spec: corev1.PodSpec{Tolerations: tolerateGPU, NodeSelector: map[string]string{"accelerator": "a100"}},
+			node: gpuNode,
+		},
+		{
+			name: "node selector not matched",
+			spec: corev1.PodSpec{Tolerations: tolerateGPU, NodeSelector: map[string]string{"accelerator": "v100"}},
+			node: gpuNode,
+			want: []string{"node selector accelerator=v100 not matched"},
+		},
+		{
+			name: "affinity matched",
+			spec: corev1.PodSpec{Tolerations: tolerateGPU, Affinity: affinity(corev1.NodeSelectorOpIn, "a100", "h100")},
+			node: gpuNode,
+		},
+		{
+			name: "affinity not matched",
+			spec: corev1.PodSpec{Tolerations: tolerateGPU, Affinity: affinity(corev1.NodeSelectorOpDoesNotExist)},
+			node: gpuNode,
+			want: []string{"required node affinity not matched"},
+		},
+		{
+			name: "cordoned node under pressure",
+			spec: corev1.PodSpec{Tolerations: tolerateGPU},
+			node: func() *corev1.Node {
+				node := gpuNode()
+				node.Spec.Unschedulable = true
+				node.Status.Conditions = append(node.Status.Conditions,
+					corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue})
+				return node
+			},
+			want: []string{"node is cordoned", "node has MemoryPressure"},
+		},
+		{
+			name:      "daemon set pods ignore cordoning",
+			spec:      corev1.PodSpec{Tolerations: tolerateGPU},
+			daemonSet: true,
+			node: func() *corev1.Node {
+				node := gpuNode()
+				node.Spec.Unschedulable = true
+				return node
+			},
+		},
+		{
+			name:      "not ready",
+			spec:      corev1.PodSpec{Tolerations: tolerateGPU},
+			daemonSet: true,
+			node:      func() *corev1.Node { return fake.Node("node-1", false) },
+			want:      []string{"node is not ready"},
+		},
+		{
+			name: "insufficient memory",
+			spec: requesting("8Gi"),
+			node: gpuNode,
+			want: []string{"insufficient memory: requests 8Gi, 4Gi free"},
+		},
+		{
+			name:    "running pod is already counted",
+			spec:    requesting("8Gi"),
+			node:    gpuNode,
+			running: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluatePlacement(&tt.spec, tt.daemonSet, tt.node(), free, tt.running)
+			if !reflect.DeepEqual(got.Reasons, tt.want) {
+				t.Errorf("reasons = %q, want %q", got.Reasons, tt.want)
+			}
+			if got.CanRun != (len(tt.want) == 0) || got.Running != tt.running {
+				t.Errorf("placement = %+v", got)
+			}
+		})
+	}
+}
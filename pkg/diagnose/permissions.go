@@ -0,0 +1,150 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// stepPermission is a permission a collection step uses. A step is skipped when a
+// permission it requires is denied; the other denied permissions leave it incomplete.
+type stepPermission struct {
+	step     string
+	perm     k8s.Permission
+	required bool
+}
+
+// pipelinePermissions lists the permissions of every collection step for a Dataset in
+// namespace, or for the Datasets of all namespaces when namespace is ""
+func pipelinePermissions(namespace, fluidNamespace, eventsGroup string, runtimes []k8s.RuntimeResource) []stepPermission {
+	perm := func(verb, group, resource, namespace string) k8s.Permission {
+		resource, subresource, _ := strings.Cut(resource, "/")
+		return k8s.Permission{Verb: verb, Group: group, Resource: resource, Subresource: subresource, Namespace: namespace}
+	}
+
+	// The Dataset itself is fetched before any step; without it the diagnosis fails
+	perms := []stepPermission{
+		{ComponentSnapshots, perm("get", k8s.FluidGroup, "datasets", namespace), false},
+	}
+	for _, r := range runtimes {
+		perms = append(perms, stepPermission{ComponentSnapshots, perm("get", k8s.FluidGroup, r.Resource, namespace), false})
+	}
+	for _, resource := range []string{"statefulsets", "daemonsets", "deployments", "replicasets"} {
+		perms = append(perms, stepPermission{ComponentDiscovery, perm("list", "apps", resource, namespace), false})
+	}
+	for _, resource := range []string{"services", "configmaps", "pods"} {
+		perms = append(perms, stepPermission{ComponentDiscovery, perm("list", "", resource, namespace), false})
+	}
+	perms = append(perms,
+		stepPermission{ComponentEvents, perm("list", eventsGroup, "events", namespace), true},
+		stepPermission{ComponentResources, perm("get", "", "persistentvolumeclaims", namespace), false},
+		stepPermission{ComponentResources, perm("get", "", "persistentvolumes", ""), false},
+		stepPermission{ComponentApps, perm("list", "", "pods", namespace), true},
+		stepPermission{ComponentApps, perm("list", eventsGroup, "events", namespace), false},
+		stepPermission{ComponentNodes, perm("get", "", "nodes", ""), true},
+		stepPermission{ComponentNodes, perm("list", "", "nodes", ""), false},
+		// The pods of every namespace count against the free resources of a node
+		stepPermission{ComponentNodes, perm("list", "", "pods", ""), false},
+	)
+	for _, resource := range []string{"deployments", "daemonsets", "replicasets"} {
+		perms = append(perms, stepPermission{ComponentControlPlane, perm("list", "apps", resource, fluidNamespace), false})
+	}
+	perms = append(perms,
+		stepPermission{ComponentControlPlane, perm("list", "", "pods", fluidNamespace), false},
+		stepPermission{ComponentControlPlane, perm("get", "", "pods/log", fluidNamespace), false},
+		stepPermission{ComponentControlPlane, perm("get", "storage.k8s.io", "csidrivers", ""), false},
+		stepPermission{ComponentLogs, perm("get", "", "pods/log", namespace), true},
+	)
+	return perms
+}
+
+// RequiredPermissions lists the permissions a diagnosis of the Datasets of namespace ("" for
+// all namespaces) uses, given the served runtime resources, the API group events are read
+// from and the namespace of the Fluid control plane
+func RequiredPermissions(namespace, fluidNamespace, eventsGroup string, runtimes []k8s.RuntimeResource) []k8s.Permission {
+	var perms []k8s.Permission
+	seen := map[k8s.Permission]bool{}
+	for _, p := range pipelinePermissions(namespace, fluidNamespace, eventsGroup, runtimes) {
+		if !seen[p.perm] {
+			seen[p.perm] = true
+			perms = append(perms, p.perm)
+		}
+	}
+	return perms
+}
+
+// CheckPermissions reviews the permissions the collection steps use for a Dataset in
+// namespace. When the review fails, the error is also recorded in the report.
+func (d *DatasetDiagnoser) CheckPermissions(ctx context.Context, namespace string) (*types.PermissionReport, error) {
+	// On a discovery error the built-in runtime kinds are checked
+	runtimes, _ := d.client.RuntimeResources()
+	stepPerms := pipelinePermissions(namespace, d.fluidNamespace, d.client.EventsGroup(), runtimes)
+
+	perms := RequiredPermissions(namespace, d.fluidNamespace, d.client.EventsGroup(), runtimes)
+	results, err := d.client.CheckAccess(ctx, perms)
+	if err != nil {
+		return &types.PermissionReport{Error: err.Error()}, err
+	}
+
+	report := &types.PermissionReport{}
+	for _, r := range results {
+		check := types.PermissionCheck{Permission: r.Permission.String(), Allowed: r.Allowed}
+		for _, p := range stepPerms {
+			if p.perm != r.Permission {
+				continue
+			}
+			if !contains(check.Steps, p.step) {
+				check.Steps = append(check.Steps, p.step)
+			}
+			if p.required {
+				check.RequiredBy = append(check.RequiredBy, p.step)
+			}
+		}
+		report.Checks = append(report.Checks, check)
+	}
+	return report, nil
+}
+
+// stepDenial is the denied permissions a step uses, and those it requires
+type stepDenial struct {
+	missing  []string
+	required []string
+}
+
+// deniedByStep maps each step to its denied permissions. A nil report denies nothing.
+func deniedByStep(report *types.PermissionReport) map[string]*stepDenial {
+	denied := map[string]*stepDenial{}
+	if report == nil {
+		return denied
+	}
+	for _, check := range report.Denied() {
+		for _, step := range check.Steps {
+			if denied[step] == nil {
+				denied[step] = &stepDenial{}
+			}
+			denied[step].missing = append(denied[step].missing, check.Permission)
+		}
+		for _, step := range check.RequiredBy {
+			denied[step].required = append(denied[step].required, check.Permission)
+		}
+	}
+	return denied
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s/fake"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+func TestDiagnosePermissions(t *testing.T) {
+	tests := []struct {
+		name   string
+		denied []fake.Denied
+		// want maps steps to their expected status and missing permissions
+		want map[string]types.CollectionStep
+	}{
+		{
+			name: "all granted",
+			want: map[string]types.CollectionStep{
+				ComponentLogs:   {Status: types.StepStatusComplete},
+				ComponentEvents: {Status: types.StepStatusComplete},
+			},
+		},
+		{
+			name:   "pod logs denied",
+			denied: []fake.Denied{{Verb: "get", Resource: "pods/log"}},
+			want: map[string]types.CollectionStep{
+				ComponentLogs: {
+					Status:             types.StepStatusSkipped,
+					MissingPermissions: []string{"get pods/log -n " + testNamespace},
+				},
+				// The control-plane logs are optional to the step
+				ComponentControlPlane: {
+					Status:             types.StepStatusComplete,
+					MissingPermissions: []string{"get pods/log -n " + k8s.FluidNamespace},
+				},
+			},
+		},
+		{
+			name:   "events denied",
+			denied: []fake.Denied{{Verb: "list", Resource: "events"}},
+			want: map[string]types.CollectionStep{
+				ComponentEvents: {
+					Status:             types.StepStatusSkipped,
+					MissingPermissions: []string{"list events.events.k8s.io -n " + testNamespace},
+				},
+				// The application pods are reported without their mount events
+				ComponentApps: {
+					Status:             types.StepStatusComplete,
+					MissingPermissions: []string{"list events.events.k8s.io -n " + testNamespace},
+				},
+			},
+		},
+		{
+			name:   "persistent volumes denied",
+			denied: []fake.Denied{{Verb: "get", Resource: "persistentvolumes"}},
+			want: map[string]types.CollectionStep{
+				ComponentResources: {
+					Status:             types.StepStatusFailed,
+					MissingPermissions: []string{"get persistentvolumes"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientDenying(tt.denied, alluxioObjects(1)...)
+			result, err := NewDatasetDiagnoser(client).Diagnose(context.Background(), testNamespace, testDataset)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Permissions == nil || result.Permissions.Error != "" {
+				t.Fatalf("permissions = %+v, want a report", result.Permissions)
+			}
+			if got := len(result.Permissions.Denied()) > 0; got != (len(tt.denied) > 0) {
+				t.Errorf("denied permissions = %+v", result.Permissions.Denied())
+			}
+
+			steps := map[string]types.CollectionStep{}
+			for _, step := range result.CollectionSteps {
+				steps[step.Name] = step
+			}
+			for name, want := range tt.want {
+				got := steps[name]
+				if got.Status != want.Status || !reflect.DeepEqual(got.MissingPermissions, want.MissingPermissions) {
+					t.Errorf("step %s = %s %v, want %s %v (error %q)", name, got.Status, got.MissingPermissions,
+						want.Status, want.MissingPermissions, got.Error)
+				}
+				if want.Status != types.StepStatusComplete && !hasHint(result.FailureHints, SeverityWarning, name) {
+					t.Errorf("no hint for step %s: %+v", name, result.FailureHints)
+				}
+			}
+		})
+	}
+}
+
+func TestRequiredPermissions(t *testing.T) {
+	runtimes := []k8s.RuntimeResource{{Kind: "AlluxioRuntime", Resource: "alluxioruntimes", Version: "v1alpha1"}}
+	perms := RequiredPermissions(k8s.FluidNamespace, k8s.FluidNamespace, "", runtimes)
+
+	seen := map[k8s.Permission]bool{}
+	for _, p := range perms {
+		if seen[p] {
+			t.Errorf("permission %s is listed twice", p)
+		}
+		seen[p] = true
+	}
+	for _, want := range []k8s.Permission{
+		{Verb: "get", Group: k8s.FluidGroup, Resource: "alluxioruntimes", Namespace: k8s.FluidNamespace},
+		{Verb: "get", Resource: "pods", Subresource: "log", Namespace: k8s.FluidNamespace},
+		{Verb: "list", Resource: "events", Namespace: k8s.FluidNamespace},
+		{Verb: "get", Resource: "nodes"},
+	} {
+		if !seen[want] {
+			t.Errorf("permission %s is missing", want)
+		}
+	}
+}
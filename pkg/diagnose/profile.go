@@ -0,0 +1,141 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+// Component names used in hints, log keys and archive file names
+const (
+	ComponentMaster = "master"
+	ComponentWorker = "worker"
+	ComponentFuse   = "fuse"
+	ComponentApp    = "app" // Application pods mounting the Dataset
+)
+
+// Components of hints recorded when a collection step fails or times out.
+// They are also the names of the collection steps.
+const (
+	ComponentSnapshots    = "snapshots"
+	ComponentDiscovery    = "discovery"
+	ComponentEvents       = "events"
+	ComponentResources    = "resources"
+	ComponentApps         = "apps"
+	ComponentNodes        = "nodes"
+	ComponentControlPlane = "controlplane"
+	ComponentLogs         = "logs"
+)
+
+// Workload kinds backing runtime components
+const (
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
+)
+
+// ComponentProfile describes how a single runtime component is deployed
+type ComponentProfile struct {
+	// Name is the component name (master, worker, fuse)
+	Name string
+	// Kind is the workload kind Fluid charts deploy for the component. Discovered
+	// workloads may differ, e.g. when a chart runs the worker as a Deployment.
+	Kind string
+	// ContainerName is the main container logs are collected from
+	ContainerName string
+}
+
+// RuntimeProfile describes the Kubernetes layout of a Fluid runtime type.
+// A nil component means the runtime does not deploy it.
+type RuntimeProfile struct {
+	// RuntimeType is the resource name returned by FindRuntime (e.g. alluxioruntimes)
+	RuntimeType string
+	Master      *ComponentProfile
+	Worker      *ComponentProfile
+	Fuse        *ComponentProfile
+}
+
+// Components returns the components deployed by the runtime, in master, worker, fuse order
+func (p *RuntimeProfile) Components() []*ComponentProfile {
+	var components []*ComponentProfile
+	for _, c := range []*ComponentProfile{p.Master, p.Worker, p.Fuse} {
+		if c != nil {
+			components = append(components, c)
+		}
+	}
+	return components
+}
+
+// defaultRuntimeType is used when the runtime type is unknown or no Runtime CR was found
+const defaultRuntimeType = "alluxioruntimes"
+
+var runtimeProfiles = map[string]*RuntimeProfile{}
+
+func init() {
+	// Runtimes with master, worker and fuse components share the same layout
+	for _, rt := range []struct {
+		runtimeType string
+		prefix      string
+	}{
+		{"alluxioruntimes", "alluxio"},
+		{"goosefsruntimes", "goosefs"},
+		{"jindoruntimes", "jindofs"},
+		{"vineyardruntimes", "vineyard"},
+	} {
+		RegisterRuntimeProfile(&RuntimeProfile{
+			RuntimeType: rt.runtimeType,
+			Master:      newComponentProfile(ComponentMaster, KindStatefulSet, rt.prefix),
+			Worker:      newComponentProfile(ComponentWorker, KindStatefulSet, rt.prefix),
+			Fuse:        newComponentProfile(ComponentFuse, KindDaemonSet, rt.prefix),
+		})
+	}
+
+	// JuiceFS keeps metadata in an external engine, so there is no master
+	RegisterRuntimeProfile(&RuntimeProfile{
+		RuntimeType: "juicefsruntimes",
+		Worker:      newComponentProfile(ComponentWorker, KindStatefulSet, "juicefs"),
+		Fuse:        newComponentProfile(ComponentFuse, KindDaemonSet, "juicefs"),
+	})
+
+	// EFC and Thin runtimes only deploy a fuse DaemonSet
+	RegisterRuntimeProfile(&RuntimeProfile{
+		RuntimeType: "efcruntimes",
+		Fuse:        newComponentProfile(ComponentFuse, KindDaemonSet, "efc"),
+	})
+	RegisterRuntimeProfile(&RuntimeProfile{
+		RuntimeType: "thinruntimes",
+		Fuse:        newComponentProfile(ComponentFuse, KindDaemonSet, "thin"),
+	})
+}
+
+// newComponentProfile builds a profile following the <prefix>-<component> naming used by Fluid charts
+func newComponentProfile(name, kind, prefix string) *ComponentProfile {
+	return &ComponentProfile{
+		Name:          name,
+		Kind:          kind,
+		ContainerName: prefix + "-" + name,
+	}
+}
+
+// RegisterRuntimeProfile adds or replaces the profile for a runtime type
+func RegisterRuntimeProfile(profile *RuntimeProfile) {
+	runtimeProfiles[profile.RuntimeType] = profile
+}
+
+// LookupRuntimeProfile returns the profile for a runtime type.
+// Unknown or empty runtime types fall back to the Alluxio layout.
+func LookupRuntimeProfile(runtimeType string) *RuntimeProfile {
+	if profile, ok := runtimeProfiles[runtimeType]; ok {
+		return profile
+	}
+	return runtimeProfiles[defaultRuntimeType]
+}
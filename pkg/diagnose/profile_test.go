@@ -0,0 +1,125 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+)
+
+// describeProfile lists the components of a profile as name:kind:container
+func describeProfile(p *RuntimeProfile) string {
+	var out []string
+	for _, c := range p.Components() {
+		out = append(out, c.Name+":"+c.Kind+":"+c.ContainerName)
+	}
+	return strings.Join(out, " ")
+}
+
+func TestLookupRuntimeProfile(t *testing.T) {
+	tests := []struct {
+		runtimeType string
+		wantType    string
+		want        string
+	}{
+		{
+			runtimeType: "alluxioruntimes",
+			wantType:    "alluxioruntimes",
+			want:        "master:StatefulSet:alluxio-master worker:StatefulSet:alluxio-worker fuse:DaemonSet:alluxio-fuse",
+		},
+		{
+			runtimeType: "goosefsruntimes",
+			wantType:    "goosefsruntimes",
+			want:        "master:StatefulSet:goosefs-master worker:StatefulSet:goosefs-worker fuse:DaemonSet:goosefs-fuse",
+		},
+		{
+			runtimeType: "jindoruntimes",
+			wantType:    "jindoruntimes",
+			want:        "master:StatefulSet:jindofs-master worker:StatefulSet:jindofs-worker fuse:DaemonSet:jindofs-fuse",
+		},
+		{
+			runtimeType: "vineyardruntimes",
+			wantType:    "vineyardruntimes",
+			want:        "master:StatefulSet:vineyard-master worker:StatefulSet:vineyard-worker fuse:DaemonSet:vineyard-fuse",
+		},
+		{
+			runtimeType: "juicefsruntimes",
+			wantType:    "juicefsruntimes",
+			want:        "worker:StatefulSet:juicefs-worker fuse:DaemonSet:juicefs-fuse",
+		},
+		{runtimeType: "efcruntimes", wantType: "efcruntimes", want: "fuse:DaemonSet:efc-fuse"},
+		{runtimeType: "thinruntimes", wantType: "thinruntimes", want: "fuse:DaemonSet:thin-fuse"},
+		{
+			runtimeType: "customruntimes",
+			wantType:    "alluxioruntimes",
+			want:        "master:StatefulSet:alluxio-master worker:StatefulSet:alluxio-worker fuse:DaemonSet:alluxio-fuse",
+		},
+		{
+			runtimeType: "",
+			wantType:    "alluxioruntimes",
+			want:        "master:StatefulSet:alluxio-master worker:StatefulSet:alluxio-worker fuse:DaemonSet:alluxio-fuse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.runtimeType, func(t *testing.T) {
+			profile := LookupRuntimeProfile(tt.runtimeType)
+			if profile.RuntimeType != tt.wantType {
+				t.Errorf("runtime type = %q, want %q", profile.RuntimeType, tt.wantType)
+			}
+			if got := describeProfile(profile); got != tt.want {
+				t.Errorf("components = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuntimeProfileWorkloadSuffixes(t *testing.T) {
+	// Fluid charts name the workloads <dataset>-<component>
+	for _, runtimeType := range []string{"alluxioruntimes", "juicefsruntimes", "thinruntimes"} {
+		profile := LookupRuntimeProfile(runtimeType)
+		graph := &k8s.ResourceGraph{}
+		for _, c := range profile.Components() {
+			graph.Workloads = append(graph.Workloads, &k8s.Workload{Kind: c.Kind, Name: "demo-" + c.Name})
+		}
+
+		for _, c := range profile.Components() {
+			w := graph.Component(c.Name)
+			if w == nil || w.Name != "demo-"+c.Name || w.Kind != c.Kind {
+				t.Errorf("%s: component %s matched workload %+v, want %s demo-%s", runtimeType, c.Name, w, c.Kind, c.Name)
+			}
+		}
+	}
+}
+
+func TestRegisterRuntimeProfile(t *testing.T) {
+	profile := &RuntimeProfile{
+		RuntimeType: "testruntimes",
+		Fuse:        newComponentProfile(ComponentFuse, KindDaemonSet, "test"),
+	}
+	RegisterRuntimeProfile(profile)
+	t.Cleanup(func() { delete(runtimeProfiles, profile.RuntimeType) })
+
+	if got := LookupRuntimeProfile("testruntimes"); got != profile {
+		t.Fatalf("LookupRuntimeProfile returned %+v, want the registered profile", got)
+	}
+	if got, want := describeProfile(profile), "fuse:DaemonSet:test-fuse"; got != want {
+		t.Errorf("components = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"fmt"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// Severity levels of failure hints
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// RuleDocBaseURL is the page documenting every built-in rule, anchored by rule ID
+const RuleDocBaseURL = "https://github.com/mrhapile/kubectl-fluid-inspect/blob/main/docs/rules.md"
+
+// Rule is a single diagnostic check evaluated against a collected result
+type Rule interface {
+	// ID is the stable identifier used to disable or reconfigure the rule
+	ID() string
+	// Title is a short human-readable description of what the rule detects
+	Title() string
+	// Severity is the default severity of hints emitted by the rule
+	Severity() string
+	// RuntimeTypes lists the runtime types (e.g. alluxioruntimes) the rule applies to; empty means all
+	RuntimeTypes() []string
+	// Evaluate returns the hints for every issue the rule detects
+	Evaluate(result *types.DiagnosticResult) []types.FailureHint
+}
+
+// RuleRegistry holds the rules run by Analyze together with their per-rule configuration
+type RuleRegistry struct {
+	rules    []Rule
+	byID     map[string]Rule
+	disabled map[string]bool
+	severity map[string]string
+}
+
+// NewRuleRegistry creates an empty RuleRegistry
+func NewRuleRegistry() *RuleRegistry {
+	return &RuleRegistry{
+		byID:     make(map[string]Rule),
+		disabled: make(map[string]bool),
+		severity: make(map[string]string),
+	}
+}
+
+// DefaultRuleRegistry creates a RuleRegistry holding the built-in rules
+func DefaultRuleRegistry() *RuleRegistry {
+	registry := NewRuleRegistry()
+	for _, rule := range builtinRules() {
+		// Built-in rule IDs are unique
+		_ = registry.Register(rule)
+	}
+	return registry
+}
+
+// Register adds a rule. Rule IDs must be unique.
+func (r *RuleRegistry) Register(rule Rule) error {
+	if _, exists := r.byID[rule.ID()]; exists {
+		return fmt.Errorf("rule %q is already registered", rule.ID())
+	}
+	if !IsValidSeverity(rule.Severity()) {
+		return fmt.Errorf("rule %q has invalid severity %q", rule.ID(), rule.Severity())
+	}
+	r.rules = append(r.rules, rule)
+	r.byID[rule.ID()] = rule
+	return nil
+}
+
+// Rules returns the registered rules in registration order
+func (r *RuleRegistry) Rules() []Rule {
+	return r.rules
+}
+
+// Disable stops a rule from being evaluated
+func (r *RuleRegistry) Disable(id string) error {
+	if _, ok := r.byID[id]; !ok {
+		return fmt.Errorf("unknown rule %q", id)
+	}
+	r.disabled[id] = true
+	return nil
+}
+
+// IsDisabled reports whether a rule has been disabled
+func (r *RuleRegistry) IsDisabled(id string) bool {
+	return r.disabled[id]
+}
+
+// SetSeverity overrides the severity of every hint emitted by a rule
+func (r *RuleRegistry) SetSeverity(id, severity string) error {
+	if _, ok := r.byID[id]; !ok {
+		return fmt.Errorf("unknown rule %q", id)
+	}
+	if !IsValidSeverity(severity) {
+		return fmt.Errorf("invalid severity %q for rule %q (must be critical, warning or info)", severity, id)
+	}
+	r.severity[id] = severity
+	return nil
+}
+
+// EffectiveSeverity returns the configured severity of a rule, or its default
+func (r *RuleRegistry) EffectiveSeverity(rule Rule) string {
+	if severity, ok := r.severity[rule.ID()]; ok {
+		return severity
+	}
+	return rule.Severity()
+}
+
+// Evaluate runs every enabled rule that applies to the result's runtime type.
+// Each hint is stamped with its rule ID, documentation URL and effective severity.
+func (r *RuleRegistry) Evaluate(result *types.DiagnosticResult) []types.FailureHint {
+	var hints []types.FailureHint
+	for _, rule := range r.rules {
+		if r.disabled[rule.ID()] || !ruleApplies(rule, result.RuntimeType) {
+			continue
+		}
+
+		override, overridden := r.severity[rule.ID()]
+		for _, hint := range rule.Evaluate(result) {
+			hint.RuleID = rule.ID()
+			if hint.DocURL == "" {
+				hint.DocURL = ruleDocURL(rule)
+			}
+			if overridden {
+				hint.Severity = override
+			} else if hint.Severity == "" {
+				hint.Severity = rule.Severity()
+			}
+			hints = append(hints, hint)
+		}
+	}
+	return hints
+}
+
+// ruleDocURL returns the documentation URL declared by a rule, falling back to docs/rules.md
+func ruleDocURL(rule Rule) string {
+	if documented, ok := rule.(interface{ DocURL() string }); ok {
+		return documented.DocURL()
+	}
+	return RuleDocURL(rule.ID())
+}
+
+// RuleDocURL returns the documentation URL of a built-in rule
+func RuleDocURL(id string) string {
+	return RuleDocBaseURL + "#" + id
+}
+
+// IsValidSeverity reports whether s is a known severity level
+func IsValidSeverity(s string) bool {
+	switch s {
+	case SeverityCritical, SeverityWarning, SeverityInfo:
+		return true
+	}
+	return false
+}
+
+func ruleApplies(rule Rule, runtimeType string) bool {
+	runtimeTypes := rule.RuntimeTypes()
+	if len(runtimeTypes) == 0 {
+		return true
+	}
+	for _, rt := range runtimeTypes {
+		if rt == runtimeType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,616 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/inspect"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// Built-in rule IDs. They are stable and documented in docs/rules.md.
+const (
+	RuleDatasetPending        = "dataset-pending"
+	RuleDatasetFailed         = "dataset-failed"
+	RuleMasterNotReady        = "master-not-ready"
+	RuleWorkerNotReady        = "worker-not-ready"
+	RuleFuseNotReady          = "fuse-not-ready"
+	RulePVCNotBound           = "pvc-not-bound"
+	RuleImagePullFailure      = "image-pull-failure"
+	RuleInsufficientResources = "insufficient-resources"
+	RuleVolumeMountFailure    = "volume-mount-failure"
+	RuleAppMountFailure       = "app-mount-failure"
+	RuleAppWithoutFuse        = "app-pod-without-fuse"
+	RuleNodeUnhealthy         = "node-unhealthy"
+	RuleWorkerUnschedulable   = "worker-unschedulable"
+	RuleControlPlaneNotFound  = "control-plane-not-found"
+	RuleControlPlaneNotReady  = "control-plane-not-ready"
+	RuleControlPlaneRestarts  = "control-plane-restarts"
+	RuleCSIDriverMissing      = "csi-driver-missing"
+	RuleCSINodePluginMissing  = "csi-nodeplugin-not-ready"
+	RuleHighRestartCount      = "high-restart-count"
+	RuleCacheHighWaterMark    = "cache-above-high-watermark"
+	RuleLowCacheHitRatio      = "low-cache-hit-ratio"
+)
+
+const (
+	// highRestartThreshold is the restart count above which a pod is reported
+	highRestartThreshold = 3
+	// defaultHighWaterMark applies when the runtime does not configure a tiered store watermark
+	defaultHighWaterMark = 0.95
+	// lowCacheHitRatio is the cache hit ratio below which the cache is reported as ineffective
+	lowCacheHitRatio = 0.5
+)
+
+// builtinRule is a Rule backed by an evaluation function
+type builtinRule struct {
+	id           string
+	title        string
+	severity     string
+	runtimeTypes []string
+	evaluate     func(result *types.DiagnosticResult, severity string) []types.FailureHint
+}
+
+func (r *builtinRule) ID() string             { return r.id }
+func (r *builtinRule) Title() string          { return r.title }
+func (r *builtinRule) Severity() string       { return r.severity }
+func (r *builtinRule) RuntimeTypes() []string { return r.runtimeTypes }
+
+func (r *builtinRule) Evaluate(result *types.DiagnosticResult) []types.FailureHint {
+	return r.evaluate(result, r.severity)
+}
+
+// builtinRules returns the rules shipped with the plugin, in evaluation order
+func builtinRules() []Rule {
+	return []Rule{
+		&builtinRule{
+			id:       RuleDatasetPending,
+			title:    "Dataset is in Pending phase",
+			severity: SeverityWarning,
+			evaluate: datasetPhaseRule("Pending", "Check if a matching Runtime CR exists and is healthy"),
+		},
+		&builtinRule{
+			id:       RuleDatasetFailed,
+			title:    "Dataset is in Failed phase",
+			severity: SeverityCritical,
+			evaluate: datasetPhaseRule("Failed", "Check events and conditions for failure reason"),
+		},
+		&builtinRule{
+			id:       RuleMasterNotReady,
+			title:    "Master pods are not all ready",
+			severity: SeverityCritical,
+			evaluate: podGroupRule(ComponentMaster, "Master", "Check master pod logs and events for errors"),
+		},
+		&builtinRule{
+			id:       RuleWorkerNotReady,
+			title:    "Worker pods are not all ready",
+			severity: SeverityWarning,
+			evaluate: podGroupRule(ComponentWorker, "Workers", "Check worker pod logs and node resources"),
+		},
+		&builtinRule{
+			id:       RuleFuseNotReady,
+			title:    "Fuse pods are not all ready",
+			severity: SeverityWarning,
+			evaluate: podGroupRule(ComponentFuse, "Fuse", "Check fuse pod logs and node selectors/tolerations"),
+		},
+		&builtinRule{
+			id:       RulePVCNotBound,
+			title:    "Dataset PVC is not bound",
+			severity: SeverityCritical,
+			evaluate: evaluatePVCNotBound,
+		},
+		&builtinRule{
+			id:       RuleImagePullFailure,
+			title:    "Warning event reports an image pull failure",
+			severity: SeverityCritical,
+			evaluate: warningEventRule([]string{"ImagePullBackOff", "ErrImagePull"},
+				"Image pull failure detected", "Check image name, tag, and registry credentials"),
+		},
+		&builtinRule{
+			id:       RuleInsufficientResources,
+			title:    "Warning event reports insufficient node resources",
+			severity: SeverityWarning,
+			evaluate: warningEventRule([]string{"Insufficient"},
+				"Resource insufficiency detected", "Check node resources and pod resource requests"),
+		},
+		&builtinRule{
+			id:       RuleVolumeMountFailure,
+			title:    "Warning event reports a volume mount failure",
+			severity: SeverityCritical,
+			evaluate: warningEventRule([]string{"FailedMount", "MountVolume"},
+				"Volume mount failure detected", "Check PVC binding and CSI driver status"),
+		},
+		&builtinRule{
+			id:       RuleAppMountFailure,
+			title:    "Application pod cannot mount the Dataset",
+			severity: SeverityCritical,
+			evaluate: evaluateAppMountFailure,
+		},
+		&builtinRule{
+			id:       RuleAppWithoutFuse,
+			title:    "Application pod runs on a node without a ready fuse pod",
+			severity: SeverityCritical,
+			evaluate: evaluateAppWithoutFuse,
+		},
+		&builtinRule{
+			id:       RuleNodeUnhealthy,
+			title:    "Node running Dataset pods is not ready, cordoned or under pressure",
+			severity: SeverityWarning,
+			evaluate: evaluateNodeUnhealthy,
+		},
+		&builtinRule{
+			id:       RuleWorkerUnschedulable,
+			title:    "No evaluated node can run the unscheduled worker pods",
+			severity: SeverityWarning,
+			evaluate: evaluateWorkerUnschedulable,
+		},
+		&builtinRule{
+			id:       RuleControlPlaneNotFound,
+			title:    "No Fluid control-plane component found in the Fluid namespace",
+			severity: SeverityInfo,
+			evaluate: evaluateControlPlaneNotFound,
+		},
+		&builtinRule{
+			id:       RuleControlPlaneNotReady,
+			title:    "Fluid controller, webhook or CSI plugin is missing or not ready",
+			severity: SeverityCritical,
+			evaluate: evaluateControlPlaneNotReady,
+		},
+		&builtinRule{
+			id:       RuleControlPlaneRestarts,
+			title:    fmt.Sprintf("Fluid control-plane pod restarted more than %d times", highRestartThreshold),
+			severity: SeverityWarning,
+			evaluate: evaluateControlPlaneRestarts,
+		},
+		&builtinRule{
+			id:       RuleCSIDriverMissing,
+			title:    "Fluid CSIDriver object is missing",
+			severity: SeverityCritical,
+			evaluate: evaluateCSIDriverMissing,
+		},
+		&builtinRule{
+			id:       RuleCSINodePluginMissing,
+			title:    "Node running application pods has no ready CSI nodeplugin",
+			severity: SeverityCritical,
+			evaluate: evaluateCSINodePluginMissing,
+		},
+		&builtinRule{
+			id:       RuleHighRestartCount,
+			title:    fmt.Sprintf("Runtime pod restarted more than %d times", highRestartThreshold),
+			severity: SeverityWarning,
+			evaluate: evaluateHighRestartCount,
+		},
+		&builtinRule{
+			id:       RuleCacheHighWaterMark,
+			title:    "Cache usage is above the high watermark",
+			severity: SeverityWarning,
+			evaluate: evaluateCacheHighWaterMark,
+		},
+		&builtinRule{
+			id:       RuleLowCacheHitRatio,
+			title:    fmt.Sprintf("Cache hit ratio is below %.0f%%", lowCacheHitRatio*100),
+			severity: SeverityInfo,
+			evaluate: evaluateLowCacheHitRatio,
+		},
+	}
+}
+
+func datasetPhaseRule(phase, suggestion string) func(*types.DiagnosticResult, string) []types.FailureHint {
+	return func(result *types.DiagnosticResult, severity string) []types.FailureHint {
+		if extractPhaseFromYAML(result.DatasetYAML) != phase {
+			return nil
+		}
+		return []types.FailureHint{{
+			Severity:   severity,
+			Component:  "dataset",
+			Issue:      fmt.Sprintf("Dataset is in %s phase", phase),
+			Suggestion: suggestion,
+		}}
+	}
+}
+
+func podGroupRule(component, label, suggestion string) func(*types.DiagnosticResult, string) []types.FailureHint {
+	return func(result *types.DiagnosticResult, severity string) []types.FailureHint {
+		group := podGroup(&result.Resources, component)
+		if group == nil || group.Healthy {
+			return nil
+		}
+		return []types.FailureHint{{
+			Severity:   severity,
+			Component:  component,
+			Issue:      fmt.Sprintf("%s not healthy: %d/%d ready", label, group.Ready, group.Desired),
+			Suggestion: suggestion,
+		}}
+	}
+}
+
+func evaluatePVCNotBound(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	pvc := result.Resources.PVC
+	if pvc == nil || pvc.Phase == "Bound" {
+		return nil
+	}
+	return []types.FailureHint{{
+		Severity:   severity,
+		Component:  "pvc",
+		Issue:      fmt.Sprintf("PVC is not bound: %s", pvc.Phase),
+		Suggestion: "Check if the Dataset is bound to a Runtime",
+	}}
+}
+
+// warningEventRule reports every Warning event whose message contains one of the patterns
+func warningEventRule(patterns []string, issue, suggestion string) func(*types.DiagnosticResult, string) []types.FailureHint {
+	return func(result *types.DiagnosticResult, severity string) []types.FailureHint {
+		var hints []types.FailureHint
+		for _, event := range result.Events {
+			if event.Type != "Warning" || !containsAny(event.Message, patterns) {
+				continue
+			}
+			hints = append(hints, types.FailureHint{
+				Severity:   severity,
+				Component:  event.ObjectKind,
+				Issue:      issue,
+				Suggestion: suggestion,
+				Evidence:   event.Message,
+			})
+		}
+		return hints
+	}
+}
+
+func evaluateAppMountFailure(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	var hints []types.FailureHint
+	for _, app := range result.Resources.Apps {
+		if len(app.MountEvents) == 0 {
+			continue
+		}
+		// Events are sorted most recent first
+		event := app.MountEvents[0]
+		hints = append(hints, types.FailureHint{
+			Severity:   severity,
+			Component:  ComponentApp,
+			Issue:      fmt.Sprintf("Pod %s cannot mount the Dataset (%s)", app.Name, event.Reason),
+			Suggestion: "Check the fuse pod and the Fluid CSI plugin on the pod's node",
+			Evidence:   event.Message,
+		})
+	}
+	return hints
+}
+
+func evaluateAppWithoutFuse(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	// Without the fuse status, a missing fuse pod cannot be told apart from a failed collection
+	if result.Resources.Fuse == nil {
+		return nil
+	}
+	var hints []types.FailureHint
+	for _, app := range result.Resources.Apps {
+		if app.NodeName == "" || app.FuseReady {
+			continue
+		}
+		hint := types.FailureHint{
+			Severity:   severity,
+			Component:  ComponentApp,
+			Issue:      fmt.Sprintf("No ready fuse pod on node %s running pod %s", app.NodeName, app.Name),
+			Suggestion: "Check that the fuse DaemonSet's node selector and tolerations allow the node, and the fuse pod logs",
+		}
+		if node := findNode(result.Resources.Nodes, app.NodeName); node != nil && node.Fuse != nil && !node.Fuse.CanRun {
+			hint.Suggestion = "Fuse pods cannot run on the node; see the evidence for why"
+			hint.Evidence = placementReasons(node.Fuse)
+		}
+		hints = append(hints, hint)
+	}
+	return hints
+}
+
+func evaluateNodeUnhealthy(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	var hints []types.FailureHint
+	for _, node := range result.Resources.Nodes {
+		if len(node.RuntimePods) == 0 && len(node.AppPods) == 0 {
+			continue
+		}
+		var problems []string
+		if !node.Ready {
+			problems = append(problems, "NotReady")
+		}
+		if node.Unschedulable {
+			problems = append(problems, "cordoned")
+		}
+		problems = append(problems, node.Pressure...)
+		if len(problems) == 0 {
+			continue
+		}
+		hints = append(hints, types.FailureHint{
+			Severity:   severity,
+			Component:  "node",
+			Issue:      fmt.Sprintf("Node %s is %s", node.Name, strings.Join(problems, ", ")),
+			Suggestion: "Check the node's conditions with kubectl describe node; its Dataset pods may be evicted or fail to start",
+			Evidence:   fmt.Sprintf("pods on the node: %s", strings.Join(append(node.RuntimePods, node.AppPods...), ", ")),
+		})
+	}
+	return hints
+}
+
+func evaluateWorkerUnschedulable(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	workers := result.Resources.Workers
+	if workers == nil {
+		return nil
+	}
+	unscheduled := 0
+	for _, pod := range workers.FailingPods {
+		if pod.NodeName == "" {
+			unscheduled++
+		}
+	}
+	if unscheduled == 0 {
+		return nil
+	}
+
+	var evidence []string
+	for _, node := range result.Resources.Nodes {
+		if node.Worker == nil {
+			continue
+		}
+		if node.Worker.CanRun {
+			// Another pod may still fit; the scheduler events tell the rest
+			return nil
+		}
+		if !node.Worker.Running {
+			evidence = append(evidence, fmt.Sprintf("%s: %s", node.Name, placementReasons(node.Worker)))
+		}
+	}
+	if len(evidence) == 0 {
+		return nil
+	}
+	return []types.FailureHint{{
+		Severity:   severity,
+		Component:  ComponentWorker,
+		Issue:      fmt.Sprintf("%d worker pod(s) unscheduled and no evaluated node can run them", unscheduled),
+		Suggestion: "Adjust the worker node selector, affinity, tolerations or resource requests, or add capacity",
+		Evidence:   strings.Join(evidence, "\n"),
+	}}
+}
+
+// controlPlaneFound returns the control-plane status when any control-plane component was found
+func controlPlaneFound(result *types.DiagnosticResult) *types.ControlPlaneStatus {
+	if cp := result.Resources.ControlPlane; cp != nil && len(cp.Components) > 0 {
+		return cp
+	}
+	return nil
+}
+
+func evaluateControlPlaneNotFound(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	cp := result.Resources.ControlPlane
+	if cp == nil || len(cp.Components) > 0 {
+		return nil
+	}
+	return []types.FailureHint{{
+		Severity:   severity,
+		Component:  "control-plane",
+		Issue:      fmt.Sprintf("No Fluid controller, webhook or CSI plugin found in namespace %s", cp.Namespace),
+		Suggestion: "If Fluid is installed in another namespace, pass it with --fluid-namespace",
+	}}
+}
+
+func evaluateControlPlaneNotReady(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	cp := controlPlaneFound(result)
+	if cp == nil {
+		return nil
+	}
+
+	var hints []types.FailureHint
+	found := map[string]bool{}
+	for _, c := range cp.Components {
+		if !c.Relevant {
+			continue
+		}
+		found[c.Role] = true
+		if c.Desired > 0 && c.Ready >= c.Desired {
+			continue
+		}
+		hint := types.FailureHint{
+			Severity:   severity,
+			Component:  c.Role,
+			Issue:      fmt.Sprintf("%s %s/%s is not ready: %d/%d ready", c.Kind, cp.Namespace, c.Name, c.Ready, c.Desired),
+			Suggestion: fmt.Sprintf("Check the pods and events of %s in %s", c.Name, cp.Namespace),
+		}
+		if c.Desired == 0 {
+			hint.Issue = fmt.Sprintf("%s %s/%s has no replicas or scheduled pods", c.Kind, cp.Namespace, c.Name)
+		}
+		if len(c.FailingPods) > 0 {
+			pod := c.FailingPods[0]
+			hint.Evidence = fmt.Sprintf("pod %s: %s %s", pod.Name, pod.Phase, pod.Reason)
+		}
+		hints = append(hints, hint)
+	}
+
+	required := []string{types.ControlPlaneDatasetController, types.ControlPlaneWebhook, types.ControlPlaneCSINodePlugin}
+	if result.RuntimeType != "" {
+		required = append(required, types.ControlPlaneRuntimeController)
+	}
+	for _, role := range required {
+		if !found[role] {
+			hints = append(hints, types.FailureHint{
+				Severity:   severity,
+				Component:  role,
+				Issue:      fmt.Sprintf("No %s found in namespace %s", role, cp.Namespace),
+				Suggestion: "Check the Fluid installation, e.g. helm status fluid, and that the runtime type is enabled",
+			})
+		}
+	}
+	return hints
+}
+
+func evaluateControlPlaneRestarts(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	cp := controlPlaneFound(result)
+	if cp == nil {
+		return nil
+	}
+
+	var hints []types.FailureHint
+	for _, c := range cp.Components {
+		if !c.Relevant {
+			continue
+		}
+		for _, pods := range [][]types.PodStatus{c.Pods, c.FailingPods} {
+			for _, pod := range pods {
+				if pod.RestartCount <= highRestartThreshold {
+					continue
+				}
+				hints = append(hints, types.FailureHint{
+					Severity:   severity,
+					Component:  c.Role,
+					Issue:      fmt.Sprintf("Pod %s/%s restarted %d times", cp.Namespace, pod.Name, pod.RestartCount),
+					Suggestion: "Check the previous logs of the pod for the crash reason, e.g. kubectl logs --previous",
+				})
+			}
+		}
+	}
+	return hints
+}
+
+func evaluateCSIDriverMissing(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	cp := controlPlaneFound(result)
+	if cp == nil || cp.CSIDriver == nil || cp.CSIDriver.Found {
+		return nil
+	}
+	return []types.FailureHint{{
+		Severity:   severity,
+		Component:  types.ControlPlaneCSINodePlugin,
+		Issue:      fmt.Sprintf("CSIDriver %s does not exist", cp.CSIDriver.Name),
+		Suggestion: "Reinstall or upgrade Fluid so its CSIDriver is registered; Dataset volumes cannot be mounted without it",
+	}}
+}
+
+func evaluateCSINodePluginMissing(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	cp := controlPlaneFound(result)
+	if cp == nil {
+		return nil
+	}
+
+	var hints []types.FailureHint
+	for _, node := range cp.CSINodes {
+		if node.Ready {
+			continue
+		}
+		hint := types.FailureHint{
+			Severity:   severity,
+			Component:  types.ControlPlaneCSINodePlugin,
+			Issue:      fmt.Sprintf("No ready CSI nodeplugin on node %s running application pods", node.Node),
+			Suggestion: "Check the csi-nodeplugin DaemonSet's node selector and tolerations, and its pod logs on the node",
+			Evidence:   fmt.Sprintf("pod %s is not ready", node.Pod),
+		}
+		if node.Pod == "" {
+			hint.Evidence = "no nodeplugin pod is scheduled on the node"
+		}
+		hints = append(hints, hint)
+	}
+	return hints
+}
+
+// findNode returns the diagnostic of the named node, or nil if it was not collected
+func findNode(nodes []types.NodeDiagnostic, name string) *types.NodeDiagnostic {
+	for i := range nodes {
+		if nodes[i].Name == name {
+			return &nodes[i]
+		}
+	}
+	return nil
+}
+
+func evaluateHighRestartCount(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	var hints []types.FailureHint
+	for _, component := range []string{ComponentMaster, ComponentWorker, ComponentFuse} {
+		group := podGroup(&result.Resources, component)
+		if group == nil {
+			continue
+		}
+		for _, pods := range [][]types.PodStatus{group.Pods, group.FailingPods} {
+			for _, pod := range pods {
+				if pod.RestartCount <= highRestartThreshold {
+					continue
+				}
+				hints = append(hints, types.FailureHint{
+					Severity:   severity,
+					Component:  component,
+					Issue:      fmt.Sprintf("High restart count (%d) for pod %s", pod.RestartCount, pod.Name),
+					Suggestion: "Check pod logs for crash reasons",
+				})
+			}
+		}
+	}
+	return hints
+}
+
+func evaluateCacheHighWaterMark(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	usage, ok := inspect.CacheUsage(result.CacheStatus)
+	if !ok {
+		return nil
+	}
+	highWaterMark, ok := inspect.ParseRatio(result.CacheStatus.HighWaterMark)
+	if !ok {
+		highWaterMark = defaultHighWaterMark
+	}
+	if usage < highWaterMark {
+		return nil
+	}
+	return []types.FailureHint{{
+		Severity:   severity,
+		Component:  "cache",
+		Issue:      fmt.Sprintf("Cache usage %.1f%% is above the high watermark %.1f%%", usage*100, highWaterMark*100),
+		Suggestion: "Increase the tiered store quota or add workers; data is being evicted from the cache",
+		Evidence:   fmt.Sprintf("cached %s of %s", result.CacheStatus.Cached, result.CacheStatus.CacheCapacity),
+	}}
+}
+
+func evaluateLowCacheHitRatio(result *types.DiagnosticResult, severity string) []types.FailureHint {
+	if result.CacheStatus == nil {
+		return nil
+	}
+	ratio, ok := inspect.ParseRatio(result.CacheStatus.CacheHitRatio)
+	if !ok || ratio >= lowCacheHitRatio {
+		return nil
+	}
+	return []types.FailureHint{{
+		Severity:   severity,
+		Component:  "cache",
+		Issue:      fmt.Sprintf("Cache hit ratio is %s", result.CacheStatus.CacheHitRatio),
+		Suggestion: "Warm up the cache with a DataLoad or check that the cache is large enough for the working set",
+	}}
+}
+
+// podGroup returns the pod group collected for a runtime component
+func podGroup(resources *types.DiagnosticResources, component string) *types.PodGroupStatus {
+	switch component {
+	case ComponentMaster:
+		return resources.Master
+	case ComponentWorker:
+		return resources.Workers
+	case ComponentFuse:
+		return resources.Fuse
+	}
+	return nil
+}
+
+func containsAny(s string, patterns []string) bool {
+	for _, p := range patterns {
+		if strings.Contains(s, p) {
+			return true
+		}
+	}
+	return false
+}
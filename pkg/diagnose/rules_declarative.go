@@ -0,0 +1,569 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// RuleFile is the on-disk format of declarative rules
+type RuleFile struct {
+	Rules []RuleSpec `json:"rules"`
+}
+
+// RuleSpec declares a rule matching collected data and the hint it emits.
+//
+// Field conditions must all match for the rule to fire. The rule then emits one
+// hint per matching event, log entry and pod. A rule with only field conditions
+// emits a single hint.
+type RuleSpec struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	Severity     string    `json:"severity"`
+	RuntimeTypes []string  `json:"runtimeTypes,omitempty"`
+	DocURL       string    `json:"docUrl,omitempty"`
+	Match        MatchSpec `json:"match"`
+	Hint         HintSpec  `json:"hint"`
+}
+
+// MatchSpec selects the data a declarative rule matches. Patterns are RE2 regular expressions.
+type MatchSpec struct {
+	Fields []FieldMatch `json:"fields,omitempty"`
+	Events *EventMatch  `json:"events,omitempty"`
+	Logs   *LogMatch    `json:"logs,omitempty"`
+	Pods   *PodMatch    `json:"pods,omitempty"`
+}
+
+// FieldMatch matches a field of the Dataset or Runtime CR, e.g. status.phase. Keys
+// containing dots are quoted in brackets, e.g. metadata.labels["fluid.io/dataset"].
+type FieldMatch struct {
+	Object  string `json:"object"` // dataset, runtime
+	Path    string `json:"path"`
+	Pattern string `json:"pattern"`
+
+	segments []string
+	pattern  *regexp.Regexp
+}
+
+// EventMatch matches Kubernetes events
+type EventMatch struct {
+	Type    string `json:"type,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	reason, message *regexp.Regexp
+}
+
+// LogMatch matches collected log lines of a component
+type LogMatch struct {
+	Component string `json:"component,omitempty"` // master, worker, fuse; empty matches all
+	Line      string `json:"line"`
+
+	line *regexp.Regexp
+}
+
+// PodMatch matches the status of runtime pods
+type PodMatch struct {
+	Component      string `json:"component,omitempty"` // master, worker, fuse; empty matches all
+	Phase          string `json:"phase,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+	ContainerState string `json:"containerState,omitempty"`
+
+	phase, reason, containerState *regexp.Regexp
+}
+
+// HintSpec holds the text/template strings rendered into the emitted FailureHint
+type HintSpec struct {
+	Component  string `json:"component,omitempty"`
+	Issue      string `json:"issue"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Evidence   string `json:"evidence,omitempty"`
+
+	issue, suggestion, evidence *template.Template
+}
+
+// RuleMatch is the data available to hint templates
+type RuleMatch struct {
+	Dataset     string
+	Namespace   string
+	RuntimeType string
+	Component   string
+	Pod         string
+	Container   string
+	// Line is the matched log line
+	Line string
+	// Event is the matched event
+	Event types.EventInfo
+	// PodStatus is the matched pod
+	PodStatus types.PodStatus
+	// Value is the value of the last field condition
+	Value string
+	// Groups holds the submatches of the pattern that matched last
+	Groups []string
+}
+
+// declarativeRule is a Rule loaded from a rule file
+type declarativeRule struct {
+	spec RuleSpec
+}
+
+func (r *declarativeRule) ID() string             { return r.spec.ID }
+func (r *declarativeRule) Title() string          { return r.spec.Title }
+func (r *declarativeRule) Severity() string       { return r.spec.Severity }
+func (r *declarativeRule) RuntimeTypes() []string { return r.spec.RuntimeTypes }
+
+// DocURL returns the documentation URL declared by the rule, if any
+func (r *declarativeRule) DocURL() string { return r.spec.DocURL }
+
+var ruleIDPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// NewDeclarativeRule validates a rule spec and compiles its patterns and templates
+func NewDeclarativeRule(spec RuleSpec) (Rule, error) {
+	var errs []error
+	addErr := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+	// Templates are checked against a match holding as many groups as the largest pattern
+	sample := &RuleMatch{Groups: []string{""}}
+	compile := func(field, pattern string) *regexp.Regexp {
+		if pattern == "" {
+			return nil
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			addErr("%s: %v", field, err)
+			return nil
+		}
+		for len(sample.Groups) <= re.NumSubexp() {
+			sample.Groups = append(sample.Groups, "")
+		}
+		return re
+	}
+	parse := func(field, text string) *template.Template {
+		tmpl, err := template.New(field).Parse(text)
+		if err != nil {
+			addErr("%s: %v", field, err)
+			return nil
+		}
+		// Execute against sample data so references to unknown fields are reported now
+		if err := tmpl.Execute(&bytes.Buffer{}, sample); err != nil {
+			addErr("%s: %v", field, err)
+			return nil
+		}
+		return tmpl
+	}
+
+	if !ruleIDPattern.MatchString(spec.ID) {
+		addErr("id %q must be lowercase words separated by dashes", spec.ID)
+	}
+	if spec.Title == "" {
+		addErr("title is required")
+	}
+	if !IsValidSeverity(spec.Severity) {
+		addErr("severity %q must be critical, warning or info", spec.Severity)
+	}
+
+	match := &spec.Match
+	if len(match.Fields) == 0 && match.Events == nil && match.Logs == nil && match.Pods == nil {
+		addErr("match must declare at least one of fields, events, logs or pods")
+	}
+	for i := range match.Fields {
+		f := &match.Fields[i]
+		if f.Object != "dataset" && f.Object != "runtime" {
+			addErr("match.fields[%d].object %q must be dataset or runtime", i, f.Object)
+		}
+		if f.Path == "" {
+			addErr("match.fields[%d].path is required", i)
+		} else if segments, err := splitFieldPath(f.Path); err != nil {
+			addErr("match.fields[%d].path %q is invalid: %v", i, f.Path, err)
+		} else {
+			f.segments = segments
+		}
+		if f.Pattern == "" {
+			addErr("match.fields[%d].pattern is required", i)
+		}
+		f.pattern = compile(fmt.Sprintf("match.fields[%d].pattern", i), f.Pattern)
+	}
+	if e := match.Events; e != nil {
+		if e.Type == "" && e.Reason == "" && e.Message == "" {
+			addErr("match.events must set type, reason or message")
+		}
+		e.reason = compile("match.events.reason", e.Reason)
+		e.message = compile("match.events.message", e.Message)
+	}
+	if l := match.Logs; l != nil {
+		if !isRuntimeComponent(l.Component) {
+			addErr("match.logs.component %q must be master, worker or fuse", l.Component)
+		}
+		if l.Line == "" {
+			addErr("match.logs.line is required")
+		}
+		l.line = compile("match.logs.line", l.Line)
+	}
+	if p := match.Pods; p != nil {
+		if !isRuntimeComponent(p.Component) {
+			addErr("match.pods.component %q must be master, worker or fuse", p.Component)
+		}
+		if p.Phase == "" && p.Reason == "" && p.ContainerState == "" {
+			addErr("match.pods must set phase, reason or containerState")
+		}
+		p.phase = compile("match.pods.phase", p.Phase)
+		p.reason = compile("match.pods.reason", p.Reason)
+		p.containerState = compile("match.pods.containerState", p.ContainerState)
+	}
+
+	if spec.Hint.Issue == "" {
+		addErr("hint.issue is required")
+	}
+	spec.Hint.issue = parse("hint.issue", spec.Hint.Issue)
+	spec.Hint.suggestion = parse("hint.suggestion", spec.Hint.Suggestion)
+	spec.Hint.evidence = parse("hint.evidence", spec.Hint.Evidence)
+
+	if len(errs) > 0 {
+		id := spec.ID
+		if id == "" {
+			id = "<no id>"
+		}
+		return nil, fmt.Errorf("rule %s: %w", id, errors.Join(errs...))
+	}
+	return &declarativeRule{spec: spec}, nil
+}
+
+// ParseRules parses and validates the rules of a rule file
+func ParseRules(data []byte) ([]Rule, error) {
+	file := &RuleFile{}
+	if err := yaml.UnmarshalStrict(data, file); err != nil {
+		return nil, err
+	}
+
+	var rules []Rule
+	var errs []error
+	for _, spec := range file.Rules {
+		rule, err := NewDeclarativeRule(spec)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return rules, nil
+}
+
+// RuleFiles returns the *.yaml and *.yml files of a rules directory in name order
+func RuleFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// LoadRulesDir loads every rule file of a directory
+func LoadRulesDir(dir string) ([]Rule, error) {
+	files, err := RuleFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules directory: %w", err)
+	}
+
+	var rules []Rule
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rule file: %w", err)
+		}
+		fileRules, err := ParseRules(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule file %s: %w", path, err)
+		}
+		rules = append(rules, fileRules...)
+	}
+	return rules, nil
+}
+
+// Evaluate emits a hint for every match of the rule
+func (r *declarativeRule) Evaluate(result *types.DiagnosticResult) []types.FailureHint {
+	base := RuleMatch{
+		Dataset:     result.DatasetName,
+		Namespace:   result.Namespace,
+		RuntimeType: result.RuntimeType,
+	}
+
+	match := &r.spec.Match
+	if len(match.Fields) > 0 {
+		objects := map[string]map[string]interface{}{
+			"dataset": parseObjectYAML(result.DatasetYAML),
+			"runtime": parseObjectYAML(result.RuntimeYAML),
+		}
+		for _, f := range match.Fields {
+			value, ok := fieldValue(objects[f.Object], f.segments)
+			if !ok || !f.pattern.MatchString(value) {
+				return nil
+			}
+			base.Value = value
+			base.Groups = f.pattern.FindStringSubmatch(value)
+			base.Component = f.Object
+		}
+		if match.Events == nil && match.Logs == nil && match.Pods == nil {
+			return []types.FailureHint{r.hint(base)}
+		}
+	}
+
+	var hints []types.FailureHint
+	if e := match.Events; e != nil {
+		for _, event := range result.Events {
+			m := base
+			if !e.matches(event, &m) {
+				continue
+			}
+			m.Component = event.ObjectKind
+			m.Event = event
+			hints = append(hints, r.hint(m))
+		}
+	}
+	if l := match.Logs; l != nil {
+		for _, c := range logsByComponent(&result.Logs) {
+			if l.Component != "" && l.Component != c.component {
+				continue
+			}
+			for _, line := range strings.Split(c.entry.Logs, "\n") {
+				if groups := l.line.FindStringSubmatch(line); groups != nil {
+					m := base
+					m.Component = c.component
+					m.Pod = c.entry.PodName
+					m.Container = c.entry.ContainerName
+					m.Line = line
+					m.Groups = groups
+					// One hint per log entry, evidenced by the first matching line
+					hints = append(hints, r.hint(m))
+					break
+				}
+			}
+		}
+	}
+	if p := match.Pods; p != nil {
+		for _, component := range []string{ComponentMaster, ComponentWorker, ComponentFuse} {
+			group := podGroup(&result.Resources, component)
+			if group == nil || (p.Component != "" && p.Component != component) {
+				continue
+			}
+			for _, pod := range append(append([]types.PodStatus{}, group.Pods...), group.FailingPods...) {
+				m := base
+				if !p.matches(pod, &m) {
+					continue
+				}
+				m.Component = component
+				m.Pod = pod.Name
+				m.PodStatus = pod
+				hints = append(hints, r.hint(m))
+			}
+		}
+	}
+	return hints
+}
+
+func (e *EventMatch) matches(event types.EventInfo, m *RuleMatch) bool {
+	if e.Type != "" && e.Type != event.Type {
+		return false
+	}
+	return matchPattern(e.reason, event.Reason, m) && matchPattern(e.message, event.Message, m)
+}
+
+func (p *PodMatch) matches(pod types.PodStatus, m *RuleMatch) bool {
+	return matchPattern(p.phase, pod.Phase, m) &&
+		matchPattern(p.reason, pod.Reason, m) &&
+		matchPattern(p.containerState, pod.ContainerState, m)
+}
+
+// matchPattern reports whether s matches re, recording the submatches. A nil pattern matches anything.
+func matchPattern(re *regexp.Regexp, s string, m *RuleMatch) bool {
+	if re == nil {
+		return true
+	}
+	groups := re.FindStringSubmatch(s)
+	if groups == nil {
+		return false
+	}
+	m.Groups = groups
+	return true
+}
+
+// hint renders the hint templates for a match
+func (r *declarativeRule) hint(m RuleMatch) types.FailureHint {
+	component := r.spec.Hint.Component
+	if component == "" {
+		component = m.Component
+	}
+	return types.FailureHint{
+		Severity:   r.spec.Severity,
+		Component:  component,
+		Issue:      render(r.spec.Hint.issue, &m),
+		Suggestion: render(r.spec.Hint.suggestion, &m),
+		Evidence:   render(r.spec.Hint.evidence, &m),
+	}
+}
+
+func render(tmpl *template.Template, m *RuleMatch) string {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, m); err != nil {
+		return fmt.Sprintf("<template error: %v>", err)
+	}
+	return buf.String()
+}
+
+type componentLog struct {
+	component string
+	entry     *types.LogEntry
+}
+
+// logsByComponent returns every collected runtime log, including previous instances,
+// init containers and sidecars
+func logsByComponent(logs *types.DiagnosticLogs) []componentLog {
+	var entries []componentLog
+	if logs.Master != nil {
+		entries = append(entries, componentLog{ComponentMaster, logs.Master})
+	}
+	for i := range logs.MasterExtra {
+		entries = append(entries, componentLog{ComponentMaster, &logs.MasterExtra[i]})
+	}
+	for i := range logs.Workers {
+		entries = append(entries, componentLog{ComponentWorker, &logs.Workers[i]})
+	}
+	for i := range logs.Fuse {
+		entries = append(entries, componentLog{ComponentFuse, &logs.Fuse[i]})
+	}
+	return entries
+}
+
+func isRuntimeComponent(component string) bool {
+	switch component {
+	case "", ComponentMaster, ComponentWorker, ComponentFuse:
+		return true
+	}
+	return false
+}
+
+func parseObjectYAML(content string) map[string]interface{} {
+	obj := map[string]interface{}{}
+	if content != "" {
+		// An unparsable snapshot matches no field conditions
+		_ = yaml.Unmarshal([]byte(content), &obj)
+	}
+	return obj
+}
+
+// splitFieldPath splits a dotted path such as status.conditions.0.type into its segments.
+// A segment in brackets, quoted with " or ', may contain dots, as in
+// metadata.annotations["app.kubernetes.io/name"]; an unquoted one is taken as is, as in
+// status.conditions[0].type.
+func splitFieldPath(path string) ([]string, error) {
+	var segments []string
+	rest := path
+	for rest != "" {
+		var segment string
+		if rest[0] == '[' {
+			if len(rest) > 1 && (rest[1] == '"' || rest[1] == '\'') {
+				quote := rest[1]
+				end := strings.IndexByte(rest[2:], quote)
+				if end < 0 || !strings.HasPrefix(rest[2+end+1:], "]") {
+					return nil, fmt.Errorf("unterminated %c in brackets", quote)
+				}
+				segment, rest = rest[2:2+end], rest[2+end+2:]
+			} else {
+				end := strings.IndexByte(rest, ']')
+				if end < 0 {
+					return nil, fmt.Errorf("unterminated [")
+				}
+				segment, rest = rest[1:end], rest[end+1:]
+			}
+			if rest != "" && rest[0] != '.' && rest[0] != '[' {
+				return nil, fmt.Errorf("expected . or [ after ]")
+			}
+		} else {
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			segment, rest = rest[:end], rest[end:]
+			if segment == "" {
+				return nil, fmt.Errorf("empty segment")
+			}
+		}
+		segments = append(segments, segment)
+
+		if strings.HasPrefix(rest, ".") {
+			rest = rest[1:]
+			if rest == "" {
+				return nil, fmt.Errorf("empty segment")
+			}
+		}
+	}
+	return segments, nil
+}
+
+// fieldValue resolves the segments of a field path to a scalar value
+func fieldValue(obj map[string]interface{}, segments []string) (string, bool) {
+	var current interface{} = obj
+	for _, segment := range segments {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[segment]
+			if !ok {
+				return "", false
+			}
+			current = value
+		case []interface{}:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(node) {
+				return "", false
+			}
+			current = node[i]
+		default:
+			return "", false
+		}
+	}
+
+	switch current.(type) {
+	case map[string]interface{}, []interface{}, nil:
+		return "", false
+	}
+	return fmt.Sprint(current), true
+}
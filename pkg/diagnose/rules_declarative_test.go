@@ -0,0 +1,369 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+const testRuleFile = `
+rules:
+  - id: oss-endpoint-timeout
+    title: Fuse cannot reach the OSS endpoint
+    severity: critical
+    docUrl: https://wiki.example.com/fluid/oss-timeout
+    match:
+      logs:
+        component: fuse
+        line: 'oss endpoint (\S+) timed out'
+    hint:
+      issue: 'OSS endpoint {{index .Groups 1}} timed out in {{.Pod}}'
+      suggestion: Check the VPC endpoint of the bucket
+      evidence: '{{.Line}}'
+  - id: webhook-denied
+    title: Admission webhook denied a runtime pod
+    severity: warning
+    match:
+      fields:
+        - object: runtime
+          path: spec.master.replicas
+          pattern: '^[1-9]'
+      events:
+        type: Warning
+        reason: FailedCreate
+        message: 'admission webhook "(\S+)" denied'
+    hint:
+      issue: 'Webhook {{index .Groups 1}} denied {{.Event.ObjectName}}'
+  - id: oom-killed-worker
+    title: Worker was OOM killed
+    severity: warning
+    runtimeTypes: [alluxioruntimes]
+    match:
+      pods:
+        component: worker
+        reason: OOMKilled
+    hint:
+      issue: '{{.Pod}} on {{.PodStatus.NodeName}} was OOM killed'
+`
+
+func testRuleResult() *types.DiagnosticResult {
+	return &types.DiagnosticResult{
+		DatasetName: "demo",
+		RuntimeType: "alluxioruntimes",
+		RuntimeYAML: "spec:\n  master:\n    replicas: 1\n",
+		Events: []types.EventInfo{
+			{Type: "Warning", Reason: "FailedCreate", ObjectKind: "StatefulSet", ObjectName: "demo-worker",
+				Message: `admission webhook "policy.example.com" denied the request`},
+			{Type: "Warning", Reason: "BackOff", ObjectKind: "Pod", ObjectName: "demo-worker-0"},
+		},
+		Resources: types.DiagnosticResources{
+			Workers: &types.PodGroupStatus{
+				Pods:        []types.PodStatus{{Name: "demo-worker-0", Ready: true}},
+				FailingPods: []types.PodStatus{{Name: "demo-worker-1", Reason: "OOMKilled", NodeName: "node-a"}},
+			},
+		},
+		Logs: types.DiagnosticLogs{
+			Fuse: []types.LogEntry{
+				{PodName: "demo-fuse-a", Logs: "starting\noss endpoint oss.example.com timed out\nretry\noss endpoint oss.example.com timed out"},
+				{PodName: "demo-fuse-b", Logs: "healthy"},
+			},
+		},
+	}
+}
+
+func TestDeclarativeRules(t *testing.T) {
+	rules, err := ParseRules([]byte(testRuleFile))
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+
+	registry := NewRuleRegistry()
+	for _, rule := range rules {
+		if err := registry.Register(rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hints := registry.Evaluate(testRuleResult())
+
+	tests := []struct {
+		ruleID    string
+		component string
+		issue     string
+		evidence  string
+		docURL    string
+	}{
+		{
+			ruleID:    "oss-endpoint-timeout",
+			component: "fuse",
+			issue:     "OSS endpoint oss.example.com timed out in demo-fuse-a",
+			evidence:  "oss endpoint oss.example.com timed out",
+			docURL:    "https://wiki.example.com/fluid/oss-timeout",
+		},
+		{
+			ruleID:    "webhook-denied",
+			component: "StatefulSet",
+			issue:     "Webhook policy.example.com denied demo-worker",
+		},
+		{
+			ruleID:    "oom-killed-worker",
+			component: "worker",
+			issue:     "demo-worker-1 on node-a was OOM killed",
+		},
+	}
+
+	if len(hints) != len(tests) {
+		t.Fatalf("hints = %+v, want one per rule", hints)
+	}
+	for _, tt := range tests {
+		t.Run(tt.ruleID, func(t *testing.T) {
+			hint := findHint(hints, tt.ruleID)
+			if hint == nil {
+				t.Fatalf("no hint from %s", tt.ruleID)
+			}
+			if hint.Component != tt.component || hint.Issue != tt.issue || hint.Evidence != tt.evidence {
+				t.Errorf("hint = %+v", hint)
+			}
+			if hint.DocURL != tt.docURL {
+				t.Errorf("doc URL = %q, want %q", hint.DocURL, tt.docURL)
+			}
+		})
+	}
+}
+
+func TestDeclarativeLogRuleMatchesMasterExtra(t *testing.T) {
+	rules, err := ParseRules([]byte(`
+rules:
+  - id: journal-corrupted
+    title: Master journal is corrupted
+    severity: critical
+    match:
+      logs:
+        component: master
+        line: 'journal \S+ is corrupted'
+    hint:
+      issue: 'Corrupted journal in {{.Pod}}/{{.Container}}'
+`))
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+	registry := NewRuleRegistry()
+	if err := registry.Register(rules[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	// The line is only in the logs of the master's previous instance
+	result := &types.DiagnosticResult{
+		Logs: types.DiagnosticLogs{
+			Master: &types.LogEntry{PodName: "demo-master-0", ContainerName: "alluxio-master", Logs: "starting"},
+			MasterExtra: []types.LogEntry{
+				{PodName: "demo-master-0", ContainerName: "alluxio-master", Previous: true, Logs: "journal /journal/0 is corrupted"},
+			},
+		},
+	}
+	hint := findHint(registry.Evaluate(result), "journal-corrupted")
+	if hint == nil {
+		t.Fatal("no hint from the master's previous logs")
+	}
+	if want := "Corrupted journal in demo-master-0/alluxio-master"; hint.Issue != want {
+		t.Errorf("issue = %q, want %q", hint.Issue, want)
+	}
+}
+
+func TestDeclarativeRuleFieldConditions(t *testing.T) {
+	rules, err := ParseRules([]byte(`
+rules:
+  - id: dataset-not-bound
+    title: Dataset is not bound
+    severity: info
+    match:
+      fields:
+        - object: dataset
+          path: status.conditions.0.type
+          pattern: '^(Ready|Bound)$'
+    hint:
+      issue: 'Last condition is {{.Value}}'
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := &types.DiagnosticResult{DatasetYAML: "status:\n  conditions:\n  - type: Ready\n"}
+	if hints := rules[0].Evaluate(result); len(hints) != 1 || hints[0].Issue != "Last condition is Ready" {
+		t.Errorf("hints = %+v", hints)
+	}
+	result.DatasetYAML = "status:\n  conditions: []\n"
+	if hints := rules[0].Evaluate(result); len(hints) != 0 {
+		t.Errorf("hints = %+v, want none for a missing field", hints)
+	}
+}
+
+func TestSplitFieldPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "status.conditions.0.type", want: "status|conditions|0|type"},
+		{path: "status.conditions[0].type", want: "status|conditions|0|type"},
+		{path: `metadata.labels["fluid.io/dataset"]`, want: "metadata|labels|fluid.io/dataset"},
+		{path: `metadata.annotations['app.kubernetes.io/name'].x`, want: "metadata|annotations|app.kubernetes.io/name|x"},
+		{path: "status..phase", wantErr: true},
+		{path: "status.", wantErr: true},
+		{path: `metadata.labels["fluid.io`, wantErr: true},
+		{path: "status.conditions[0", wantErr: true},
+		{path: `metadata.labels["a"]b`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			segments, err := splitFieldPath(tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("segments = %q, want an error", segments)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := strings.Join(segments, "|"); got != tt.want {
+				t.Errorf("segments = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeclarativeRuleDottedKeys(t *testing.T) {
+	rules, err := ParseRules([]byte(`
+rules:
+  - id: managed-by-helm
+    title: Runtime is managed by Helm
+    severity: info
+    match:
+      fields:
+        - object: runtime
+          path: metadata.labels["app.kubernetes.io/managed-by"]
+          pattern: '^Helm$'
+    hint:
+      issue: 'Managed by {{.Value}}'
+`))
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+
+	result := &types.DiagnosticResult{
+		RuntimeYAML: "metadata:\n  labels:\n    app.kubernetes.io/managed-by: Helm\n",
+	}
+	if hints := rules[0].Evaluate(result); len(hints) != 1 || hints[0].Issue != "Managed by Helm" {
+		t.Errorf("hints = %+v", hints)
+	}
+}
+
+func TestParseRulesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			content: "rules:\n  - id: a\n    severty: info\n",
+			wantErr: "unknown field",
+		},
+		{
+			name: "invalid id and severity",
+			content: `rules:
+  - id: Bad_ID
+    title: x
+    severity: fatal
+    match: {events: {reason: x}}
+    hint: {issue: x}`,
+			wantErr: "lowercase words",
+		},
+		{
+			name: "invalid regex",
+			content: `rules:
+  - id: bad-regex
+    title: x
+    severity: info
+    match: {logs: {line: "("}}
+    hint: {issue: x}`,
+			wantErr: "match.logs.line",
+		},
+		{
+			name: "unknown template field",
+			content: `rules:
+  - id: bad-template
+    title: x
+    severity: info
+    match: {pods: {reason: x}}
+    hint: {issue: "{{.Nope}}"}`,
+			wantErr: "hint.issue",
+		},
+		{
+			name: "invalid field path",
+			content: `rules:
+  - id: bad-path
+    title: x
+    severity: info
+    match: {fields: [{object: dataset, path: 'metadata.labels["fluid.io', pattern: x}]}
+    hint: {issue: x}`,
+			wantErr: "match.fields[0].path",
+		},
+		{
+			name: "no matcher",
+			content: `rules:
+  - id: no-match
+    title: x
+    severity: info
+    hint: {issue: x}`,
+			wantErr: "at least one of",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRules([]byte(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadRulesDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "site.yaml"), []byte(testRuleFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a rule"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := LoadRulesDir(dir)
+	if err != nil {
+		t.Fatalf("LoadRulesDir: %v", err)
+	}
+	if len(rules) != 3 {
+		t.Errorf("loaded %d rules, want 3", len(rules))
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// unhealthyResult triggers the worker-not-ready and volume-mount-failure rules
+func unhealthyResult() *types.DiagnosticResult {
+	return &types.DiagnosticResult{
+		RuntimeType: "alluxioruntimes",
+		Resources: types.DiagnosticResources{
+			Workers: &types.PodGroupStatus{Desired: 2, Ready: 1},
+		},
+		Events: []types.EventInfo{
+			{Type: "Warning", ObjectKind: "Pod", Message: "MountVolume.SetUp failed"},
+		},
+	}
+}
+
+func findHint(hints []types.FailureHint, ruleID string) *types.FailureHint {
+	for i := range hints {
+		if hints[i].RuleID == ruleID {
+			return &hints[i]
+		}
+	}
+	return nil
+}
+
+func TestRuleRegistryEvaluate(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(t *testing.T, r *RuleRegistry)
+		want      map[string]string // rule ID -> severity; absent rules must not fire
+	}{
+		{
+			name: "defaults",
+			want: map[string]string{
+				RuleWorkerNotReady:     SeverityWarning,
+				RuleVolumeMountFailure: SeverityCritical,
+			},
+		},
+		{
+			name: "disabled rule",
+			configure: func(t *testing.T, r *RuleRegistry) {
+				if err := r.Disable(RuleVolumeMountFailure); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: map[string]string{RuleWorkerNotReady: SeverityWarning},
+		},
+		{
+			name: "severity override",
+			configure: func(t *testing.T, r *RuleRegistry) {
+				if err := r.SetSeverity(RuleWorkerNotReady, SeverityCritical); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: map[string]string{
+				RuleWorkerNotReady:     SeverityCritical,
+				RuleVolumeMountFailure: SeverityCritical,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := DefaultRuleRegistry()
+			if tt.configure != nil {
+				tt.configure(t, registry)
+			}
+
+			hints := registry.Evaluate(unhealthyResult())
+			if len(hints) != len(tt.want) {
+				t.Errorf("hints = %+v, want %d", hints, len(tt.want))
+			}
+			for id, severity := range tt.want {
+				hint := findHint(hints, id)
+				if hint == nil {
+					t.Errorf("no hint from rule %s", id)
+					continue
+				}
+				if hint.Severity != severity {
+					t.Errorf("%s severity = %s, want %s", id, hint.Severity, severity)
+				}
+				if hint.DocURL != RuleDocURL(id) {
+					t.Errorf("%s doc URL = %q", id, hint.DocURL)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleRegistryErrors(t *testing.T) {
+	registry := DefaultRuleRegistry()
+
+	if err := registry.Disable("no-such-rule"); err == nil {
+		t.Error("expected error disabling an unknown rule")
+	}
+	if err := registry.SetSeverity("no-such-rule", SeverityInfo); err == nil {
+		t.Error("expected error overriding an unknown rule")
+	}
+	if err := registry.SetSeverity(RuleWorkerNotReady, "fatal"); err == nil {
+		t.Error("expected error for an invalid severity")
+	}
+	if err := registry.Register(registry.Rules()[0]); err == nil {
+		t.Error("expected error registering a duplicate rule ID")
+	}
+}
+
+func TestRuleRuntimeTypes(t *testing.T) {
+	registry := NewRuleRegistry()
+	rule := &builtinRule{
+		id:           "juicefs-only",
+		severity:     SeverityInfo,
+		runtimeTypes: []string{"juicefsruntimes"},
+		evaluate: func(result *types.DiagnosticResult, severity string) []types.FailureHint {
+			return []types.FailureHint{{Severity: severity, Issue: "matched"}}
+		},
+	}
+	if err := registry.Register(rule); err != nil {
+		t.Fatal(err)
+	}
+
+	if hints := registry.Evaluate(&types.DiagnosticResult{RuntimeType: "alluxioruntimes"}); len(hints) != 0 {
+		t.Errorf("rule ran for another runtime: %+v", hints)
+	}
+	if hints := registry.Evaluate(&types.DiagnosticResult{RuntimeType: "juicefsruntimes"}); len(hints) != 1 {
+		t.Errorf("hints = %+v, want one for juicefs", hints)
+	}
+}
+
+func TestCacheRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		cache *types.CacheStatus
+		want  []string
+	}{
+		{
+			name:  "within watermark",
+			cache: &types.CacheStatus{Cached: "2.00GiB", CacheCapacity: "4.00GiB", HighWaterMark: "0.95", CacheHitRatio: "90%"},
+		},
+		{
+			name:  "above configured watermark",
+			cache: &types.CacheStatus{Cached: "3.50GiB", CacheCapacity: "4.00GiB", HighWaterMark: "0.8"},
+			want:  []string{RuleCacheHighWaterMark},
+		},
+		{
+			name:  "above default watermark",
+			cache: &types.CacheStatus{Cached: "4.00GiB", CacheCapacity: "4.00GiB"},
+			want:  []string{RuleCacheHighWaterMark},
+		},
+		{
+			name:  "low hit ratio",
+			cache: &types.CacheStatus{CacheHitRatio: "12.5%"},
+			want:  []string{RuleLowCacheHitRatio},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hints := DefaultRuleRegistry().Evaluate(&types.DiagnosticResult{CacheStatus: tt.cache})
+			if len(hints) != len(tt.want) {
+				t.Fatalf("hints = %+v, want rules %v", hints, tt.want)
+			}
+			for _, id := range tt.want {
+				if findHint(hints, id) == nil {
+					t.Errorf("no hint from rule %s", id)
+				}
+			}
+		})
+	}
+}
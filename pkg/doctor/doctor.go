@@ -0,0 +1,382 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package doctor checks a Fluid installation: the data.fluid.io API, the controllers,
+// the webhook, the CSI driver and the access the current user has to them.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/parallel"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// Doctor runs the installation checks
+type Doctor struct {
+	client         k8s.Interface
+	fluidNamespace string
+}
+
+// NewDoctor creates a Doctor checking the Fluid installation in the default namespace
+func NewDoctor(client k8s.Interface) *Doctor {
+	return &Doctor{client: client, fluidNamespace: k8s.FluidNamespace}
+}
+
+// WithFluidNamespace sets the namespace of the Fluid control plane
+func (d *Doctor) WithFluidNamespace(namespace string) *Doctor {
+	d.fluidNamespace = namespace
+	return d
+}
+
+// Run checks the installation. It fails only when the cluster cannot be reached;
+// every other problem is reported as a failed or warning check.
+func (d *Doctor) Run(ctx context.Context) (*types.DoctorResult, error) {
+	version, err := d.client.ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the cluster: %w", err)
+	}
+	result := &types.DoctorResult{
+		FluidNamespace: d.fluidNamespace,
+		ServerVersion:  version,
+		CheckedAt:      time.Now(),
+	}
+
+	api, apiErr := d.client.DiscoverFluidAPI()
+	graph, graphErr := d.client.DiscoverControlPlane(ctx, d.fluidNamespace)
+	checks := make([]types.DoctorCheck, 5)
+	checks[0] = checkCRDs(api, apiErr)
+	checks[1] = d.checkControllers(api, graph, graphErr)
+	parallel.Run(ctx, parallel.DefaultLimit,
+		func(ctx context.Context) { checks[2] = d.checkWebhook(ctx) },
+		func(ctx context.Context) { checks[3] = d.checkCSI(ctx, graph, graphErr) },
+		func(ctx context.Context) { checks[4] = d.checkRBAC(ctx) },
+	)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result.Status = types.DoctorPass
+	for i := range checks {
+		checks[i].Status = checkStatus(checks[i].Hints)
+		result.Status = worse(result.Status, checks[i].Status)
+	}
+	result.Checks = checks
+	return result, nil
+}
+
+// checkCRDs reports the served versions and resources of data.fluid.io
+func checkCRDs(api *k8s.FluidAPI, apiErr error) types.DoctorCheck {
+	check := types.DoctorCheck{Name: types.DoctorCheckCRDs, Title: "Fluid CRDs (data.fluid.io)"}
+	if api == nil {
+		if apiErr != nil {
+			addHint(&check, diagnose.SeverityCritical, "Failed to discover the data.fluid.io API",
+				"Check that the API server is reachable and that discovery is allowed", apiErr.Error())
+			return check
+		}
+		addHint(&check, diagnose.SeverityCritical, "The data.fluid.io API is not served",
+			"Install Fluid with its Helm chart, which installs the CRDs", "")
+		return check
+	}
+	if apiErr != nil {
+		addHint(&check, diagnose.SeverityWarning, "Some data.fluid.io versions could not be discovered",
+			"Check the API server logs for aggregated discovery errors", apiErr.Error())
+	}
+
+	check.Details = append(check.Details, "preferred version: "+api.PreferredVersion)
+	for _, version := range sortedKeys(api.Resources) {
+		check.Details = append(check.Details, fmt.Sprintf("%s: %s", version, strings.Join(api.Resources[version], ", ")))
+	}
+
+	if !api.Served("datasets") {
+		addHint(&check, diagnose.SeverityCritical, "The datasets resource is not served",
+			"Reinstall the Fluid CRDs from the chart of the installed Fluid version", "")
+	}
+	if len(runtimeResources(api)) == 0 {
+		addHint(&check, diagnose.SeverityCritical, "No runtime resource is served",
+			"Reinstall the Fluid CRDs from the chart of the installed Fluid version", "")
+	}
+	return check
+}
+
+// checkControllers reports the readiness and versions of the controllers and the webhook,
+// and whether the resource of every runtime controller is served
+func (d *Doctor) checkControllers(api *k8s.FluidAPI, graph *k8s.ResourceGraph, err error) types.DoctorCheck {
+	check := types.DoctorCheck{Name: types.DoctorCheckControllers, Title: "Fluid controllers"}
+	if err != nil {
+		addHint(&check, diagnose.SeverityWarning, "Failed to list the Fluid control plane in "+d.fluidNamespace,
+			"Check the access of the current user to deployments and daemonsets", err.Error())
+	}
+
+	versions := map[string][]string{}
+	datasetController := false
+	for _, w := range graph.Workloads {
+		role := k8s.ControlPlaneRole(w.Name)
+		tag := imageTag(w)
+		if tag != "" {
+			versions[tag] = append(versions[tag], w.Name)
+		}
+		if role == types.ControlPlaneCSINodePlugin {
+			// Checked with the CSI driver
+			continue
+		}
+		check.Details = append(check.Details, fmt.Sprintf("%s: %d/%d ready, version %s", w.Name, w.Ready, w.Desired, orUnknown(tag)))
+
+		switch {
+		case role == types.ControlPlaneDatasetController:
+			datasetController = true
+		case role == types.ControlPlaneRuntimeController && api != nil:
+			// The runtime controller is named after its resource, e.g. alluxioruntime-controller
+			resource := strings.TrimSuffix(w.Name, "-controller") + "s"
+			if !api.Served(resource) {
+				addHint(&check, diagnose.SeverityCritical, fmt.Sprintf("%s manages %s, which the API server does not serve", w.Name, resource),
+					"Upgrade the Fluid CRDs to the version of the controllers", "")
+			}
+		}
+
+		// Runtime controllers may be scaled to zero until a runtime of their kind exists
+		if w.Desired == 0 && role != types.ControlPlaneRuntimeController {
+			addHint(&check, diagnose.SeverityCritical, w.Name+" is scaled to zero", "Scale the deployment back up or reinstall Fluid", "")
+		} else if w.Ready < w.Desired {
+			addHint(&check, diagnose.SeverityCritical, fmt.Sprintf("%s is not ready (%d/%d)", w.Name, w.Ready, w.Desired),
+				fmt.Sprintf("Check its pods with kubectl -n %s describe deployment %s", d.fluidNamespace, w.Name), "")
+		}
+	}
+
+	if !datasetController && err == nil {
+		addHint(&check, diagnose.SeverityCritical, "The dataset controller is not deployed in "+d.fluidNamespace,
+			"Install Fluid, or pass the namespace it is installed in with --fluid-namespace", "")
+	}
+	if len(versions) > 1 {
+		var evidence []string
+		for _, tag := range sortedKeys(versions) {
+			evidence = append(evidence, fmt.Sprintf("%s: %s", tag, strings.Join(versions[tag], ", ")))
+		}
+		addHint(&check, diagnose.SeverityWarning, "Fluid components run different versions",
+			"Upgrade all components and the CRDs together with the Fluid Helm chart", strings.Join(evidence, "; "))
+	}
+	return check
+}
+
+// checkWebhook reports whether the Fluid mutating webhooks have a ready endpoint
+func (d *Doctor) checkWebhook(ctx context.Context) types.DoctorCheck {
+	check := types.DoctorCheck{Name: types.DoctorCheckWebhook, Title: "Fluid webhook"}
+	configs, err := d.client.ListMutatingWebhooks(ctx)
+	if err != nil {
+		addHint(&check, diagnose.SeverityWarning, "Failed to list the mutating webhook configurations",
+			"Check the access of the current user to mutatingwebhookconfigurations", err.Error())
+		return check
+	}
+
+	found := false
+	for _, config := range configs {
+		for _, webhook := range config.Webhooks {
+			service := webhook.ClientConfig.Service
+			if service == nil || service.Namespace != d.fluidNamespace {
+				continue
+			}
+			found = true
+
+			policy := "Fail"
+			if webhook.FailurePolicy != nil {
+				policy = string(*webhook.FailurePolicy)
+			}
+			ready, err := d.client.ReadyEndpoints(ctx, service.Namespace, service.Name)
+			if err != nil {
+				addHint(&check, diagnose.SeverityWarning, "Failed to list the endpoints of the webhook service "+service.Name,
+					"Check the access of the current user to endpointslices", err.Error())
+				continue
+			}
+			check.Details = append(check.Details, fmt.Sprintf("%s: service %s/%s, %d ready endpoint(s), failure policy %s",
+				webhook.Name, service.Namespace, service.Name, ready, policy))
+
+			if ready == 0 {
+				// With the Fail policy, pods the webhook matches cannot be created at all
+				severity := diagnose.SeverityWarning
+				if policy == "Fail" {
+					severity = diagnose.SeverityCritical
+				}
+				addHint(&check, severity, fmt.Sprintf("The webhook service %s/%s has no ready endpoint", service.Namespace, service.Name),
+					"Check the fluid-webhook pods; until they are ready, pods using Datasets are not mutated", "")
+			}
+			if len(webhook.ClientConfig.CABundle) == 0 {
+				addHint(&check, diagnose.SeverityWarning, webhook.Name+" has no CA bundle",
+					"Restart the fluid-webhook pods, which patch their certificate into the configuration", "")
+			}
+		}
+	}
+
+	if !found {
+		addHint(&check, diagnose.SeverityWarning, "No mutating webhook calls a service in "+d.fluidNamespace,
+			"Pods using Datasets are not scheduled with their cache affinity; check the Fluid installation", "")
+	}
+	return check
+}
+
+// checkCSI reports whether the Fluid CSIDriver is registered and the CSI nodeplugin is ready
+func (d *Doctor) checkCSI(ctx context.Context, graph *k8s.ResourceGraph, graphErr error) types.DoctorCheck {
+	check := types.DoctorCheck{Name: types.DoctorCheckCSI, Title: "Fluid CSI driver"}
+	driver, err := d.client.GetCSIDriver(ctx, k8s.CSIDriverName)
+	switch {
+	case err == nil:
+		attachRequired := driver.Spec.AttachRequired != nil && *driver.Spec.AttachRequired
+		check.Details = append(check.Details, fmt.Sprintf("CSIDriver %s: attachRequired=%t", driver.Name, attachRequired))
+	case k8s.IsNotFound(err):
+		addHint(&check, diagnose.SeverityCritical, "The CSIDriver "+k8s.CSIDriverName+" is not registered",
+			"Reinstall Fluid; without it no Dataset PVC can be mounted", "")
+	default:
+		addHint(&check, diagnose.SeverityWarning, "Failed to get the CSIDriver "+k8s.CSIDriverName,
+			"Check the access of the current user to csidrivers", err.Error())
+	}
+
+	// A listing error is reported by the controllers check
+	found := false
+	for _, w := range graph.Workloads {
+		if k8s.ControlPlaneRole(w.Name) != types.ControlPlaneCSINodePlugin {
+			continue
+		}
+		found = true
+		check.Details = append(check.Details, fmt.Sprintf("%s: ready on %d/%d nodes", w.Name, w.Ready, w.Desired))
+		switch {
+		case w.Desired == 0:
+			addHint(&check, diagnose.SeverityWarning, w.Name+" runs on no node",
+				"Check the node selector and tolerations of the DaemonSet", "")
+		case w.Ready == 0:
+			addHint(&check, diagnose.SeverityCritical, w.Name+" is ready on no node",
+				fmt.Sprintf("Check its pods with kubectl -n %s get pods -o wide", d.fluidNamespace), "")
+		case w.Ready < w.Desired:
+			addHint(&check, diagnose.SeverityWarning, fmt.Sprintf("%s is ready on %d of %d nodes", w.Name, w.Ready, w.Desired),
+				"Dataset volumes cannot be mounted on the other nodes; check the failing plugin pods", "")
+		}
+	}
+	if !found && graphErr == nil {
+		addHint(&check, diagnose.SeverityCritical, "The CSI nodeplugin DaemonSet is not deployed in "+d.fluidNamespace,
+			"Install Fluid, or pass the namespace it is installed in with --fluid-namespace", "")
+	}
+	return check
+}
+
+// checkRBAC reports the permissions inspect and diagnose need that the current user lacks
+func (d *Doctor) checkRBAC(ctx context.Context) types.DoctorCheck {
+	check := types.DoctorCheck{Name: types.DoctorCheckRBAC, Title: "Permissions of the current user"}
+	// On a discovery error the built-in runtimes are checked
+	runtimes, _ := d.client.RuntimeResources()
+	results, err := d.client.CheckAccess(ctx, diagnose.RequiredPermissions("", d.fluidNamespace, d.client.EventsGroup(), runtimes))
+	if err != nil {
+		addHint(&check, diagnose.SeverityWarning, "Failed to review the permissions of the current user",
+			"Check the permissions by hand with kubectl auth can-i --list", err.Error())
+		return check
+	}
+
+	var denied []string
+	for _, r := range results {
+		if !r.Allowed {
+			denied = append(denied, r.Permission.String())
+		}
+	}
+	check.Details = append(check.Details, fmt.Sprintf("%d/%d permissions granted in all namespaces", len(results)-len(denied), len(results)))
+	for _, p := range denied {
+		check.Details = append(check.Details, "missing: "+p)
+	}
+	if len(denied) > 0 {
+		addHint(&check, diagnose.SeverityWarning, fmt.Sprintf("The current user lacks %d permission(s) inspect and diagnose use", len(denied)),
+			"Grant them through a ClusterRole, or run the commands in the namespaces you can read",
+			strings.Join(denied, ", "))
+	}
+	return check
+}
+
+// addHint records an issue of a check; the component of its hints is the check itself
+func addHint(check *types.DoctorCheck, severity, issue, suggestion, evidence string) {
+	check.Hints = append(check.Hints, types.FailureHint{
+		Severity:   severity,
+		Component:  check.Name,
+		Issue:      issue,
+		Suggestion: suggestion,
+		Evidence:   evidence,
+	})
+}
+
+// checkStatus fails on a critical hint and warns on a warning hint
+func checkStatus(hints []types.FailureHint) types.DoctorStatus {
+	status := types.DoctorPass
+	for _, hint := range hints {
+		switch hint.Severity {
+		case diagnose.SeverityCritical:
+			status = worse(status, types.DoctorFail)
+		case diagnose.SeverityWarning:
+			status = worse(status, types.DoctorWarn)
+		}
+	}
+	return status
+}
+
+// worse returns the more severe of two statuses
+func worse(a, b types.DoctorStatus) types.DoctorStatus {
+	rank := map[types.DoctorStatus]int{types.DoctorPass: 0, types.DoctorWarn: 1, types.DoctorFail: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// runtimeResources returns the runtime resources among the served Fluid resources
+func runtimeResources(api *k8s.FluidAPI) []string {
+	var runtimes []string
+	for _, resources := range api.Resources {
+		for _, r := range resources {
+			if strings.HasSuffix(r, "runtimes") {
+				runtimes = append(runtimes, r)
+			}
+		}
+	}
+	return runtimes
+}
+
+// imageTag returns the tag of the first container image of a workload, without any digest
+func imageTag(w *k8s.Workload) string {
+	if len(w.PodSpec.Containers) == 0 {
+		return ""
+	}
+	image, _, _ := strings.Cut(w.PodSpec.Containers[0].Image, "@")
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return ""
+	}
+	return image[i+1:]
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
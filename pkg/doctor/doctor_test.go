@@ -0,0 +1,165 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doctor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s/fake"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestDoctor(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		denied  []fake.Denied
+		want    map[string]types.DoctorStatus
+		// wantDetail is a detail some check must report
+		wantDetail string
+	}{
+		{
+			name:       "healthy installation",
+			objects:    fake.ControlPlane(k8s.FluidNamespace, "alluxioruntime-controller"),
+			want:       map[string]types.DoctorStatus{},
+			wantDetail: "dataset-controller: 1/1 ready, version " + fake.FluidVersion,
+		},
+		{
+			name: "nothing installed in the namespace",
+			want: map[string]types.DoctorStatus{
+				types.DoctorCheckControllers: types.DoctorFail,
+				types.DoctorCheckWebhook:     types.DoctorWarn,
+				types.DoctorCheckCSI:         types.DoctorFail,
+			},
+		},
+		{
+			name:    "controller of an unserved runtime",
+			objects: fake.ControlPlane(k8s.FluidNamespace, "curvineruntime-controller"),
+			want: map[string]types.DoctorStatus{
+				types.DoctorCheckControllers: types.DoctorFail,
+			},
+		},
+		{
+			name: "controllers of different versions",
+			objects: mutate(fake.ControlPlane(k8s.FluidNamespace, "alluxioruntime-controller"), func(obj runtime.Object) runtime.Object {
+				if d, ok := obj.(*appsv1.Deployment); ok && d.Name == "fluid-webhook" {
+					d.Spec.Template.Spec.Containers[0].Image = "fluidcloudnative/fluid-webhook:v1.0.3"
+				}
+				return obj
+			}),
+			want: map[string]types.DoctorStatus{
+				types.DoctorCheckControllers: types.DoctorWarn,
+			},
+		},
+		{
+			name: "webhook without ready endpoint",
+			objects: mutate(fake.ControlPlane(k8s.FluidNamespace, "alluxioruntime-controller"), func(obj runtime.Object) runtime.Object {
+				if _, ok := obj.(*discoveryv1.EndpointSlice); ok {
+					return fake.EndpointSlice(k8s.FluidNamespace, fake.WebhookName, false)
+				}
+				return obj
+			}),
+			want: map[string]types.DoctorStatus{
+				types.DoctorCheckWebhook: types.DoctorWarn,
+			},
+			wantDetail: "0 ready endpoint(s)",
+		},
+		{
+			name: "CSIDriver not registered",
+			objects: mutate(fake.ControlPlane(k8s.FluidNamespace, "alluxioruntime-controller"), func(obj runtime.Object) runtime.Object {
+				if _, ok := obj.(*storagev1.CSIDriver); ok {
+					return fake.CSIDriver("other.csi.example.com")
+				}
+				return obj
+			}),
+			want: map[string]types.DoctorStatus{
+				types.DoctorCheckCSI: types.DoctorFail,
+			},
+		},
+		{
+			name:    "missing permissions",
+			objects: fake.ControlPlane(k8s.FluidNamespace, "alluxioruntime-controller"),
+			denied:  []fake.Denied{{Verb: "get", Resource: "pods/log"}, {Verb: "get", Resource: "nodes"}},
+			want: map[string]types.DoctorStatus{
+				types.DoctorCheckRBAC: types.DoctorWarn,
+			},
+			wantDetail: "missing: get pods/log -n " + k8s.FluidNamespace,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientDenying(tt.denied, tt.objects...)
+			result, err := NewDoctor(client).Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			wantOverall := types.DoctorPass
+			var details []string
+			for _, check := range result.Checks {
+				want, ok := tt.want[check.Name]
+				if !ok {
+					want = types.DoctorPass
+				}
+				if check.Status != want {
+					t.Errorf("check %s = %s, want %s (hints %+v)", check.Name, check.Status, want, check.Hints)
+				}
+				wantOverall = worse(wantOverall, want)
+				details = append(details, check.Details...)
+			}
+			if result.Status != wantOverall {
+				t.Errorf("status = %s, want %s", result.Status, wantOverall)
+			}
+			if tt.wantDetail != "" && !strings.Contains(strings.Join(details, "\n"), tt.wantDetail) {
+				t.Errorf("details %q do not contain %q", details, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestImageTag(t *testing.T) {
+	tests := map[string]string{
+		"fluidcloudnative/dataset-controller:v1.0.4":     "v1.0.4",
+		"registry:5000/fluid/dataset-controller":         "",
+		"registry:5000/fluid/dataset-controller:v1.0.4":  "v1.0.4",
+		"fluidcloudnative/dataset-controller@sha256:abc": "",
+	}
+	for image, want := range tests {
+		w := &k8s.Workload{}
+		w.PodSpec.Containers = append(w.PodSpec.Containers, corev1.Container{Image: image})
+		if got := imageTag(w); got != want {
+			t.Errorf("imageTag(%q) = %q, want %q", image, got, want)
+		}
+	}
+}
+
+// mutate applies fn to every object
+func mutate(objects []runtime.Object, fn func(runtime.Object) runtime.Object) []runtime.Object {
+	for i := range objects {
+		objects[i] = fn(objects[i])
+	}
+	return objects
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inspect
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ParseCacheStatus builds the cache statistics of a Dataset from the status.cacheStates
+// of the Dataset, falling back to those of the Runtime, and the watermarks of the
+// Runtime's first tiered-store level. Either object may be nil. It returns nil when
+// no cache information is available.
+func ParseCacheStatus(dataset, runtime map[string]interface{}) *types.CacheStatus {
+	datasetStates := cacheStates(dataset)
+	runtimeStates := cacheStates(runtime)
+	state := func(key string) string {
+		if v := datasetStates[key]; v != "" {
+			return v
+		}
+		return runtimeStates[key]
+	}
+
+	status := &types.CacheStatus{
+		CacheCapacity:    state("cacheCapacity"),
+		Cached:           state("cached"),
+		CachedPercentage: state("cachedPercentage"),
+		Cacheable:        state("cacheable"),
+		LowWaterMark:     state("lowWaterMark"),
+		HighWaterMark:    state("highWaterMark"),
+		CacheHitRatio:    state("cacheHitRatio"),
+	}
+
+	// The tiered store spec holds the configured watermarks when the status does not report them
+	if status.LowWaterMark == "" || status.HighWaterMark == "" {
+		if levels, found, _ := unstructured.NestedSlice(runtime, "spec", "tieredstore", "levels"); found && len(levels) > 0 {
+			if level, ok := levels[0].(map[string]interface{}); ok {
+				if status.LowWaterMark == "" {
+					status.LowWaterMark = scalarString(level["low"])
+				}
+				if status.HighWaterMark == "" {
+					status.HighWaterMark = scalarString(level["high"])
+				}
+			}
+		}
+	}
+
+	if *status == (types.CacheStatus{}) {
+		return nil
+	}
+	return status
+}
+
+// CacheUsage returns the fraction of the cache capacity in use, or false if it cannot be computed
+func CacheUsage(status *types.CacheStatus) (float64, bool) {
+	if status == nil {
+		return 0, false
+	}
+	cached, ok := ParseByteSize(status.Cached)
+	if !ok {
+		return 0, false
+	}
+	capacity, ok := ParseByteSize(status.CacheCapacity)
+	if !ok || capacity == 0 {
+		return 0, false
+	}
+	return cached / capacity, true
+}
+
+// ParseRatio parses a ratio written as a fraction ("0.95") or a percentage ("95%", "95.0%")
+func ParseRatio(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+	percent := strings.HasSuffix(s, "%")
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil {
+		return 0, false
+	}
+	if percent {
+		v /= 100
+	}
+	return v, true
+}
+
+var byteSizePattern = regexp.MustCompile(`^([0-9]*\.?[0-9]+)\s*([KMGTPE]?)(i?)B?$`)
+
+// ParseByteSize parses sizes reported by Fluid such as "4.00GiB", "512MB" or "100Gi" into bytes
+func ParseByteSize(s string) (float64, bool) {
+	m := byteSizePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+
+	base := 1000.0
+	if m[3] == "i" {
+		base = 1024
+	}
+	for i := 0; i < strings.Index("KMGTPE", m[2])+1 && m[2] != ""; i++ {
+		v *= base
+	}
+	return v, true
+}
+
+func cacheStates(obj map[string]interface{}) map[string]string {
+	states, found, _ := unstructured.NestedMap(obj, "status", "cacheStates")
+	if !found {
+		return nil
+	}
+	result := make(map[string]string, len(states))
+	for k, v := range states {
+		result[k] = scalarString(v)
+	}
+	return result
+}
+
+func scalarString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inspect
+
+import (
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+func TestParseCacheStatus(t *testing.T) {
+	tieredStoreRuntime := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"tieredstore": map[string]interface{}{
+				"levels": []interface{}{
+					map[string]interface{}{"mediumtype": "MEM", "quota": "4Gi", "low": "0.7", "high": "0.95"},
+				},
+			},
+		},
+		"status": map[string]interface{}{
+			"cacheStates": map[string]interface{}{"cacheCapacity": "4.00GiB", "cacheHitRatio": "80.0%"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		dataset map[string]interface{}
+		runtime map[string]interface{}
+		want    *types.CacheStatus
+	}{
+		{
+			name: "no cache information",
+			want: nil,
+		},
+		{
+			name: "dataset states and tiered store watermarks",
+			dataset: map[string]interface{}{
+				"status": map[string]interface{}{
+					"cacheStates": map[string]interface{}{"cacheCapacity": "8.00GiB", "cached": "1.00GiB", "cachedPercentage": "10.0%"},
+				},
+			},
+			runtime: tieredStoreRuntime,
+			want: &types.CacheStatus{
+				CacheCapacity:    "8.00GiB",
+				Cached:           "1.00GiB",
+				CachedPercentage: "10.0%",
+				CacheHitRatio:    "80.0%",
+				LowWaterMark:     "0.7",
+				HighWaterMark:    "0.95",
+			},
+		},
+		{
+			name: "numeric watermarks",
+			runtime: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"tieredstore": map[string]interface{}{
+						"levels": []interface{}{map[string]interface{}{"low": 0.5, "high": 0.9}},
+					},
+				},
+			},
+			want: &types.CacheStatus{LowWaterMark: "0.5", HighWaterMark: "0.9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseCacheStatus(tt.dataset, tt.runtime)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ParseCacheStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"4.00GiB", 4 << 30, true},
+		{"100Gi", 100 << 30, true},
+		{"512MB", 512e6, true},
+		{"0.00B", 0, true},
+		{"1024", 1024, true},
+		{"", 0, false},
+		{"lots", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseByteSize(tt.in)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseByteSize(%q) = %v, %t, want %v, %t", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCacheUsage(t *testing.T) {
+	usage, ok := CacheUsage(&types.CacheStatus{Cached: "3.80GiB", CacheCapacity: "4.00GiB"})
+	if !ok || usage != 0.95 {
+		t.Errorf("CacheUsage() = %v, %t, want 0.95", usage, ok)
+	}
+	if _, ok := CacheUsage(&types.CacheStatus{Cached: "1.00GiB"}); ok {
+		t.Error("expected no usage without a capacity")
+	}
+}
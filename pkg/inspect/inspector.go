@@ -18,54 +18,122 @@ package inspect
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/parallel"
 	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // DatasetInspector inspects Dataset and related resources
 type DatasetInspector struct {
-	client *k8s.Client
+	client k8s.Interface
 }
 
 // NewDatasetInspector creates a new DatasetInspector
-func NewDatasetInspector(client *k8s.Client) *DatasetInspector {
+func NewDatasetInspector(client k8s.Interface) *DatasetInspector {
 	return &DatasetInspector{
 		client: client,
 	}
 }
 
 // Inspect inspects a Dataset and returns the complete result
-func (d *DatasetInspector) Inspect(namespace, name string) (*types.InspectionResult, error) {
-	ctx := context.Background()
-
+func (d *DatasetInspector) Inspect(ctx context.Context, namespace, name string) (*types.InspectionResult, error) {
 	// Get Dataset
 	dataset, err := d.client.GetDataset(ctx, namespace, name)
 	if err != nil {
 		return nil, err
 	}
 
+	return d.inspectDataset(ctx, dataset, nil)
+}
+
+// InspectAll inspects every Dataset matching a label selector. An empty namespace
+// inspects all namespaces. Datasets are inspected concurrently and the results are
+// sorted by namespace and name. The objects of each runtime namespace are listed once and
+// shared by the Datasets of the namespace.
+//
+// A Dataset that cannot be inspected is still returned, with only its Dataset info
+// and the failure in Warnings, and the failures are joined in the returned error.
+// The results are nil only when the Datasets cannot be listed.
+func (d *DatasetInspector) InspectAll(ctx context.Context, namespace, labelSelector string) ([]*types.InspectionResult, error) {
+	datasets, err := d.client.ListDatasets(ctx, namespace, labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := k8s.NewDiscoveryCache()
+	results := make([]*types.InspectionResult, len(datasets))
+	errs := make([]error, len(datasets))
+	parallel.ForEach(ctx, len(datasets), parallel.DefaultLimit, func(ctx context.Context, i int) {
+		results[i], errs[i] = d.inspectDataset(ctx, &datasets[i], cache)
+	})
+	for i := range datasets {
+		// Datasets not started before the context expired have neither a result nor an error
+		if results[i] == nil && errs[i] == nil {
+			errs[i] = ctx.Err()
+		}
+		if errs[i] == nil {
+			continue
+		}
+		errs[i] = fmt.Errorf("failed to inspect dataset %s/%s: %w", datasets[i].GetNamespace(), datasets[i].GetName(), errs[i])
+		results[i] = &types.InspectionResult{
+			Dataset:  d.parseDatasetInfo(&datasets[i]),
+			Warnings: []string{errs[i].Error()},
+		}
+	}
+
+	if err := SortResults(results, SortByNamespace); err != nil {
+		return nil, err
+	}
+	return results, errors.Join(errs...)
+}
+
+// inspectDataset builds the inspection result of a fetched Dataset. cache may be nil.
+func (d *DatasetInspector) inspectDataset(ctx context.Context, dataset *unstructured.Unstructured, cache *k8s.DiscoveryCache) (*types.InspectionResult, error) {
+	namespace, name := dataset.GetNamespace(), dataset.GetName()
 	result := &types.InspectionResult{}
 
 	// Parse Dataset info
 	result.Dataset = d.parseDatasetInfo(dataset)
 
-	// Try to find and parse Runtime
-	runtime, runtimeType, err := d.client.TryFindRuntime(ctx, namespace, name)
+	// Find and parse the bound Runtime. Lookup failures leave the runtime out and are reported.
+	runtime, runtimeType, err := d.client.FindRuntime(ctx, dataset)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find runtime: %w", err)
+		result.Warnings = append(result.Warnings, strings.Split(err.Error(), "\n")...)
 	}
 
+	var runtimeObj map[string]interface{}
 	if runtime != nil {
 		runtimeInfo := d.parseRuntimeInfo(runtime, runtimeType)
 		result.Runtime = runtimeInfo
+		result.Conditions = parseConditions(runtime)
+		runtimeObj = runtime.Object
 	}
 
-	// Get Kubernetes resources
-	result.Resources = d.getResourceStatus(ctx, namespace, name)
+	result.CacheStatus = ParseCacheStatus(dataset.Object, runtimeObj)
+
+	// Get Kubernetes resources. Discovery is best-effort: objects that cannot be listed
+	// are left out and reported, while workloads that do not exist are just absent.
+	graph, err := d.client.DiscoverResources(ctx, namespace, name, runtime, cache)
+	if err != nil {
+		result.Warnings = append(result.Warnings, strings.Split(err.Error(), "\n")...)
+	}
+	if graph == nil {
+		graph = &k8s.ResourceGraph{}
+	}
+	result.Resources, err = d.getResourceStatus(ctx, graph, namespace, name)
+	if err != nil {
+		result.Warnings = append(result.Warnings, err.Error())
+	}
+
+	// Resources that could not be fetched in time are missing from the result
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("inspection of dataset %s/%s did not complete: %w", namespace, name, err)
+	}
 
 	return result, nil
 }
@@ -91,26 +159,7 @@ func (d *DatasetInspector) parseDatasetInfo(dataset *unstructured.Unstructured)
 		}
 
 		// Parse conditions
-		if conditions, found, _ := unstructured.NestedSlice(dataset.Object, "status", "conditions"); found {
-			for _, c := range conditions {
-				if cond, ok := c.(map[string]interface{}); ok {
-					condInfo := types.ConditionInfo{}
-					if t, ok := cond["type"].(string); ok {
-						condInfo.Type = t
-					}
-					if s, ok := cond["status"].(string); ok {
-						condInfo.Status = s
-					}
-					if r, ok := cond["reason"].(string); ok {
-						condInfo.Reason = r
-					}
-					if m, ok := cond["message"].(string); ok {
-						condInfo.Message = m
-					}
-					info.Conditions = append(info.Conditions, condInfo)
-				}
-			}
-		}
+		info.Conditions = parseConditions(dataset)
 
 		// Parse runtimes
 		if runtimes, found, _ := unstructured.NestedSlice(dataset.Object, "status", "runtimes"); found {
@@ -146,6 +195,35 @@ func (d *DatasetInspector) parseDatasetInfo(dataset *unstructured.Unstructured)
 	return info
 }
 
+// parseConditions extracts status.conditions from a Dataset or Runtime
+func parseConditions(obj *unstructured.Unstructured) []types.ConditionInfo {
+	conditions, found, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if !found {
+		return nil
+	}
+
+	var result []types.ConditionInfo
+	for _, c := range conditions {
+		if cond, ok := c.(map[string]interface{}); ok {
+			condInfo := types.ConditionInfo{}
+			if t, ok := cond["type"].(string); ok {
+				condInfo.Type = t
+			}
+			if s, ok := cond["status"].(string); ok {
+				condInfo.Status = s
+			}
+			if r, ok := cond["reason"].(string); ok {
+				condInfo.Reason = r
+			}
+			if m, ok := cond["message"].(string); ok {
+				condInfo.Message = m
+			}
+			result = append(result, condInfo)
+		}
+	}
+	return result
+}
+
 // parseRuntimeInfo extracts information from a Runtime unstructured object
 func (d *DatasetInspector) parseRuntimeInfo(runtime *unstructured.Unstructured, resourceType string) *types.RuntimeInfo {
 	info := &types.RuntimeInfo{
@@ -228,50 +306,28 @@ func (d *DatasetInspector) parseRuntimeInfo(runtime *unstructured.Unstructured,
 	return info
 }
 
-// getResourceStatus fetches Kubernetes resource status
-func (d *DatasetInspector) getResourceStatus(ctx context.Context, namespace, name string) types.ResourceStatus {
-	result := types.ResourceStatus{}
-
-	// Get Master StatefulSet
-	masterSts, _ := d.client.GetStatefulSet(ctx, namespace, name+"-master")
-	if masterSts != nil {
-		result.MasterStatefulSet = &types.StatefulSetStatus{
-			Name:            masterSts.Name,
-			Replicas:        *masterSts.Spec.Replicas,
-			ReadyReplicas:   masterSts.Status.ReadyReplicas,
-			CurrentReplicas: masterSts.Status.CurrentReplicas,
-			Healthy:         masterSts.Status.ReadyReplicas == *masterSts.Spec.Replicas,
-		}
+// getResourceStatus reports the discovered runtime workloads and fetches the PVC. A PVC
+// that cannot be read is left out and its error returned; one that does not exist is not an error.
+func (d *DatasetInspector) getResourceStatus(ctx context.Context, graph *k8s.ResourceGraph, namespace, name string) (types.ResourceStatus, error) {
+	result := types.ResourceStatus{
+		MasterStatefulSet: workloadStatus(graph.Component("master")),
+		WorkerStatefulSet: workloadStatus(graph.Component("worker")),
+		Discovered:        graph.Refs(),
 	}
 
-	// Get Worker StatefulSet
-	workerSts, _ := d.client.GetStatefulSet(ctx, namespace, name+"-worker")
-	if workerSts != nil {
-		result.WorkerStatefulSet = &types.StatefulSetStatus{
-			Name:            workerSts.Name,
-			Replicas:        *workerSts.Spec.Replicas,
-			ReadyReplicas:   workerSts.Status.ReadyReplicas,
-			CurrentReplicas: workerSts.Status.CurrentReplicas,
-			Healthy:         workerSts.Status.ReadyReplicas == *workerSts.Spec.Replicas,
-		}
-	}
-
-	// Get Fuse DaemonSet
-	fuseDaemonSet, _ := d.client.GetDaemonSet(ctx, namespace, name+"-fuse")
-	if fuseDaemonSet != nil {
+	if fuse := graph.Component("fuse"); fuse != nil {
 		result.FuseDaemonSet = &types.DaemonSetStatus{
-			Name:             fuseDaemonSet.Name,
-			DesiredScheduled: fuseDaemonSet.Status.DesiredNumberScheduled,
-			CurrentScheduled: fuseDaemonSet.Status.CurrentNumberScheduled,
-			Ready:            fuseDaemonSet.Status.NumberReady,
-			Available:        fuseDaemonSet.Status.NumberAvailable,
-			Unavailable:      fuseDaemonSet.Status.NumberUnavailable,
-			Healthy:          fuseDaemonSet.Status.NumberReady == fuseDaemonSet.Status.DesiredNumberScheduled,
+			Name:             fuse.Name,
+			DesiredScheduled: fuse.Desired,
+			CurrentScheduled: fuse.Current,
+			Ready:            fuse.Ready,
+			Available:        fuse.Available,
+			Unavailable:      fuse.Unavailable,
+			Healthy:          fuse.Ready == fuse.Desired,
 		}
 	}
 
-	// Get PVC
-	pvc, _ := d.client.GetPVC(ctx, namespace, name)
+	pvc, err := d.client.GetPVC(ctx, namespace, name)
 	if pvc != nil {
 		capacity := ""
 		if pvc.Status.Capacity != nil {
@@ -287,7 +343,22 @@ func (d *DatasetInspector) getResourceStatus(ctx context.Context, namespace, nam
 		}
 	}
 
-	return result
+	return result, err
+}
+
+// workloadStatus returns the status of a master or worker workload, or nil if none was discovered
+func workloadStatus(w *k8s.Workload) *types.StatefulSetStatus {
+	if w == nil {
+		return nil
+	}
+	return &types.StatefulSetStatus{
+		Kind:            w.Kind,
+		Name:            w.Name,
+		Replicas:        w.Desired,
+		ReadyReplicas:   w.Ready,
+		CurrentReplicas: w.Current,
+		Healthy:         w.Ready == w.Desired,
+	}
 }
 
 // resourceTypeToRuntimeType converts resource type to display name
@@ -306,5 +377,6 @@ func (d *DatasetInspector) resourceTypeToRuntimeType(resourceType string) string
 	if name, ok := mapping[lower]; ok {
 		return name
 	}
-	return resourceType
+	// Runtime kinds added after this mapping, e.g. cacheruntimes
+	return strings.TrimSuffix(lower, "runtimes")
 }
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inspect
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s/fake"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	testNamespace = "default"
+	testDataset   = "demo"
+)
+
+type runtimeFixture struct {
+	kind   string
+	status map[string]interface{}
+}
+
+func readyAlluxioRuntime() *runtimeFixture {
+	return &runtimeFixture{
+		kind: "AlluxioRuntime",
+		status: map[string]interface{}{
+			"masterPhase":                  "Ready",
+			"desiredMasterNumberScheduled": int64(1),
+			"masterNumberReady":            int64(1),
+			"workerPhase":                  "Ready",
+			"desiredWorkerNumberScheduled": int64(2),
+			"workerNumberReady":            int64(2),
+			"fusePhase":                    "Ready",
+			"desiredFuseNumberScheduled":   int64(3),
+			"fuseNumberReady":              int64(3),
+		},
+	}
+}
+
+func datasetWithCacheStates(states map[string]string) *unstructured.Unstructured {
+	dataset := fake.Dataset(testNamespace, testDataset, "Bound")
+	_ = unstructured.SetNestedStringMap(dataset.Object, states, "status", "cacheStates")
+	return dataset
+}
+
+func TestInspect(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		runtime *runtimeFixture
+		wantErr bool
+		check   func(t *testing.T, result *types.InspectionResult)
+	}{
+		{
+			name:    "dataset not found",
+			wantErr: true,
+		},
+		{
+			name: "healthy alluxio dataset",
+			objects: []runtime.Object{
+				fake.Dataset(testNamespace, testDataset, "Bound"),
+				fake.StatefulSet(testNamespace, testDataset+"-master", 1, 1),
+				fake.StatefulSet(testNamespace, testDataset+"-worker", 2, 2),
+				fake.DaemonSet(testNamespace, testDataset+"-fuse", 3, 3),
+				fake.PVC(testNamespace, testDataset, "Bound", "default-demo"),
+			},
+			runtime: readyAlluxioRuntime(),
+			check: func(t *testing.T, result *types.InspectionResult) {
+				if result.Dataset.Phase != "Bound" {
+					t.Errorf("dataset phase = %q, want Bound", result.Dataset.Phase)
+				}
+				if result.Runtime == nil {
+					t.Fatal("expected runtime to be found")
+				}
+				if result.Runtime.Type != "alluxio" {
+					t.Errorf("runtime type = %q, want alluxio", result.Runtime.Type)
+				}
+				if result.Runtime.Worker.Ready != 2 || result.Runtime.Worker.DesiredScheduled != 2 {
+					t.Errorf("worker status = %d/%d, want 2/2", result.Runtime.Worker.Ready, result.Runtime.Worker.DesiredScheduled)
+				}
+				res := result.Resources
+				if res.MasterStatefulSet == nil || !res.MasterStatefulSet.Healthy {
+					t.Errorf("master statefulset = %+v, want healthy", res.MasterStatefulSet)
+				}
+				if res.WorkerStatefulSet == nil || !res.WorkerStatefulSet.Healthy {
+					t.Errorf("worker statefulset = %+v, want healthy", res.WorkerStatefulSet)
+				}
+				if res.FuseDaemonSet == nil || !res.FuseDaemonSet.Healthy {
+					t.Errorf("fuse daemonset = %+v, want healthy", res.FuseDaemonSet)
+				}
+				if res.PVC == nil || res.PVC.Phase != "Bound" || res.PVC.VolumeName != "default-demo" {
+					t.Errorf("pvc = %+v, want Bound to default-demo", res.PVC)
+				}
+			},
+		},
+		{
+			name: "dataset without runtime",
+			objects: []runtime.Object{
+				fake.Dataset(testNamespace, testDataset, "NotBound"),
+			},
+			check: func(t *testing.T, result *types.InspectionResult) {
+				if result.Runtime != nil {
+					t.Errorf("runtime = %+v, want nil", result.Runtime)
+				}
+				res := result.Resources
+				if res.MasterStatefulSet != nil || res.WorkerStatefulSet != nil || res.FuseDaemonSet != nil || res.PVC != nil {
+					t.Errorf("resources = %+v, want none", res)
+				}
+			},
+		},
+		{
+			name: "partially ready workers",
+			objects: []runtime.Object{
+				fake.Dataset(testNamespace, testDataset, "Bound"),
+				fake.StatefulSet(testNamespace, testDataset+"-master", 1, 1),
+				fake.StatefulSet(testNamespace, testDataset+"-worker", 3, 1),
+				fake.DaemonSet(testNamespace, testDataset+"-fuse", 3, 2),
+			},
+			runtime: &runtimeFixture{kind: "JuiceFSRuntime"},
+			check: func(t *testing.T, result *types.InspectionResult) {
+				if result.Runtime == nil || result.Runtime.Type != "juicefs" {
+					t.Fatalf("runtime = %+v, want juicefs", result.Runtime)
+				}
+				if result.Resources.WorkerStatefulSet.Healthy {
+					t.Error("worker statefulset should not be healthy")
+				}
+				if result.Resources.FuseDaemonSet.Healthy {
+					t.Error("fuse daemonset should not be healthy")
+				}
+				if result.Resources.PVC != nil {
+					t.Errorf("pvc = %+v, want nil", result.Resources.PVC)
+				}
+			},
+		},
+		{
+			name: "runtime conditions and cache states",
+			objects: []runtime.Object{
+				datasetWithCacheStates(map[string]string{
+					"cacheCapacity":    "4.00GiB",
+					"cached":           "1.00GiB",
+					"cachedPercentage": "25.0%",
+				}),
+			},
+			runtime: &runtimeFixture{
+				kind: "AlluxioRuntime",
+				status: map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "MasterReady", "status": "True", "reason": "Ready"},
+						map[string]interface{}{"type": "WorkersReady", "status": "False", "message": "0/2 ready"},
+					},
+				},
+			},
+			check: func(t *testing.T, result *types.InspectionResult) {
+				if len(result.Conditions) != 2 || result.Conditions[1].Message != "0/2 ready" {
+					t.Errorf("conditions = %+v, want runtime conditions", result.Conditions)
+				}
+				cache := result.CacheStatus
+				if cache == nil || cache.Cached != "1.00GiB" || cache.CachedPercentage != "25.0%" || cache.CacheCapacity != "4.00GiB" {
+					t.Errorf("cache status = %+v", cache)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := tt.objects
+			if tt.runtime != nil {
+				objects = append(objects, fake.Runtime(tt.runtime.kind, testNamespace, testDataset, tt.runtime.status))
+			}
+
+			inspector := NewDatasetInspector(fake.NewClient(objects...))
+			result, err := inspector.Inspect(context.Background(), testNamespace, testDataset)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.check(t, result)
+		})
+	}
+}
+
+func TestInspectReportsUnreadableResources(t *testing.T) {
+	// Workloads and the PVC that cannot be read are reported, not shown as absent
+	client := fake.NewClientDenying([]fake.Denied{
+		{Verb: "list", Resource: "statefulsets"},
+		{Verb: "get", Resource: "persistentvolumeclaims"},
+	},
+		fake.Dataset(testNamespace, testDataset, "Bound"),
+		fake.Runtime("AlluxioRuntime", testNamespace, testDataset, readyAlluxioRuntime().status),
+	)
+
+	result, err := NewDatasetInspector(client).Inspect(context.Background(), testNamespace, testDataset)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	warnings := strings.Join(result.Warnings, "\n")
+	for _, want := range []string{"statefulsets", "pvc"} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("warnings = %q, want one about %s", result.Warnings, want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inspect
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// Sort keys accepted by SortResults
+const (
+	SortByName      = "name"
+	SortByNamespace = "namespace"
+	SortByPhase     = "phase"
+	SortByRuntime   = "runtime"
+	SortByCached    = "cached"
+)
+
+// SortKeys lists the sort keys accepted by SortResults
+var SortKeys = []string{SortByName, SortByNamespace, SortByPhase, SortByRuntime, SortByCached}
+
+// Fields accepted by ParseFieldSelector
+const (
+	FieldName        = "metadata.name"
+	FieldNamespace   = "metadata.namespace"
+	FieldPhase       = "status.phase"
+	FieldRuntimeType = "runtime.type"
+	FieldPVCPhase    = "pvc.phase"
+)
+
+// SelectorFields lists the fields accepted by ParseFieldSelector
+var SelectorFields = []string{FieldName, FieldNamespace, FieldPhase, FieldRuntimeType, FieldPVCPhase}
+
+// ParseFieldSelector parses a field selector such as "status.phase!=Bound,runtime.type=alluxio".
+// It is evaluated on inspection results with MatchesFieldSelector.
+func ParseFieldSelector(selector string) (fields.Selector, error) {
+	parsed, err := fields.ParseSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field selector %q: %w", selector, err)
+	}
+	for _, req := range parsed.Requirements() {
+		if !contains(SelectorFields, req.Field) {
+			return nil, fmt.Errorf("unsupported field %q in field selector (must be one of %s)", req.Field, strings.Join(SelectorFields, ", "))
+		}
+	}
+	return parsed, nil
+}
+
+// MatchesFieldSelector reports whether an inspection result matches a field selector.
+// Missing values match as empty strings.
+func MatchesFieldSelector(result *types.InspectionResult, selector fields.Selector) bool {
+	set := fields.Set{
+		FieldName:      result.Dataset.Name,
+		FieldNamespace: result.Dataset.Namespace,
+		FieldPhase:     result.Dataset.Phase,
+	}
+	if result.Runtime != nil {
+		set[FieldRuntimeType] = result.Runtime.Type
+	}
+	if result.Resources.PVC != nil {
+		set[FieldPVCPhase] = result.Resources.PVC.Phase
+	}
+	return selector.Matches(set)
+}
+
+// SortResults sorts inspection results in place by one of SortKeys. Ties are broken
+// by namespace and name. Sorting by cached puts the least cached Datasets first.
+func SortResults(results []*types.InspectionResult, by string) error {
+	var key func(r *types.InspectionResult) string
+	switch by {
+	case SortByName:
+		key = func(r *types.InspectionResult) string { return r.Dataset.Name }
+	case SortByNamespace:
+		key = func(r *types.InspectionResult) string { return "" }
+	case SortByPhase:
+		key = func(r *types.InspectionResult) string { return r.Dataset.Phase }
+	case SortByRuntime:
+		key = func(r *types.InspectionResult) string {
+			if r.Runtime == nil {
+				return ""
+			}
+			return r.Runtime.Type
+		}
+	case SortByCached:
+		sort.SliceStable(results, func(i, j int) bool {
+			ci, cj := cachedRatio(results[i]), cachedRatio(results[j])
+			if ci != cj {
+				return ci < cj
+			}
+			return lessNamespacedName(results[i], results[j])
+		})
+		return nil
+	default:
+		return fmt.Errorf("unknown sort key %q (must be one of %s)", by, strings.Join(SortKeys, ", "))
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		ki, kj := key(results[i]), key(results[j])
+		if ki != kj {
+			return ki < kj
+		}
+		return lessNamespacedName(results[i], results[j])
+	})
+	return nil
+}
+
+// cachedRatio returns the cached percentage of a Dataset as a fraction, or -1 when unknown
+func cachedRatio(result *types.InspectionResult) float64 {
+	if result.CacheStatus == nil {
+		return -1
+	}
+	ratio, ok := ParseRatio(result.CacheStatus.CachedPercentage)
+	if !ok {
+		return -1
+	}
+	return ratio
+}
+
+func lessNamespacedName(a, b *types.InspectionResult) bool {
+	if a.Dataset.Namespace != b.Dataset.Namespace {
+		return a.Dataset.Namespace < b.Dataset.Namespace
+	}
+	return a.Dataset.Name < b.Dataset.Name
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inspect
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s/fake"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+func listResult(namespace, name, phase, runtimeType, cached string) *types.InspectionResult {
+	result := &types.InspectionResult{
+		Dataset: types.DatasetInfo{Namespace: namespace, Name: name, Phase: phase},
+	}
+	if runtimeType != "" {
+		result.Runtime = &types.RuntimeInfo{Type: runtimeType}
+	}
+	if cached != "" {
+		result.CacheStatus = &types.CacheStatus{CachedPercentage: cached}
+	}
+	return result
+}
+
+func names(results []*types.InspectionResult) string {
+	var out []string
+	for _, r := range results {
+		out = append(out, r.Dataset.Namespace+"/"+r.Dataset.Name)
+	}
+	return strings.Join(out, " ")
+}
+
+func TestInspectAll(t *testing.T) {
+	labeled := fake.Dataset("team-a", "labeled", "Bound")
+	labeled.SetLabels(map[string]string{"team": "ml"})
+
+	client := fake.NewClient(
+		fake.Dataset("team-b", "zeta", "NotBound"),
+		fake.Dataset("team-b", "alpha", "Bound"),
+		labeled,
+	)
+	inspector := NewDatasetInspector(client)
+
+	tests := []struct {
+		name      string
+		namespace string
+		selector  string
+		want      string
+	}{
+		{name: "all namespaces", want: "team-a/labeled team-b/alpha team-b/zeta"},
+		{name: "one namespace", namespace: "team-b", want: "team-b/alpha team-b/zeta"},
+		{name: "label selector", selector: "team=ml", want: "team-a/labeled"},
+		{name: "empty namespace", namespace: "team-c", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := inspector.InspectAll(context.Background(), tt.namespace, tt.selector)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := names(results); got != tt.want {
+				t.Errorf("datasets = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInspectAllReportsFailedDatasets(t *testing.T) {
+	client := fake.NewClient(
+		fake.Dataset("team-a", "alpha", "Bound"),
+		fake.Dataset("team-b", "beta", "NotBound"),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := NewDatasetInspector(client).InspectAll(ctx, "", "")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if got, want := names(results), "team-a/alpha team-b/beta"; got != want {
+		t.Fatalf("datasets = %q, want %q", got, want)
+	}
+	for _, result := range results {
+		if result.Dataset.Phase == "" || len(result.Warnings) != 1 {
+			t.Errorf("%s: phase = %q, warnings = %q, want the phase and one warning",
+				result.Dataset.Name, result.Dataset.Phase, result.Warnings)
+		}
+	}
+}
+
+func TestFieldSelector(t *testing.T) {
+	results := []*types.InspectionResult{
+		listResult("default", "bound", "Bound", "alluxio", ""),
+		listResult("default", "pending", "NotBound", "juicefs", ""),
+		listResult("other", "orphan", "NotBound", "", ""),
+	}
+
+	tests := []struct {
+		selector string
+		want     string
+		wantErr  bool
+	}{
+		{selector: "", want: "default/bound default/pending other/orphan"},
+		{selector: "status.phase!=Bound", want: "default/pending other/orphan"},
+		{selector: "status.phase!=Bound,runtime.type=juicefs", want: "default/pending"},
+		{selector: "runtime.type=", want: "other/orphan"},
+		{selector: "metadata.namespace==other", want: "other/orphan"},
+		{selector: "spec.owner=me", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := ParseFieldSelector(tt.selector)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var matched []*types.InspectionResult
+			for _, r := range results {
+				if MatchesFieldSelector(r, selector) {
+					matched = append(matched, r)
+				}
+			}
+			if got := names(matched); got != tt.want {
+				t.Errorf("matched = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortResults(t *testing.T) {
+	tests := []struct {
+		by      string
+		want    string
+		wantErr bool
+	}{
+		{by: SortByNamespace, want: "a/x b/w b/y c/z"},
+		{by: SortByName, want: "b/w a/x b/y c/z"},
+		{by: SortByPhase, want: "a/x b/y b/w c/z"},
+		{by: SortByRuntime, want: "c/z a/x b/w b/y"},
+		{by: SortByCached, want: "c/z b/y a/x b/w"},
+		{by: "size", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			results := []*types.InspectionResult{
+				listResult("c", "z", "Pending", "", ""),
+				listResult("b", "y", "Bound", "juicefs", "5.0%"),
+				listResult("b", "w", "NotBound", "alluxio", "100.0%"),
+				listResult("a", "x", "Bound", "alluxio", "50.0%"),
+			}
+
+			err := SortResults(results, tt.by)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := names(results); got != tt.want {
+				t.Errorf("order = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/parallel"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Permission is an API access checked with a SelfSubjectAccessReview.
+// An empty Namespace means all namespaces, or a cluster-scoped resource.
+type Permission struct {
+	Verb        string `json:"verb"`
+	Group       string `json:"group,omitempty"`
+	Resource    string `json:"resource"`
+	Subresource string `json:"subresource,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+// String formats the permission like kubectl auth can-i, e.g. "get pods/log -n fluid-system"
+func (p Permission) String() string {
+	resource := p.Resource
+	if p.Group != "" {
+		resource += "." + p.Group
+	}
+	if p.Subresource != "" {
+		resource += "/" + p.Subresource
+	}
+	if p.Namespace == "" {
+		return fmt.Sprintf("%s %s", p.Verb, resource)
+	}
+	return fmt.Sprintf("%s %s -n %s", p.Verb, resource, p.Namespace)
+}
+
+// AccessResult is the outcome of checking a Permission
+type AccessResult struct {
+	Permission
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// CheckAccess asks the API server whether the current user holds each permission.
+// Results are returned in the order of perms; a review that fails is returned as an error.
+func (c *Client) CheckAccess(ctx context.Context, perms []Permission) ([]AccessResult, error) {
+	results := make([]AccessResult, len(perms))
+	errs := make([]error, len(perms))
+	parallel.ForEach(ctx, len(perms), parallel.DefaultLimit, func(ctx context.Context, i int) {
+		p := perms[i]
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace:   p.Namespace,
+					Verb:        p.Verb,
+					Group:       p.Group,
+					Resource:    p.Resource,
+					Subresource: p.Subresource,
+				},
+			},
+		}
+		created, err := c.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			errs[i] = newAPIError(err, "create", "selfsubjectaccessreview for", "", p.String())
+			return
+		}
+		results[i] = AccessResult{Permission: p, Allowed: created.Status.Allowed, Reason: created.Status.Reason}
+	})
+	// Reviews usually fail together, e.g. when SelfSubjectAccessReviews are unavailable
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
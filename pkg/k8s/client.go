@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -36,12 +37,26 @@ import (
 
 // Client wraps Kubernetes client functionality
 type Client struct {
-	clientset     *kubernetes.Clientset
+	clientset     kubernetes.Interface
 	dynamicClient dynamic.Interface
+
+	// Runtime kinds and the Dataset version served by the cluster, discovered on first use
+	runtimesOnce   sync.Once
+	runtimes       []RuntimeResource
+	runtimesErr    error
+	datasetVersion string
+
+	// Whether the cluster serves events.k8s.io/v1, discovered on first use
+	eventsOnce sync.Once
+	eventsV1   bool
+
+	// The kubeconfig context in use, empty in-cluster
+	kubeContext string
 }
 
 // NewClient creates a new Kubernetes client
 func NewClient(kubeconfigPath string) (*Client, error) {
+	kubeconfigPath = kubeconfigFile(kubeconfigPath)
 	config, err := getConfig(kubeconfigPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get config: %w", err)
@@ -57,111 +72,96 @@ func NewClient(kubeconfigPath string) (*Client, error) {
 		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
 	}
 
+	client := NewClientFromInterfaces(clientset, dynamicClient)
+	if kubeconfigPath != "" {
+		if raw, err := clientcmd.LoadFromFile(kubeconfigPath); err == nil {
+			client.kubeContext = raw.CurrentContext
+		}
+	}
+	return client, nil
+}
+
+// KubeContext returns the name of the kubeconfig context the client uses, or "" when
+// running in-cluster
+func (c *Client) KubeContext() string {
+	return c.kubeContext
+}
+
+// NewClientFromInterfaces creates a Client backed by existing clientsets.
+// This allows fake clientsets to be injected in tests.
+func NewClientFromInterfaces(clientset kubernetes.Interface, dynamicClient dynamic.Interface) *Client {
 	return &Client{
 		clientset:     clientset,
 		dynamicClient: dynamicClient,
-	}, nil
+	}
 }
 
-// getConfig returns the Kubernetes config
-func getConfig(kubeconfigPath string) (*rest.Config, error) {
+// kubeconfigFile returns the kubeconfig file to use: the given path, $KUBECONFIG, or
+// ~/.kube/config if it exists. It returns "" when the in-cluster config is to be used.
+func kubeconfigFile(kubeconfigPath string) string {
 	// If kubeconfig is explicitly provided, use it
 	if kubeconfigPath != "" {
-		return clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+		return kubeconfigPath
 	}
 
 	// Check KUBECONFIG environment variable
 	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
-		return clientcmd.BuildConfigFromFlags("", kubeconfig)
+		return kubeconfig
 	}
 
 	// Fall back to default kubeconfig location
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		// Try in-cluster config as last resort
-		return rest.InClusterConfig()
+		return ""
 	}
-
 	kubeconfigPath = filepath.Join(homeDir, ".kube", "config")
 	if _, err := os.Stat(kubeconfigPath); os.IsNotExist(err) {
-		// Try in-cluster config as last resort
-		return rest.InClusterConfig()
+		return ""
 	}
+	return kubeconfigPath
+}
 
+// getConfig returns the Kubernetes config of a kubeconfig file, or the in-cluster
+// config when the path is empty
+func getConfig(kubeconfigPath string) (*rest.Config, error) {
+	if kubeconfigPath == "" {
+		return rest.InClusterConfig()
+	}
 	return clientcmd.BuildConfigFromFlags("", kubeconfigPath)
 }
 
-// GetDataset fetches a Dataset CR
-func (c *Client) GetDataset(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
-	gvr := schema.GroupVersionResource{
-		Group:    "data.fluid.io",
-		Version:  "v1alpha1",
-		Resource: "datasets",
+// DatasetResource returns the Dataset resource in the first served version that has it,
+// starting with the preferred one. It falls back to v1alpha1 when the version cannot be
+// discovered.
+func (c *Client) DatasetResource() schema.GroupVersionResource {
+	c.RuntimeResources() // Discovers the Dataset version along with the runtime kinds
+	version := c.datasetVersion
+	if version == "" {
+		version = "v1alpha1"
 	}
+	return schema.GroupVersionResource{Group: FluidGroup, Version: version, Resource: "datasets"}
+}
 
-	dataset, err := c.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+// GetDataset fetches a Dataset CR
+func (c *Client) GetDataset(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	dataset, err := c.dynamicClient.Resource(c.DatasetResource()).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		if errors.IsNotFound(err) {
-			return nil, fmt.Errorf("dataset %s/%s not found", namespace, name)
-		}
-		return nil, fmt.Errorf("failed to get dataset: %w", err)
+		return nil, newAPIError(err, "get", "dataset", namespace, name)
 	}
 
 	return dataset, nil
 }
 
-// GetRuntime fetches a Runtime CR by type
-func (c *Client) GetRuntime(ctx context.Context, namespace, name, runtimeType string) (*unstructured.Unstructured, error) {
-	resourceName := runtimeTypeToResourceName(runtimeType)
-	gvr := schema.GroupVersionResource{
-		Group:    "data.fluid.io",
-		Version:  "v1alpha1",
-		Resource: resourceName,
-	}
-
-	runtime, err := c.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+// ListDatasets lists Dataset CRs matching a label selector. An empty namespace lists all namespaces.
+func (c *Client) ListDatasets(ctx context.Context, namespace, labelSelector string) ([]unstructured.Unstructured, error) {
+	list, err := c.dynamicClient.Resource(c.DatasetResource()).Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
 	if err != nil {
-		if errors.IsNotFound(err) {
-			return nil, nil // Runtime not found is not an error
-		}
-		return nil, fmt.Errorf("failed to get runtime: %w", err)
+		return nil, newAPIError(err, "list", "datasets", namespace, "")
 	}
 
-	return runtime, nil
-}
-
-// TryFindRuntime attempts to find a Runtime CR for a Dataset by trying different types
-func (c *Client) TryFindRuntime(ctx context.Context, namespace, name string) (*unstructured.Unstructured, string, error) {
-	runtimeTypes := []string{
-		"alluxioruntimes",
-		"jindoruntimes",
-		"juicefsruntimes",
-		"efcruntimes",
-		"thinruntimes",
-		"vineyardruntimes",
-		"goosefsruntimes",
-	}
-
-	for _, resourceName := range runtimeTypes {
-		gvr := schema.GroupVersionResource{
-			Group:    "data.fluid.io",
-			Version:  "v1alpha1",
-			Resource: resourceName,
-		}
-
-		runtime, err := c.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			if errors.IsNotFound(err) {
-				continue
-			}
-			// Ignore other errors (e.g., CRD not installed)
-			continue
-		}
-
-		return runtime, resourceName, nil
-	}
-
-	return nil, "", nil
+	return list.Items, nil
 }
 
 // GetStatefulSet fetches a StatefulSet
@@ -171,7 +171,7 @@ func (c *Client) GetStatefulSet(ctx context.Context, namespace, name string) (*a
 		if errors.IsNotFound(err) {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("failed to get statefulset: %w", err)
+		return nil, newAPIError(err, "get", "statefulset", namespace, name)
 	}
 	return sts, nil
 }
@@ -183,7 +183,7 @@ func (c *Client) GetDaemonSet(ctx context.Context, namespace, name string) (*app
 		if errors.IsNotFound(err) {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("failed to get daemonset: %w", err)
+		return nil, newAPIError(err, "get", "daemonset", namespace, name)
 	}
 	return ds, nil
 }
@@ -195,39 +195,29 @@ func (c *Client) GetPVC(ctx context.Context, namespace, name string) (*corev1.Pe
 		if errors.IsNotFound(err) {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("failed to get pvc: %w", err)
+		return nil, newAPIError(err, "get", "pvc", namespace, name)
 	}
 	return pvc, nil
 }
 
 // ListStatefulSetsByLabel lists StatefulSets by label selector
 func (c *Client) ListStatefulSetsByLabel(ctx context.Context, namespace, labelSelector string) (*appsv1.StatefulSetList, error) {
-	return c.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{
+	list, err := c.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: labelSelector,
 	})
+	if err != nil {
+		return nil, newAPIError(err, "list", "statefulsets", namespace, "")
+	}
+	return list, nil
 }
 
 // ListDaemonSetsByLabel lists DaemonSets by label selector
 func (c *Client) ListDaemonSetsByLabel(ctx context.Context, namespace, labelSelector string) (*appsv1.DaemonSetList, error) {
-	return c.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{
+	list, err := c.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: labelSelector,
 	})
-}
-
-// runtimeTypeToResourceName converts runtime type to resource name
-func runtimeTypeToResourceName(runtimeType string) string {
-	mapping := map[string]string{
-		"AlluxioRuntime":  "alluxioruntimes",
-		"JindoRuntime":    "jindoruntimes",
-		"JuiceFSRuntime":  "juicefsruntimes",
-		"EFCRuntime":      "efcruntimes",
-		"ThinRuntime":     "thinruntimes",
-		"VineyardRuntime": "vineyardruntimes",
-		"GooseFSRuntime":  "goosefsruntimes",
-	}
-
-	if name, ok := mapping[runtimeType]; ok {
-		return name
+	if err != nil {
+		return nil, newAPIError(err, "list", "daemonsets", namespace, "")
 	}
-	return runtimeType
+	return list, nil
 }
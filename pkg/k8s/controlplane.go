@@ -0,0 +1,96 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/parallel"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Defaults of a Fluid installation
+const (
+	FluidNamespace = "fluid-system"
+	CSIDriverName  = "fuse.csi.fluid.io"
+)
+
+// ControlPlaneRole returns the role of a Fluid control-plane workload, one of the
+// types.ControlPlane* constants, from its name as set by the Fluid chart, or "" if the
+// workload is not part of the control plane
+func ControlPlaneRole(name string) string {
+	switch {
+	case name == "dataset-controller":
+		return types.ControlPlaneDatasetController
+	case strings.HasSuffix(name, "runtime-controller"):
+		return types.ControlPlaneRuntimeController
+	case name == "fluid-webhook":
+		return types.ControlPlaneWebhook
+	case strings.HasPrefix(name, "csi-nodeplugin"):
+		return types.ControlPlaneCSINodePlugin
+	}
+	return ""
+}
+
+// DiscoverControlPlane finds the Fluid control-plane Deployments and DaemonSets in namespace,
+// and their pods. Workloads that could not be listed are reported in the error, which comes
+// with the partial graph.
+func (c *Client) DiscoverControlPlane(ctx context.Context, namespace string) (*ResourceGraph, error) {
+	set := &objectSet{}
+	errs := make([]error, 2)
+	parallel.Run(ctx, parallel.DefaultLimit,
+		func(ctx context.Context) {
+			list, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				errs[0] = newAPIError(err, "list", "deployments", namespace, "")
+				return
+			}
+			set.deployments = list.Items
+		},
+		func(ctx context.Context) {
+			list, err := c.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				errs[1] = newAPIError(err, "list", "daemonsets", namespace, "")
+				return
+			}
+			set.daemonSets = list.Items
+		},
+	)
+
+	graph := newResourceGraph()
+	graph.addObjects(set, types.DiscoveredByName, func(obj metav1.Object) bool {
+		return ControlPlaneRole(obj.GetName()) != ""
+	})
+	// The graph is built once both lists are done; discoverWorkloadPods fills the pods of
+	// the workloads only after its own fetches finish
+	errs = append(errs, c.discoverWorkloadPods(ctx, graph))
+	graph.sort()
+	return graph, errors.Join(errs...)
+}
+
+// GetCSIDriver fetches a CSIDriver
+func (c *Client) GetCSIDriver(ctx context.Context, name string) (*storagev1.CSIDriver, error) {
+	driver, err := c.clientset.StorageV1().CSIDrivers().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, newAPIError(err, "get", "csidriver", "", name)
+	}
+	return driver, nil
+}
@@ -0,0 +1,608 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/parallel"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apitypes "k8s.io/apimachinery/pkg/types"
+)
+
+// Labels Fluid sets on the objects it creates for a Dataset
+const (
+	LabelDataset          = "fluid.io/dataset"
+	LabelDatasetNamespace = "fluid.io/dataset-namespace"
+)
+
+// Workload kinds found by discovery
+const (
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
+	KindDeployment  = "Deployment"
+)
+
+// Workload is a StatefulSet, DaemonSet or Deployment backing a runtime component
+type Workload struct {
+	Kind      string
+	Namespace string
+	Name      string
+	UID       apitypes.UID
+	// PodLabels are the labels of the workload's pod template
+	PodLabels map[string]string
+	// PodSpec is the spec of the workload's pod template
+	PodSpec corev1.PodSpec
+	// Desired, Current, Ready, Available and Unavailable count replicas or scheduled pods
+	Desired     int32
+	Current     int32
+	Ready       int32
+	Available   int32
+	Unavailable int32
+	// Pods are the pods owned by the workload
+	Pods []corev1.Pod
+	// Via is how the workload was found, one of the types.DiscoveredBy constants
+	Via string
+	// Object is the StatefulSet, DaemonSet or Deployment itself
+	Object metav1.Object
+
+	selector *metav1.LabelSelector
+}
+
+// ResourceGraph is the set of objects backing the runtime of a Dataset
+type ResourceGraph struct {
+	// Workloads are sorted by namespace and name
+	Workloads  []*Workload
+	Services   []corev1.Service
+	ConfigMaps []corev1.ConfigMap
+	// Pods are all discovered pods, including pods not owned by a discovered workload,
+	// sorted by namespace and name
+	Pods []corev1.Pod
+
+	// mu guards refs, the graph is otherwise only built from a single goroutine
+	mu   sync.Mutex
+	refs map[string]types.ResourceRef
+}
+
+func newResourceGraph() *ResourceGraph {
+	return &ResourceGraph{refs: map[string]types.ResourceRef{}}
+}
+
+// Component returns the workload of a runtime component (master, worker or fuse): the
+// workload whose pods have a role label ending in -<component>, or whose name ends in
+// -<component>. It returns nil if there is none.
+func (g *ResourceGraph) Component(component string) *Workload {
+	suffix := "-" + component
+	for _, w := range g.Workloads {
+		if strings.HasSuffix(w.PodLabels["role"], suffix) {
+			return w
+		}
+	}
+	for _, w := range g.Workloads {
+		if strings.HasSuffix(w.Name, suffix) {
+			return w
+		}
+	}
+	return nil
+}
+
+// ComponentPods returns the pods of a runtime component: the pods owned by its workload,
+// or the discovered pods whose role label ends in -<component> if none are owned.
+func (g *ResourceGraph) ComponentPods(component string) []corev1.Pod {
+	if w := g.Component(component); w != nil && len(w.Pods) > 0 {
+		return w.Pods
+	}
+
+	var pods []corev1.Pod
+	for _, pod := range g.Pods {
+		if strings.HasSuffix(pod.Labels["role"], "-"+component) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// RefOf returns the reference of a custom resource such as a Dataset or Runtime
+func RefOf(obj *unstructured.Unstructured) types.ResourceRef {
+	return types.ResourceRef{
+		Kind:      obj.GetKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		UID:       string(obj.GetUID()),
+	}
+}
+
+// Refs lists the discovered objects, sorted by kind, namespace and name
+func (g *ResourceGraph) Refs() []types.ResourceRef {
+	refs := make([]types.ResourceRef, 0, len(g.refs))
+	for _, ref := range g.refs {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		a, b := refs[i], refs[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return refs
+}
+
+// add records an object and reports whether it was not discovered before
+func (g *ResourceGraph) add(kind string, obj metav1.Object, via string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	key := kind + "/" + obj.GetNamespace() + "/" + obj.GetName()
+	if _, ok := g.refs[key]; ok {
+		return false
+	}
+	g.refs[key] = types.ResourceRef{Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), UID: string(obj.GetUID()), Via: via}
+	return true
+}
+
+func (g *ResourceGraph) addStatefulSet(sts *appsv1.StatefulSet, via string) {
+	if !g.add(KindStatefulSet, sts, via) {
+		return
+	}
+	var replicas int32 = 1
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	g.Workloads = append(g.Workloads, &Workload{
+		Kind:        KindStatefulSet,
+		Namespace:   sts.Namespace,
+		Name:        sts.Name,
+		UID:         sts.UID,
+		PodLabels:   sts.Spec.Template.Labels,
+		PodSpec:     sts.Spec.Template.Spec,
+		Desired:     replicas,
+		Current:     sts.Status.CurrentReplicas,
+		Ready:       sts.Status.ReadyReplicas,
+		Available:   sts.Status.AvailableReplicas,
+		Unavailable: replicas - sts.Status.ReadyReplicas,
+		Via:         via,
+		Object:      sts,
+		selector:    sts.Spec.Selector,
+	})
+}
+
+func (g *ResourceGraph) addDaemonSet(ds *appsv1.DaemonSet, via string) {
+	if !g.add(KindDaemonSet, ds, via) {
+		return
+	}
+	g.Workloads = append(g.Workloads, &Workload{
+		Kind:        KindDaemonSet,
+		Namespace:   ds.Namespace,
+		Name:        ds.Name,
+		UID:         ds.UID,
+		PodLabels:   ds.Spec.Template.Labels,
+		PodSpec:     ds.Spec.Template.Spec,
+		Desired:     ds.Status.DesiredNumberScheduled,
+		Current:     ds.Status.CurrentNumberScheduled,
+		Ready:       ds.Status.NumberReady,
+		Available:   ds.Status.NumberAvailable,
+		Unavailable: ds.Status.NumberUnavailable,
+		Via:         via,
+		Object:      ds,
+		selector:    ds.Spec.Selector,
+	})
+}
+
+func (g *ResourceGraph) addDeployment(deploy *appsv1.Deployment, via string) {
+	if !g.add(KindDeployment, deploy, via) {
+		return
+	}
+	var replicas int32 = 1
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	g.Workloads = append(g.Workloads, &Workload{
+		Kind:        KindDeployment,
+		Namespace:   deploy.Namespace,
+		Name:        deploy.Name,
+		UID:         deploy.UID,
+		PodLabels:   deploy.Spec.Template.Labels,
+		PodSpec:     deploy.Spec.Template.Spec,
+		Desired:     replicas,
+		Current:     deploy.Status.Replicas,
+		Ready:       deploy.Status.ReadyReplicas,
+		Available:   deploy.Status.AvailableReplicas,
+		Unavailable: deploy.Status.UnavailableReplicas,
+		Via:         via,
+		Object:      deploy,
+		selector:    deploy.Spec.Selector,
+	})
+}
+
+func (g *ResourceGraph) addPod(pod *corev1.Pod, via string) {
+	if g.add("Pod", pod, via) {
+		g.Pods = append(g.Pods, *pod)
+	}
+}
+
+// addObjects adds every object of a set for which keep returns true
+func (g *ResourceGraph) addObjects(set *objectSet, via string, keep func(obj metav1.Object) bool) {
+	for i := range set.statefulSets {
+		if keep(&set.statefulSets[i]) {
+			g.addStatefulSet(&set.statefulSets[i], via)
+		}
+	}
+	for i := range set.daemonSets {
+		if keep(&set.daemonSets[i]) {
+			g.addDaemonSet(&set.daemonSets[i], via)
+		}
+	}
+	for i := range set.deployments {
+		if keep(&set.deployments[i]) {
+			g.addDeployment(&set.deployments[i], via)
+		}
+	}
+	for i := range set.services {
+		if keep(&set.services[i]) && g.add("Service", &set.services[i], via) {
+			g.Services = append(g.Services, set.services[i])
+		}
+	}
+	for i := range set.configMaps {
+		if keep(&set.configMaps[i]) && g.add("ConfigMap", &set.configMaps[i], via) {
+			g.ConfigMaps = append(g.ConfigMaps, set.configMaps[i])
+		}
+	}
+	for i := range set.pods {
+		if keep(&set.pods[i]) {
+			g.addPod(&set.pods[i], via)
+		}
+	}
+}
+
+// DiscoverResources finds the objects backing the runtime of a Dataset. It collects the
+// StatefulSets, DaemonSets, Deployments, Services and ConfigMaps owned by the Runtime CR,
+// every object labelled with the Dataset in any namespace, and the pods of the found
+// workloads. When neither finds a workload, it falls back to the <dataset>-master,
+// <dataset>-worker and <dataset>-fuse workloads and the pods labelled release=<dataset>.
+// runtime may be nil. The owner pass lists every object of the runtime namespace; a cache,
+// which may be nil, shares those lists between the Datasets of a namespace. Objects that
+// could not be listed are reported in the error, which comes with the partial graph.
+func (c *Client) DiscoverResources(ctx context.Context, namespace, datasetName string, runtime *unstructured.Unstructured, cache *DiscoveryCache) (*ResourceGraph, error) {
+	graph := newResourceGraph()
+	var errs []error
+
+	// Objects owned by the Runtime CR
+	runtimeNamespace := namespace
+	if runtime != nil {
+		runtimeNamespace = runtime.GetNamespace()
+		owned, err := cache.listNamespace(ctx, c, runtimeNamespace)
+		errs = append(errs, err)
+		graph.addObjects(owned, types.DiscoveredByOwner, func(obj metav1.Object) bool {
+			return isOwnedBy(obj, runtime.GetUID())
+		})
+	}
+
+	// Objects labelled with the Dataset, in any namespace the credentials can list
+	errs = append(errs, c.discoverLabelled(ctx, graph, namespace, datasetName, runtimeNamespace))
+
+	// Fluid naming conventions
+	if len(graph.Workloads) == 0 {
+		errs = append(errs, c.discoverNamed(ctx, graph, namespace, datasetName))
+	}
+
+	errs = append(errs, c.discoverWorkloadPods(ctx, graph))
+	graph.sort()
+	return graph, errors.Join(errs...)
+}
+
+// discoverLabelled adds the objects labelled with the Dataset. Without permission to
+// list across namespaces it searches the Dataset and Runtime namespaces.
+func (c *Client) discoverLabelled(ctx context.Context, graph *ResourceGraph, namespace, datasetName, runtimeNamespace string) error {
+	selector := fmt.Sprintf("%s=%s,%s=%s", LabelDataset, datasetName, LabelDatasetNamespace, namespace)
+	all := func(metav1.Object) bool { return true }
+
+	labelled, err := c.listObjects(ctx, metav1.NamespaceAll, selector, true)
+	if err == nil || !IsForbidden(err) {
+		graph.addObjects(labelled, types.DiscoveredByLabel, all)
+		return err
+	}
+
+	var errs []error
+	for _, ns := range uniqueStrings(namespace, runtimeNamespace) {
+		labelled, err := c.listObjects(ctx, ns, selector, true)
+		errs = append(errs, err)
+		graph.addObjects(labelled, types.DiscoveredByLabel, all)
+	}
+	return errors.Join(errs...)
+}
+
+// discoverNamed adds the workloads and pods following the Fluid chart naming conventions
+func (c *Client) discoverNamed(ctx context.Context, graph *ResourceGraph, namespace, datasetName string) error {
+	// Each fetch fills its own variable; the graph is only built once they are all done
+	var master, worker *appsv1.StatefulSet
+	var fuse *appsv1.DaemonSet
+	var pods *corev1.PodList
+	errs := make([]error, 4)
+	parallel.Run(ctx, parallel.DefaultLimit,
+		func(ctx context.Context) {
+			master, errs[0] = c.GetStatefulSet(ctx, namespace, datasetName+"-master")
+		},
+		func(ctx context.Context) {
+			worker, errs[1] = c.GetStatefulSet(ctx, namespace, datasetName+"-worker")
+		},
+		func(ctx context.Context) {
+			fuse, errs[2] = c.GetDaemonSet(ctx, namespace, datasetName+"-fuse")
+		},
+		func(ctx context.Context) {
+			pods, errs[3] = c.GetPodsByLabel(ctx, namespace, "release="+datasetName)
+		},
+	)
+
+	for _, sts := range []*appsv1.StatefulSet{master, worker} {
+		if sts != nil {
+			graph.addStatefulSet(sts, types.DiscoveredByName)
+		}
+	}
+	if fuse != nil {
+		graph.addDaemonSet(fuse, types.DiscoveredByName)
+	}
+	if pods != nil {
+		for i := range pods.Items {
+			graph.addPod(&pods.Items[i], types.DiscoveredByName)
+		}
+	}
+
+	// Missing workloads are expected: not every runtime deploys every component
+	var result []error
+	for _, err := range errs {
+		if err != nil && !IsNotFound(err) {
+			result = append(result, err)
+		}
+	}
+	return errors.Join(result...)
+}
+
+// discoverWorkloadPods adds the pods owned by each workload, through its ReplicaSets for
+// Deployments. The pods of each workload are fetched concurrently and added to the graph
+// once every fetch is done.
+func (c *Client) discoverWorkloadPods(ctx context.Context, graph *ResourceGraph) error {
+	owned := make([][]corev1.Pod, len(graph.Workloads))
+	errs := make([]error, len(graph.Workloads))
+	parallel.ForEach(ctx, len(graph.Workloads), parallel.DefaultLimit, func(ctx context.Context, i int) {
+		owned[i], errs[i] = c.listWorkloadPods(ctx, graph.Workloads[i])
+	})
+
+	for i, w := range graph.Workloads {
+		w.Pods = owned[i]
+		for j := range w.Pods {
+			graph.addPod(&w.Pods[j], types.DiscoveredByOwner)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// listWorkloadPods lists the pods owned by a workload
+func (c *Client) listWorkloadPods(ctx context.Context, w *Workload) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(w.selector)
+	if w.selector == nil || err != nil || selector.Empty() {
+		return nil, nil
+	}
+
+	owners := map[apitypes.UID]bool{w.UID: true}
+	if w.Kind == KindDeployment {
+		replicaSets, err := c.clientset.AppsV1().ReplicaSets(w.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, newAPIError(err, "list", "replicasets", w.Namespace, "")
+		}
+		for _, rs := range replicaSets.Items {
+			if isOwnedBy(&rs, w.UID) {
+				owners[rs.UID] = true
+			}
+		}
+	}
+
+	pods, err := c.GetPodsByLabel(ctx, w.Namespace, selector.String())
+	if err != nil {
+		return nil, err
+	}
+	var result []corev1.Pod
+	for _, pod := range pods.Items {
+		for _, ref := range pod.OwnerReferences {
+			if owners[ref.UID] {
+				result = append(result, pod)
+				break
+			}
+		}
+	}
+	sortPods(result)
+	return result, nil
+}
+
+// sort orders the workloads and pods by namespace and name, so that Component picks the
+// same workload on every run
+func (g *ResourceGraph) sort() {
+	sort.SliceStable(g.Workloads, func(i, j int) bool {
+		a, b := g.Workloads[i], g.Workloads[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	sortPods(g.Pods)
+}
+
+func sortPods(pods []corev1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+}
+
+// DiscoveryCache shares the namespace-wide lists of the owner pass of DiscoverResources
+// between Datasets, so that discovering N Datasets of a namespace lists each kind once
+// rather than N times. It is safe for concurrent use. The cached objects are shared by the
+// graphs and must not be modified. Lists that fail are not cached, so that a Dataset whose
+// context expired does not fail the discovery of the other Datasets of the namespace.
+type DiscoveryCache struct {
+	mu    sync.Mutex
+	lists map[string]*cachedObjects
+}
+
+type cachedObjects struct {
+	mu  sync.Mutex // Held while listing, so that concurrent callers wait for the list
+	set *objectSet // Set once listed without error
+}
+
+// NewDiscoveryCache creates an empty DiscoveryCache
+func NewDiscoveryCache() *DiscoveryCache {
+	return &DiscoveryCache{lists: map[string]*cachedObjects{}}
+}
+
+// listNamespace lists the objects of a namespace once per cache, until a list succeeds;
+// a nil cache always lists
+func (dc *DiscoveryCache) listNamespace(ctx context.Context, c *Client, namespace string) (*objectSet, error) {
+	if dc == nil {
+		return c.listObjects(ctx, namespace, "", false)
+	}
+
+	dc.mu.Lock()
+	cached, ok := dc.lists[namespace]
+	if !ok {
+		cached = &cachedObjects{}
+		dc.lists[namespace] = cached
+	}
+	dc.mu.Unlock()
+
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+	if cached.set != nil {
+		return cached.set, nil
+	}
+	set, err := c.listObjects(ctx, namespace, "", false)
+	if err == nil {
+		cached.set = set
+	}
+	return set, err
+}
+
+// objectSet holds the objects of the kinds discovery looks for
+type objectSet struct {
+	statefulSets []appsv1.StatefulSet
+	daemonSets   []appsv1.DaemonSet
+	deployments  []appsv1.Deployment
+	services     []corev1.Service
+	configMaps   []corev1.ConfigMap
+	pods         []corev1.Pod
+}
+
+// listObjects lists the objects of every discovered kind matching a label selector, concurrently.
+// Pods are listed only if withPods is set. Kinds that could not be listed are left empty.
+func (c *Client) listObjects(ctx context.Context, namespace, labelSelector string, withPods bool) (*objectSet, error) {
+	set := &objectSet{}
+	opts := metav1.ListOptions{LabelSelector: labelSelector}
+	errs := make([]error, 6)
+
+	listers := []func(ctx context.Context){
+		func(ctx context.Context) {
+			list, err := c.clientset.AppsV1().StatefulSets(namespace).List(ctx, opts)
+			if err != nil {
+				errs[0] = newAPIError(err, "list", "statefulsets", namespace, "")
+				return
+			}
+			set.statefulSets = list.Items
+		},
+		func(ctx context.Context) {
+			list, err := c.clientset.AppsV1().DaemonSets(namespace).List(ctx, opts)
+			if err != nil {
+				errs[1] = newAPIError(err, "list", "daemonsets", namespace, "")
+				return
+			}
+			set.daemonSets = list.Items
+		},
+		func(ctx context.Context) {
+			list, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, opts)
+			if err != nil {
+				errs[2] = newAPIError(err, "list", "deployments", namespace, "")
+				return
+			}
+			set.deployments = list.Items
+		},
+		func(ctx context.Context) {
+			list, err := c.clientset.CoreV1().Services(namespace).List(ctx, opts)
+			if err != nil {
+				errs[3] = newAPIError(err, "list", "services", namespace, "")
+				return
+			}
+			set.services = list.Items
+		},
+		func(ctx context.Context) {
+			list, err := c.clientset.CoreV1().ConfigMaps(namespace).List(ctx, opts)
+			if err != nil {
+				errs[4] = newAPIError(err, "list", "configmaps", namespace, "")
+				return
+			}
+			set.configMaps = list.Items
+		},
+	}
+	if withPods {
+		listers = append(listers, func(ctx context.Context) {
+			list, err := c.clientset.CoreV1().Pods(namespace).List(ctx, opts)
+			if err != nil {
+				errs[5] = newAPIError(err, "list", "pods", namespace, "")
+				return
+			}
+			set.pods = list.Items
+		})
+	}
+
+	parallel.Run(ctx, parallel.DefaultLimit, listers...)
+	return set, errors.Join(errs...)
+}
+
+func isOwnedBy(obj metav1.Object, uid apitypes.UID) bool {
+	if uid == "" {
+		return false
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueStrings(values ...string) []string {
+	var result []string
+	seen := map[string]bool{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s/fake"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDiscoverResources(t *testing.T) {
+	runtimeCR := fake.Runtime("AlluxioRuntime", "default", "demo", nil)
+
+	// A chart release named differently from the Dataset, with the worker as a Deployment
+	master := fake.OwnedBy(fake.StatefulSet("default", "cache-master", 1, 1), "AlluxioRuntime", runtimeCR)
+	master.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "cache-master"}}
+	master.Spec.Template.Labels = map[string]string{"app": "cache-master", "role": "alluxio-master"}
+	masterPod := fake.OwnedBy(fake.Pod("default", "cache-master-0", "cache", "alluxio-master", true), "StatefulSet", master)
+	masterPod.Labels["app"] = "cache-master"
+
+	worker := fake.OwnedBy(fake.Deployment("default", "cache-worker", 1, 0), "AlluxioRuntime", runtimeCR)
+	worker.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "cache-worker"}}
+	worker.Spec.Template.Labels = map[string]string{"app": "cache-worker", "role": "alluxio-worker"}
+	replicaSet := fake.OwnedBy(&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name: "cache-worker-7d9f", Namespace: "default", UID: "replicaset-cache-worker-7d9f",
+		Labels: map[string]string{"app": "cache-worker"},
+	}}, "Deployment", worker)
+	workerPod := fake.OwnedBy(fake.Pod("default", "cache-worker-7d9f-x2k", "cache", "alluxio-worker", false), "ReplicaSet", replicaSet)
+	workerPod.Labels["app"] = "cache-worker"
+
+	// The fuse runs in another namespace and is only linked to the Dataset by labels
+	fuse := fake.DaemonSet("fluid-system", "demo-fuse", 2, 2)
+	fuse.Labels = map[string]string{k8s.LabelDataset: "demo", k8s.LabelDatasetNamespace: "default"}
+	fuse.Spec.Template.Labels = map[string]string{"role": "alluxio-fuse"}
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		runtime bool
+		check   func(t *testing.T, graph *k8s.ResourceGraph)
+	}{
+		{
+			name:    "owner references and labels",
+			objects: []runtime.Object{runtimeCR, master, masterPod, worker, replicaSet, workerPod, fuse},
+			runtime: true,
+			check: func(t *testing.T, graph *k8s.ResourceGraph) {
+				if w := graph.Component("master"); w == nil || w.Name != "cache-master" || w.Via != types.DiscoveredByOwner {
+					t.Errorf("master = %+v, want cache-master found via owner", w)
+				}
+				if pods := graph.ComponentPods("master"); len(pods) != 1 || pods[0].Name != "cache-master-0" {
+					t.Errorf("master pods = %v, want cache-master-0", pods)
+				}
+				w := graph.Component("worker")
+				if w == nil || w.Kind != k8s.KindDeployment || w.Unavailable != 1 {
+					t.Fatalf("worker = %+v, want an unavailable Deployment", w)
+				}
+				if pods := graph.ComponentPods("worker"); len(pods) != 1 || pods[0].Name != "cache-worker-7d9f-x2k" {
+					t.Errorf("worker pods = %v, want the pod of its ReplicaSet", pods)
+				}
+				if w := graph.Component("fuse"); w == nil || w.Namespace != "fluid-system" || w.Via != types.DiscoveredByLabel {
+					t.Errorf("fuse = %+v, want demo-fuse in fluid-system found via label", w)
+				}
+				var names []string
+				for _, w := range graph.Workloads {
+					names = append(names, w.Namespace+"/"+w.Name)
+				}
+				if want := "default/cache-master default/cache-worker fluid-system/demo-fuse"; strings.Join(names, " ") != want {
+					t.Errorf("workloads = %v, want sorted %s", names, want)
+				}
+			},
+		},
+		{
+			name: "naming conventions",
+			objects: []runtime.Object{
+				fake.StatefulSet("default", "demo-master", 1, 1),
+				fake.DaemonSet("default", "demo-fuse", 1, 1),
+				fake.Pod("default", "demo-master-0", "demo", "alluxio-master", true),
+				fake.Pod("default", "demo-fuse-abcde", "demo", "alluxio-fuse", true),
+				fake.Pod("default", "other-master-0", "other", "alluxio-master", true),
+			},
+			check: func(t *testing.T, graph *k8s.ResourceGraph) {
+				if w := graph.Component("master"); w == nil || w.Via != types.DiscoveredByName {
+					t.Errorf("master = %+v, want demo-master found via name", w)
+				}
+				if w := graph.Component("worker"); w != nil {
+					t.Errorf("worker = %+v, want none", w)
+				}
+				if pods := graph.ComponentPods("master"); len(pods) != 1 || pods[0].Name != "demo-master-0" {
+					t.Errorf("master pods = %v, want demo-master-0", pods)
+				}
+				if refs := graph.Refs(); len(refs) != 4 {
+					t.Errorf("refs = %v, want 4 objects", refs)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var owner *unstructured.Unstructured
+			if tt.runtime {
+				owner = runtimeCR
+			}
+			graph, err := fake.NewClient(tt.objects...).DiscoverResources(context.Background(), "default", "demo", owner, nil)
+			if err != nil {
+				t.Fatalf("DiscoverResources() error = %v", err)
+			}
+			tt.check(t, graph)
+		})
+	}
+}
+
+func TestDiscoveryCache(t *testing.T) {
+	runtimes := []*unstructured.Unstructured{
+		fake.Runtime("AlluxioRuntime", "default", "demo", nil),
+		fake.Runtime("AlluxioRuntime", "default", "other", nil),
+	}
+	var objects []runtime.Object
+	for _, r := range runtimes {
+		objects = append(objects, fake.OwnedBy(fake.StatefulSet("default", r.GetName()+"-master", 1, 1), "AlluxioRuntime", r))
+	}
+	clientset := kubefake.NewSimpleClientset(objects...)
+	client := k8s.NewClientFromInterfaces(clientset, nil)
+
+	cache := k8s.NewDiscoveryCache()
+	for _, r := range runtimes {
+		graph, err := client.DiscoverResources(context.Background(), "default", r.GetName(), r, cache)
+		if err != nil {
+			t.Fatalf("DiscoverResources(%s) error = %v", r.GetName(), err)
+		}
+		if w := graph.Component("master"); w == nil || w.Name != r.GetName()+"-master" {
+			t.Errorf("%s master = %+v, want its own StatefulSet", r.GetName(), w)
+		}
+	}
+
+	// The unselective owner-pass list of the namespace is shared by both Datasets
+	lists := 0
+	for _, action := range clientset.Actions() {
+		if list, ok := action.(k8stesting.ListAction); ok && action.GetResource().Resource == "statefulsets" &&
+			action.GetNamespace() == "default" && list.GetListRestrictions().Labels.Empty() {
+			lists++
+		}
+	}
+	if lists != 1 {
+		t.Errorf("listed every statefulset of the namespace %d times, want 1", lists)
+	}
+}
+
+func TestDiscoveryCacheRetriesFailedLists(t *testing.T) {
+	runtimes := []*unstructured.Unstructured{
+		fake.Runtime("AlluxioRuntime", "default", "demo", nil),
+		fake.Runtime("AlluxioRuntime", "default", "other", nil),
+	}
+	var objects []runtime.Object
+	for _, r := range runtimes {
+		objects = append(objects, fake.OwnedBy(fake.StatefulSet("default", r.GetName()+"-master", 1, 1), "AlluxioRuntime", r))
+	}
+	clientset := kubefake.NewSimpleClientset(objects...)
+	// The first list fails, as when the context of the first Dataset expires
+	failed := false
+	clientset.PrependReactor("list", "statefulsets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if failed {
+			return false, nil, nil
+		}
+		failed = true
+		return true, nil, context.DeadlineExceeded
+	})
+	client := k8s.NewClientFromInterfaces(clientset, nil)
+
+	cache := k8s.NewDiscoveryCache()
+	if _, err := client.DiscoverResources(context.Background(), "default", "demo", runtimes[0], cache); err == nil {
+		t.Fatal("expected the failed list to be reported")
+	}
+	graph, err := client.DiscoverResources(context.Background(), "default", "other", runtimes[1], cache)
+	if err != nil {
+		t.Fatalf("DiscoverResources(other) error = %v, want the list retried", err)
+	}
+	if w := graph.Component("master"); w == nil || w.Name != "other-master" {
+		t.Errorf("other master = %+v, want its own StatefulSet", w)
+	}
+}
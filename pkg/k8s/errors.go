@@ -0,0 +1,117 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Error classes of failed API calls, matched with errors.Is
+var (
+	// ErrNotFound means the requested object does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrForbidden means the credentials are rejected or lack RBAC permissions
+	ErrForbidden = errors.New("forbidden")
+	// ErrUnreachable means the API server could not be reached or did not answer in time
+	ErrUnreachable = errors.New("cluster unreachable")
+)
+
+// APIError describes a failed Kubernetes API call
+type APIError struct {
+	// Class is ErrNotFound, ErrForbidden, ErrUnreachable, or nil for other failures
+	Class     error
+	Verb      string
+	Resource  string
+	Namespace string
+	Name      string
+	Err       error
+}
+
+func (e *APIError) Error() string {
+	object := e.Resource
+	if e.Name != "" {
+		object += " " + e.Name
+		if e.Namespace != "" {
+			object = fmt.Sprintf("%s %s/%s", e.Resource, e.Namespace, e.Name)
+		}
+	} else if e.Namespace != "" {
+		object += " in namespace " + e.Namespace
+	}
+
+	if e.Class == ErrNotFound {
+		return object + " not found"
+	}
+	return fmt.Sprintf("failed to %s %s: %v", e.Verb, object, e.Err)
+}
+
+// Unwrap exposes both the error class and the underlying client error
+func (e *APIError) Unwrap() []error {
+	if e.Class == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Class, e.Err}
+}
+
+// newAPIError classifies an error returned by client-go
+func newAPIError(err error, verb, resource, namespace, name string) error {
+	return &APIError{
+		Class:     classify(err),
+		Verb:      verb,
+		Resource:  resource,
+		Namespace: namespace,
+		Name:      name,
+		Err:       err,
+	}
+}
+
+func classify(err error) error {
+	var netErr net.Error
+	var urlErr *url.Error
+
+	switch {
+	case apierrors.IsNotFound(err):
+		return ErrNotFound
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return ErrForbidden
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), apierrors.IsServiceUnavailable(err),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr), errors.As(err, &urlErr):
+		return ErrUnreachable
+	}
+	return nil
+}
+
+// IsNotFound reports whether err means the requested object does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsForbidden reports whether err means access was denied
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsUnreachable reports whether err means the API server could not be reached
+func IsUnreachable(err error) bool {
+	return errors.Is(err, ErrUnreachable)
+}
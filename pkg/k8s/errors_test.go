@@ -0,0 +1,90 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"syscall"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestAPIErrorClassification(t *testing.T) {
+	gr := schema.GroupResource{Group: "data.fluid.io", Resource: "datasets"}
+
+	tests := []struct {
+		name      string
+		err       error
+		wantClass error
+		wantMsg   string
+	}{
+		{
+			name:      "not found",
+			err:       apierrors.NewNotFound(gr, "demo"),
+			wantClass: ErrNotFound,
+			wantMsg:   "dataset default/demo not found",
+		},
+		{
+			name:      "forbidden",
+			err:       apierrors.NewForbidden(gr, "demo", errors.New("RBAC denied")),
+			wantClass: ErrForbidden,
+		},
+		{
+			name:      "unauthorized",
+			err:       apierrors.NewUnauthorized("token expired"),
+			wantClass: ErrForbidden,
+		},
+		{
+			name:      "connection refused",
+			err:       &url.Error{Op: "Get", URL: "https://10.0.0.1:6443", Err: syscall.ECONNREFUSED},
+			wantClass: ErrUnreachable,
+		},
+		{
+			name:      "deadline exceeded",
+			err:       fmt.Errorf("request: %w", context.DeadlineExceeded),
+			wantClass: ErrUnreachable,
+		},
+		{
+			name: "other",
+			err:  apierrors.NewBadRequest("invalid"),
+		},
+	}
+
+	classes := []error{ErrNotFound, ErrForbidden, ErrUnreachable}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", newAPIError(tt.err, "get", "dataset", "default", "demo"))
+
+			for _, class := range classes {
+				if got, want := errors.Is(err, class), class == tt.wantClass; got != want {
+					t.Errorf("errors.Is(%v) = %t, want %t", class, got, want)
+				}
+			}
+			if !errors.Is(err, tt.err) {
+				t.Error("underlying client error is not unwrapped")
+			}
+			if tt.wantMsg != "" && err.Error() != "wrapped: "+tt.wantMsg {
+				t.Errorf("message = %q, want %q", err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
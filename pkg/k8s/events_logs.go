@@ -19,98 +19,289 @@ package k8s
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
+	"time"
 
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/parallel"
 	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 )
 
-// GetEventsForObject fetches events related to an object
-func (c *Client) GetEventsForObject(ctx context.Context, namespace, name, uid string) ([]types.EventInfo, error) {
-	// Get events involving this object
-	eventList, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
-		FieldSelector: fmt.Sprintf("involvedObject.name=%s", name),
-	})
+// GetEventsForObject fetches the events of an object, matched by kind and name, and by UID when set
+func (c *Client) GetEventsForObject(ctx context.Context, object types.ResourceRef) ([]types.EventInfo, error) {
+	index, err := c.listEvents(ctx, object.Namespace, &object)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list events: %w", err)
+		return nil, err
 	}
+	events := index.lookup(object)
+	sortEvents(events)
+	return events, nil
+}
 
-	var events []types.EventInfo
-	for _, e := range eventList.Items {
-		events = append(events, types.EventInfo{
-			Type:           e.Type,
-			Reason:         e.Reason,
-			Message:        e.Message,
-			Count:          e.Count,
-			FirstTimestamp: e.FirstTimestamp.Time,
-			LastTimestamp:  e.LastTimestamp.Time,
-			Source:         e.Source.Component,
-			ObjectKind:     e.InvolvedObject.Kind,
-			ObjectName:     e.InvolvedObject.Name,
+// GetAllRelatedEvents fetches the events of the given objects, typically the Dataset, its
+// Runtime and the discovered runtime workloads and pods. The events of each namespace are
+// listed once and matched to the objects in memory; a namespace holding a single object is
+// filtered server-side instead. Namespaces are listed concurrently; the events of the other
+// namespaces are returned along with the errors of those whose events cannot be listed.
+func (c *Client) GetAllRelatedEvents(ctx context.Context, objects []types.ResourceRef) ([]types.EventInfo, error) {
+	byNamespace := map[string][]types.ResourceRef{}
+	var namespaces []string
+	for _, obj := range objects {
+		if _, ok := byNamespace[obj.Namespace]; !ok {
+			namespaces = append(namespaces, obj.Namespace)
+		}
+		byNamespace[obj.Namespace] = append(byNamespace[obj.Namespace], obj)
+	}
+
+	perNamespace := make([][]types.EventInfo, len(namespaces))
+	errs := make([]error, len(namespaces))
+	parallel.ForEach(ctx, len(namespaces), parallel.DefaultLimit, func(ctx context.Context, i int) {
+		refs := byNamespace[namespaces[i]]
+		var only *types.ResourceRef
+		if len(refs) == 1 {
+			only = &refs[0]
+		}
+		index, err := c.listEvents(ctx, namespaces[i], only)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		for _, ref := range refs {
+			perNamespace[i] = append(perNamespace[i], index.lookup(ref)...)
+		}
+	})
+
+	var allEvents []types.EventInfo
+	for _, events := range perNamespace {
+		allEvents = append(allEvents, events...)
+	}
+	sortEvents(allEvents)
+
+	return allEvents, errors.Join(errs...)
+}
+
+// EventsGroup returns the API group events are read from: events.k8s.io when the
+// cluster serves events.k8s.io/v1, otherwise the core group ("")
+func (c *Client) EventsGroup() string {
+	if c.eventsV1Served() {
+		return eventsv1.GroupName
+	}
+	return corev1.GroupName
+}
+
+// eventsV1Served reports whether the cluster serves events.k8s.io/v1, discovered on first use
+func (c *Client) eventsV1Served() bool {
+	c.eventsOnce.Do(func() {
+		list, err := c.clientset.Discovery().ServerResourcesForGroupVersion(eventsv1.SchemeGroupVersion.String())
+		if err != nil {
+			return
+		}
+		for _, r := range list.APIResources {
+			if r.Name == "events" {
+				c.eventsV1 = true
+			}
+		}
+	})
+	return c.eventsV1
+}
+
+// listEvents lists the events of a namespace through events.k8s.io/v1, or core/v1 on
+// clusters that do not serve it. When object is set, only its events are requested.
+func (c *Client) listEvents(ctx context.Context, namespace string, object *types.ResourceRef) (eventIndex, error) {
+	index := eventIndex{}
+
+	if c.eventsV1Served() {
+		list, err := c.clientset.EventsV1().Events(namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: eventFieldSelector("regarding", object),
 		})
+		if err != nil {
+			return nil, newAPIError(err, "list", "events in", "", namespace)
+		}
+		for i := range list.Items {
+			index.add(list.Items[i].Regarding, eventV1Info(&list.Items[i]))
+		}
+		return index, nil
 	}
 
-	// Sort by last timestamp (most recent first)
-	sort.Slice(events, func(i, j int) bool {
-		return events[i].LastTimestamp.After(events[j].LastTimestamp)
+	list, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: eventFieldSelector("involvedObject", object),
 	})
+	if err != nil {
+		return nil, newAPIError(err, "list", "events in", "", namespace)
+	}
+	for i := range list.Items {
+		index.add(list.Items[i].InvolvedObject, coreEventInfo(&list.Items[i]))
+	}
+	return index, nil
+}
 
-	return events, nil
+// eventFieldSelector selects the events of an object through the given reference field,
+// regarding for events.k8s.io/v1 or involvedObject for core/v1
+func eventFieldSelector(field string, object *types.ResourceRef) string {
+	if object == nil {
+		return ""
+	}
+	set := fields.Set{
+		field + ".kind": object.Kind,
+		field + ".name": object.Name,
+	}
+	if object.UID != "" {
+		set[field+".uid"] = object.UID
+	}
+	return fields.SelectorFromSet(set).String()
 }
 
-// GetAllRelatedEvents fetches events for Dataset, Runtime, and related resources
-func (c *Client) GetAllRelatedEvents(ctx context.Context, namespace, datasetName string) ([]types.EventInfo, error) {
-	var allEvents []types.EventInfo
+// indexedEvent is an event and the UID of the object it involves
+type indexedEvent struct {
+	uid  string
+	info types.EventInfo
+}
 
-	// Events for Dataset
-	datasetEvents, _ := c.GetEventsForObject(ctx, namespace, datasetName, "")
-	allEvents = append(allEvents, datasetEvents...)
+// eventIndex indexes events by the kind, namespace and name of the object they involve
+type eventIndex map[string][]indexedEvent
 
-	// Events for Runtime resources (using naming convention)
-	resourceNames := []string{
-		datasetName + "-master",
-		datasetName + "-worker",
-		datasetName + "-fuse",
+func eventKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+func (idx eventIndex) add(object corev1.ObjectReference, info types.EventInfo) {
+	key := eventKey(object.Kind, object.Namespace, object.Name)
+	idx[key] = append(idx[key], indexedEvent{uid: string(object.UID), info: info})
+}
+
+// lookup returns the events of an object. When both UIDs are known, events of an
+// earlier object with the same name are skipped.
+func (idx eventIndex) lookup(object types.ResourceRef) []types.EventInfo {
+	var events []types.EventInfo
+	for _, e := range idx[eventKey(object.Kind, object.Namespace, object.Name)] {
+		if object.UID != "" && e.uid != "" && e.uid != object.UID {
+			continue
+		}
+		events = append(events, e.info)
 	}
+	return events
+}
 
-	for _, name := range resourceNames {
-		events, _ := c.GetEventsForObject(ctx, namespace, name, "")
-		allEvents = append(allEvents, events...)
+// coreEventInfo converts a core/v1 event. Events recorded through events.k8s.io/v1 leave
+// the count and first/last timestamps empty and report an event time and series instead.
+func coreEventInfo(e *corev1.Event) types.EventInfo {
+	info := types.EventInfo{
+		Type:           e.Type,
+		Reason:         e.Reason,
+		Message:        e.Message,
+		Count:          e.Count,
+		FirstTimestamp: firstTime(e.FirstTimestamp.Time, e.EventTime.Time, e.CreationTimestamp.Time),
+		LastTimestamp:  e.LastTimestamp.Time,
+		Source:         e.Source.Component,
+		ObjectKind:     e.InvolvedObject.Kind,
+		ObjectName:     e.InvolvedObject.Name,
+	}
+	if info.Source == "" {
+		info.Source = e.ReportingController
 	}
+	if e.Series != nil {
+		info.Count = e.Series.Count
+		info.LastTimestamp = e.Series.LastObservedTime.Time
+	}
+	return completeEventInfo(info)
+}
 
-	// Events for pods matching the release label
-	podList, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: fmt.Sprintf("release=%s", datasetName),
-	})
-	if err == nil {
-		for _, pod := range podList.Items {
-			podEvents, _ := c.GetEventsForObject(ctx, namespace, pod.Name, "")
-			allEvents = append(allEvents, podEvents...)
+// eventV1Info converts an events.k8s.io/v1 event
+func eventV1Info(e *eventsv1.Event) types.EventInfo {
+	info := types.EventInfo{
+		Type:           e.Type,
+		Reason:         e.Reason,
+		Message:        e.Note,
+		Count:          e.DeprecatedCount,
+		FirstTimestamp: firstTime(e.DeprecatedFirstTimestamp.Time, e.EventTime.Time, e.CreationTimestamp.Time),
+		LastTimestamp:  e.DeprecatedLastTimestamp.Time,
+		Source:         e.ReportingController,
+		ObjectKind:     e.Regarding.Kind,
+		ObjectName:     e.Regarding.Name,
+	}
+	if info.Source == "" {
+		info.Source = e.DeprecatedSource.Component
+	}
+	if e.Series != nil {
+		info.Count = e.Series.Count
+		info.LastTimestamp = e.Series.LastObservedTime.Time
+	}
+	return completeEventInfo(info)
+}
+
+// completeEventInfo fills in the count and last timestamp of a single occurrence
+func completeEventInfo(info types.EventInfo) types.EventInfo {
+	if info.Count == 0 {
+		info.Count = 1
+	}
+	info.LastTimestamp = firstTime(info.LastTimestamp, info.FirstTimestamp)
+	return info
+}
+
+// firstTime returns the first non-zero time
+func firstTime(times ...time.Time) time.Time {
+	for _, t := range times {
+		if !t.IsZero() {
+			return t
 		}
 	}
+	return time.Time{}
+}
 
-	// Sort all events chronologically
-	sort.Slice(allEvents, func(i, j int) bool {
-		return allEvents[i].LastTimestamp.After(allEvents[j].LastTimestamp)
+// sortEvents sorts events by last occurrence, most recent first
+func sortEvents(events []types.EventInfo) {
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if !a.LastTimestamp.Equal(b.LastTimestamp) {
+			return a.LastTimestamp.After(b.LastTimestamp)
+		}
+		if a.ObjectKind != b.ObjectKind {
+			return a.ObjectKind < b.ObjectKind
+		}
+		return a.ObjectName < b.ObjectName
 	})
+}
 
-	return allEvents, nil
+// LogOptions selects the container logs to fetch
+type LogOptions struct {
+	Container string
+	// TailLines is the number of lines from the end of the log; negative fetches the whole log
+	TailLines int64
+	// Since fetches only the lines newer than this duration; zero means no limit
+	Since time.Duration
+	// LimitBytes caps the size of the log; zero means no limit
+	LimitBytes int64
+	// Previous fetches the logs of the previous, terminated instance of the container
+	Previous bool
 }
 
 // GetPodLogs fetches logs from a pod container
-func (c *Client) GetPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines int64) (string, error) {
+func (c *Client) GetPodLogs(ctx context.Context, namespace, podName string, logOpts LogOptions) (string, error) {
 	opts := &corev1.PodLogOptions{
-		Container: containerName,
-		TailLines: &tailLines,
+		Container: logOpts.Container,
+		Previous:  logOpts.Previous,
+	}
+	if logOpts.TailLines >= 0 {
+		opts.TailLines = &logOpts.TailLines
+	}
+	if logOpts.Since > 0 {
+		seconds := int64(logOpts.Since.Round(time.Second).Seconds())
+		opts.SinceSeconds = &seconds
+	}
+	if logOpts.LimitBytes > 0 {
+		opts.LimitBytes = &logOpts.LimitBytes
 	}
 
 	req := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, opts)
 	stream, err := req.Stream(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get log stream: %w", err)
+		return "", newAPIError(err, "get", "logs of pod", namespace, podName)
 	}
 	defer stream.Close()
 
@@ -125,19 +316,57 @@ func (c *Client) GetPodLogs(ctx context.Context, namespace, podName, containerNa
 
 // GetPodsByLabel fetches pods by label selector
 func (c *Client) GetPodsByLabel(ctx context.Context, namespace, labelSelector string) (*corev1.PodList, error) {
-	return c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+	list, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: labelSelector,
 	})
+	if err != nil {
+		return nil, newAPIError(err, "list", "pods", namespace, "")
+	}
+	return list, nil
+}
+
+// ListPodsMountingPVC lists the pods of a namespace with a volume referencing the PVC
+func (c *Client) ListPodsMountingPVC(ctx context.Context, namespace, claimName string) ([]corev1.Pod, error) {
+	list, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, newAPIError(err, "list", "pods", namespace, "")
+	}
+
+	var pods []corev1.Pod
+	for _, pod := range list.Items {
+		if PodVolumeForClaim(&pod, claimName) != "" {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+// PodVolumeForClaim returns the name of the pod volume referencing the PVC, or "" if there is none
+func PodVolumeForClaim(pod *corev1.Pod, claimName string) string {
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == claimName {
+			return v.Name
+		}
+	}
+	return ""
 }
 
 // GetPod fetches a single pod
 func (c *Client) GetPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
-	return c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, newAPIError(err, "get", "pod", namespace, name)
+	}
+	return pod, nil
 }
 
 // GetPV fetches a PersistentVolume
 func (c *Client) GetPV(ctx context.Context, name string) (*corev1.PersistentVolume, error) {
-	return c.clientset.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
+	pv, err := c.clientset.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, newAPIError(err, "get", "pv", "", name)
+	}
+	return pv, nil
 }
 
 // ListPodsByOwner lists pods owned by a specific controller
@@ -149,7 +378,7 @@ func (c *Client) ListPodsByOwner(ctx context.Context, namespace, ownerName, owne
 		LabelSelector: labelSelector,
 	})
 	if err != nil {
-		return nil, err
+		return nil, newAPIError(err, "list", "pods", namespace, "")
 	}
 
 	var matchingPods []corev1.Pod
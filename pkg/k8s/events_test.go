@@ -0,0 +1,98 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s/fake"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetAllRelatedEvents(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Recorded through events.k8s.io/v1: no count or first/last timestamps
+	seriesEvent := fake.Event("default", "Pod", "demo-worker-0", "Warning", "BackOff", "back-off restarting")
+	seriesEvent.Count = 0
+	seriesEvent.EventTime = metav1.NewMicroTime(base)
+	seriesEvent.Series = &corev1.EventSeries{Count: 12, LastObservedTime: metav1.NewMicroTime(base.Add(10 * time.Minute))}
+
+	mountEvent := fake.Event("default", "Pod", "demo-fuse-abcde", "Warning", "FailedMount", "mount failed")
+	mountEvent.FirstTimestamp = metav1.NewTime(base.Add(time.Minute))
+	mountEvent.LastTimestamp = metav1.NewTime(base.Add(5 * time.Minute))
+
+	// A Service sharing the pod's name, and an earlier pod with the same name
+	serviceEvent := fake.Event("default", "Service", "demo-worker-0", "Warning", "SyncLoadBalancerFailed", "unrelated service")
+	staleEvent := fake.Event("default", "Pod", "demo-fuse-abcde", "Warning", "Evicted", "previous pod")
+	staleEvent.Name = "stale"
+	staleEvent.InvolvedObject.UID = "pod-demo-fuse-abcde-old"
+
+	// The only object of its namespace is filtered server-side
+	datasetEvent := fake.Event("fluid-system", "Dataset", "demo", "Normal", "Bound", "dataset bound")
+	datasetEvent.EventTime = metav1.NewMicroTime(base.Add(2 * time.Minute))
+
+	objects := []runtime.Object{seriesEvent, mountEvent, serviceEvent, staleEvent, datasetEvent}
+	refs := []types.ResourceRef{
+		{Kind: "Dataset", Namespace: "fluid-system", Name: "demo", UID: "dataset-demo"},
+		{Kind: "Pod", Namespace: "default", Name: "demo-worker-0", UID: "pod-demo-worker-0"},
+		{Kind: "Pod", Namespace: "default", Name: "demo-fuse-abcde", UID: "pod-demo-fuse-abcde"},
+	}
+
+	clients := map[string]*k8s.Client{
+		"events.k8s.io/v1": fake.NewClient(objects...),
+		"core/v1":          k8s.NewClientFromInterfaces(kubefake.NewSimpleClientset(objects...), nil),
+	}
+	for api, client := range clients {
+		t.Run(api, func(t *testing.T) {
+			events, err := client.GetAllRelatedEvents(context.Background(), refs)
+			if err != nil {
+				t.Fatalf("GetAllRelatedEvents() error = %v", err)
+			}
+
+			want := []struct {
+				reason string
+				count  int32
+				last   time.Time
+			}{
+				{"BackOff", 12, base.Add(10 * time.Minute)},
+				{"FailedMount", 1, base.Add(5 * time.Minute)},
+				{"Bound", 1, base.Add(2 * time.Minute)},
+			}
+			if len(events) != len(want) {
+				t.Fatalf("events = %+v, want %d events", events, len(want))
+			}
+			for i, w := range want {
+				e := events[i]
+				if e.Reason != w.reason || e.Count != w.count || !e.LastTimestamp.Equal(w.last) {
+					t.Errorf("event %d = %s count %d last %s, want %s count %d last %s",
+						i, e.Reason, e.Count, e.LastTimestamp, w.reason, w.count, w.last)
+				}
+			}
+			if !events[0].FirstTimestamp.Equal(base) {
+				t.Errorf("series first timestamp = %s, want the event time %s", events[0].FirstTimestamp, base)
+			}
+		})
+	}
+}
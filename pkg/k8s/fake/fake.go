@@ -0,0 +1,538 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides a k8s.Client backed by fake clientsets, plus
+// fixture builders for Fluid and Kubernetes objects. It lets the inspect
+// and diagnose pipelines be tested without a live cluster.
+package fake
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// FluidAPIVersion is the apiVersion of every Fluid fixture
+const FluidAPIVersion = "data.fluid.io/v1alpha1"
+
+// Defaults of the ControlPlane fixture
+const (
+	FluidVersion = "v1.0.4"
+	WebhookName  = "fluid-pod-admission-webhook"
+)
+
+// fluidListKinds registers the list kinds the dynamic fake client needs for Fluid resources
+var fluidListKinds = map[schema.GroupVersionResource]string{
+	{Group: "data.fluid.io", Version: "v1alpha1", Resource: "datasets"}:         "DatasetList",
+	{Group: "data.fluid.io", Version: "v1alpha1", Resource: "alluxioruntimes"}:  "AlluxioRuntimeList",
+	{Group: "data.fluid.io", Version: "v1alpha1", Resource: "cacheruntimes"}:    "CacheRuntimeList",
+	{Group: "data.fluid.io", Version: "v1alpha1", Resource: "jindoruntimes"}:    "JindoRuntimeList",
+	{Group: "data.fluid.io", Version: "v1alpha1", Resource: "juicefsruntimes"}:  "JuiceFSRuntimeList",
+	{Group: "data.fluid.io", Version: "v1alpha1", Resource: "efcruntimes"}:      "EFCRuntimeList",
+	{Group: "data.fluid.io", Version: "v1alpha1", Resource: "thinruntimes"}:     "ThinRuntimeList",
+	{Group: "data.fluid.io", Version: "v1alpha1", Resource: "vineyardruntimes"}: "VineyardRuntimeList",
+	{Group: "data.fluid.io", Version: "v1alpha1", Resource: "goosefsruntimes"}:  "GooseFSRuntimeList",
+}
+
+// NewClient creates a k8s.Client serving the given objects.
+// Unstructured objects are served by the dynamic client, all others by the typed clientset.
+func NewClient(objects ...runtime.Object) *k8s.Client {
+	return NewClientDenying(nil, objects...)
+}
+
+// Denied is an API access the fake client rejects as Forbidden, as RBAC would
+type Denied struct {
+	Verb     string // e.g. get, list
+	Resource string // e.g. pods, goosefsruntimes
+}
+
+// NewClientDenying creates a k8s.Client like NewClient that rejects the denied accesses
+func NewClientDenying(denied []Denied, objects ...runtime.Object) *k8s.Client {
+	var typed, dynamic []runtime.Object
+	for _, obj := range objects {
+		if _, ok := obj.(*unstructured.Unstructured); ok {
+			dynamic = append(dynamic, obj)
+		} else {
+			typed = append(typed, obj)
+		}
+	}
+
+	clientset := kubefake.NewSimpleClientset(typed...)
+	clientset.PrependReactor("list", "events", eventListReactor(clientset))
+	clientset.Resources = append(fluidAPIResources(), &metav1.APIResourceList{
+		GroupVersion: eventsv1.SchemeGroupVersion.String(),
+		APIResources: []metav1.APIResource{{Name: "events", Namespaced: true, Kind: "Event"}},
+	})
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), fluidListKinds, dynamic...)
+
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", accessReviewReactor(denied))
+	for _, d := range denied {
+		clientset.PrependReactor(d.Verb, d.Resource, forbiddenReactor)
+		dynamicClient.PrependReactor(d.Verb, d.Resource, forbiddenReactor)
+	}
+
+	return k8s.NewClientFromInterfaces(clientset, dynamicClient)
+}
+
+func forbiddenReactor(action k8stesting.Action) (bool, runtime.Object, error) {
+	var name string
+	if get, ok := action.(k8stesting.GetAction); ok {
+		name = get.GetName()
+	}
+	gr := action.GetResource().GroupResource()
+	return true, nil, apierrors.NewForbidden(gr, name, fmt.Errorf("%s %s is denied", action.GetVerb(), gr))
+}
+
+// accessReviewReactor answers SelfSubjectAccessReviews, allowing every access but the denied ones.
+// A denied resource matches the reviewed resource with or without its subresource, e.g. pods/log.
+func accessReviewReactor(denied []Denied) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		create, ok := action.(k8stesting.CreateAction)
+		if !ok {
+			return false, nil, nil
+		}
+		review, ok := create.GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		if !ok || review.Spec.ResourceAttributes == nil {
+			return false, nil, nil
+		}
+		attrs := review.Spec.ResourceAttributes
+		review = review.DeepCopy()
+		review.Status.Allowed = true
+		for _, d := range denied {
+			if d.Verb == attrs.Verb && (d.Resource == attrs.Resource || d.Resource == attrs.Resource+"/"+attrs.Subresource) {
+				review.Status.Allowed = false
+				review.Status.Reason = fmt.Sprintf("%s %s is denied", d.Verb, d.Resource)
+			}
+		}
+		return true, review, nil
+	}
+}
+
+// fluidAPIResources returns the discovery document of the Fluid resources served by the fake client
+func fluidAPIResources() []*metav1.APIResourceList {
+	list := &metav1.APIResourceList{GroupVersion: FluidAPIVersion}
+	for gvr, listKind := range fluidListKinds {
+		list.APIResources = append(list.APIResources,
+			metav1.APIResource{Name: gvr.Resource, Namespaced: true, Kind: strings.TrimSuffix(listKind, "List")},
+			metav1.APIResource{Name: gvr.Resource + "/status", Namespaced: true, Kind: strings.TrimSuffix(listKind, "List")},
+		)
+	}
+	sort.Slice(list.APIResources, func(i, j int) bool {
+		return list.APIResources[i].Name < list.APIResources[j].Name
+	})
+	return []*metav1.APIResourceList{list}
+}
+
+// eventListReactor applies the involvedObject and regarding field selectors, which the fake
+// tracker ignores. Like the API server, it serves the stored core/v1 events through events.k8s.io/v1.
+func eventListReactor(clientset *kubefake.Clientset) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		listAction, ok := action.(k8stesting.ListAction)
+		if !ok {
+			return false, nil, nil
+		}
+		v1 := action.GetResource().Group == eventsv1.GroupName
+		selector := listAction.GetListRestrictions().Fields
+		if !v1 && (selector == nil || selector.Empty()) {
+			return false, nil, nil
+		}
+
+		obj, err := clientset.Tracker().List(
+			corev1.SchemeGroupVersion.WithResource("events"),
+			corev1.SchemeGroupVersion.WithKind("Event"),
+			action.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+
+		coreList := &corev1.EventList{}
+		v1List := &eventsv1.EventList{}
+		for _, e := range obj.(*corev1.EventList).Items {
+			if selector != nil && !selector.Matches(eventFields(&e, v1)) {
+				continue
+			}
+			if v1 {
+				v1List.Items = append(v1List.Items, toEventV1(&e))
+			} else {
+				coreList.Items = append(coreList.Items, e)
+			}
+		}
+		if v1 {
+			return true, v1List, nil
+		}
+		return true, coreList, nil
+	}
+}
+
+// eventFields returns the selectable fields of an event, under regarding for events.k8s.io/v1
+func eventFields(e *corev1.Event, v1 bool) fields.Set {
+	ref := "involvedObject"
+	if v1 {
+		ref = "regarding"
+	}
+	return fields.Set{
+		ref + ".kind":      e.InvolvedObject.Kind,
+		ref + ".name":      e.InvolvedObject.Name,
+		ref + ".namespace": e.InvolvedObject.Namespace,
+		ref + ".uid":       string(e.InvolvedObject.UID),
+		"reason":           e.Reason,
+		"type":             e.Type,
+	}
+}
+
+// toEventV1 converts a core/v1 event to events.k8s.io/v1
+func toEventV1(e *corev1.Event) eventsv1.Event {
+	return eventsv1.Event{
+		ObjectMeta:               e.ObjectMeta,
+		EventTime:                e.EventTime,
+		Series:                   toEventSeriesV1(e.Series),
+		ReportingController:      e.ReportingController,
+		ReportingInstance:        e.ReportingInstance,
+		Action:                   e.Action,
+		Reason:                   e.Reason,
+		Regarding:                e.InvolvedObject,
+		Related:                  e.Related,
+		Note:                     e.Message,
+		Type:                     e.Type,
+		DeprecatedSource:         e.Source,
+		DeprecatedFirstTimestamp: e.FirstTimestamp,
+		DeprecatedLastTimestamp:  e.LastTimestamp,
+		DeprecatedCount:          e.Count,
+	}
+}
+
+func toEventSeriesV1(series *corev1.EventSeries) *eventsv1.EventSeries {
+	if series == nil {
+		return nil
+	}
+	return &eventsv1.EventSeries{Count: series.Count, LastObservedTime: series.LastObservedTime}
+}
+
+// Dataset builds a Dataset CR in the given phase
+func Dataset(namespace, name, phase string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": FluidAPIVersion,
+		"kind":       "Dataset",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"uid":       "dataset-" + name,
+		},
+		"spec": map[string]interface{}{
+			"mounts": []interface{}{
+				map[string]interface{}{
+					"mountPoint": "s3://bucket/" + name,
+					"name":       name,
+				},
+			},
+		},
+		"status": map[string]interface{}{
+			"phase": phase,
+		},
+	}}
+}
+
+// Runtime builds a Runtime CR of the given kind (e.g. AlluxioRuntime) with the given status fields
+func Runtime(kind, namespace, name string, status map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": FluidAPIVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"uid":       strings.ToLower(kind) + "-" + name,
+		},
+		"spec": map[string]interface{}{},
+	}}
+	if status != nil {
+		obj.Object["status"] = status
+	}
+	return obj
+}
+
+// StatefulSet builds a StatefulSet with the given desired and ready replicas
+func StatefulSet(namespace, name string, replicas, ready int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: apitypes.UID("statefulset-" + name)},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:          replicas,
+			ReadyReplicas:     ready,
+			CurrentReplicas:   replicas,
+			AvailableReplicas: ready,
+		},
+	}
+}
+
+// DaemonSet builds a DaemonSet with the given desired and ready pod counts
+func DaemonSet(namespace, name string, desired, ready int32) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: apitypes.UID("daemonset-" + name)},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: desired,
+			CurrentNumberScheduled: desired,
+			NumberReady:            ready,
+			NumberAvailable:        ready,
+			NumberUnavailable:      desired - ready,
+		},
+	}
+}
+
+// Deployment builds a Deployment with the given desired and ready replicas
+func Deployment(namespace, name string, replicas, ready int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: apitypes.UID("deployment-" + name)},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			Replicas:            replicas,
+			ReadyReplicas:       ready,
+			AvailableReplicas:   ready,
+			UnavailableReplicas: replicas - ready,
+		},
+	}
+}
+
+// ControlPlane builds a ready Fluid control plane in namespace: the dataset controller,
+// the given runtime controller (e.g. alluxioruntime-controller) and the webhook, each with
+// a ready pod running the FluidVersion image, the CSI nodeplugin DaemonSet with a ready pod
+// on node-1, the Fluid CSIDriver, and the webhook configuration with a ready endpoint
+func ControlPlane(namespace, runtimeController string) []runtime.Object {
+	var objects []runtime.Object
+	for _, name := range []string{"dataset-controller", runtimeController, "fluid-webhook"} {
+		labels := map[string]string{"control-plane": name}
+		deploy := Deployment(namespace, name, 1, 1)
+		deploy.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+		deploy.Spec.Template.Labels = labels
+		deploy.Spec.Template.Spec.Containers = []corev1.Container{{Name: "manager", Image: "fluidcloudnative/" + name + ":" + FluidVersion}}
+		replicaSet := OwnedBy(&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: name + "-5d8f", Namespace: namespace, UID: apitypes.UID("replicaset-" + name + "-5d8f"), Labels: labels,
+		}}, "Deployment", deploy)
+		pod := OwnedBy(Pod(namespace, name+"-5d8f-x7k2p", "", "manager", true), "ReplicaSet", replicaSet)
+		pod.Labels = labels
+		objects = append(objects, deploy, replicaSet, pod)
+	}
+
+	labels := map[string]string{"app": "csi-nodeplugin-fluid"}
+	plugin := DaemonSet(namespace, "csi-nodeplugin-fluid", 1, 1)
+	plugin.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	plugin.Spec.Template.Labels = labels
+	plugin.Spec.Template.Spec.Containers = []corev1.Container{{Name: "plugins", Image: "fluidcloudnative/fluid-csi:" + FluidVersion}}
+	pod := OwnedBy(Pod(namespace, "csi-nodeplugin-fluid-abcde", "", "plugins", true), "DaemonSet", plugin)
+	pod.Labels = labels
+
+	return append(objects, plugin, pod, CSIDriver(k8s.CSIDriverName),
+		Webhook(namespace, WebhookName), EndpointSlice(namespace, WebhookName, true))
+}
+
+// Webhook builds a MutatingWebhookConfiguration calling the Service of the same name in namespace
+func Webhook(namespace, name string) *admissionregistrationv1.MutatingWebhookConfiguration {
+	failurePolicy := admissionregistrationv1.Ignore
+	return &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{{
+			Name: "fluid-pod-admission-webhook.fluid.io",
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{
+				Service:  &admissionregistrationv1.ServiceReference{Namespace: namespace, Name: name},
+				CABundle: []byte("ca"),
+			},
+			FailurePolicy: &failurePolicy,
+		}},
+	}
+}
+
+// EndpointSlice builds an EndpointSlice of a Service with a single endpoint
+func EndpointSlice(namespace, service string, ready bool) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      service + "-x1",
+			Namespace: namespace,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses:  []string{"10.0.0.8"},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		}},
+	}
+}
+
+// CSIDriver builds a CSIDriver that requires no attachment
+func CSIDriver(name string) *storagev1.CSIDriver {
+	attachRequired := false
+	return &storagev1.CSIDriver{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       storagev1.CSIDriverSpec{AttachRequired: &attachRequired},
+	}
+}
+
+// OwnedBy adds an owner reference to owner, an object of the given kind, to obj and returns obj
+func OwnedBy[T metav1.Object](obj T, kind string, owner metav1.Object) T {
+	obj.SetOwnerReferences(append(obj.GetOwnerReferences(), metav1.OwnerReference{
+		Kind: kind,
+		Name: owner.GetName(),
+		UID:  owner.GetUID(),
+	}))
+	return obj
+}
+
+// Pod builds a pod for a runtime component, labelled the way Fluid charts label them.
+// A pod that is not ready is left waiting in CrashLoopBackOff.
+func Pod(namespace, name, release, role string, ready bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       apitypes.UID("pod-" + name),
+			Labels: map[string]string{
+				"release": release,
+				"role":    role,
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:   "node-1",
+			Containers: []corev1.Container{{Name: role}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	readyStatus := corev1.ConditionTrue
+	state := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	if !ready {
+		readyStatus = corev1.ConditionFalse
+		state = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+			Reason:  "CrashLoopBackOff",
+			Message: "back-off restarting failed container",
+		}}
+	}
+
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  role,
+		Ready: ready,
+		State: state,
+	}}
+	return pod
+}
+
+// AppPod builds an application pod on nodeName mounting the claim as volume "data".
+// A pod that is not ready is stuck in ContainerCreating.
+func AppPod(namespace, name, claimName, nodeName string, ready bool) *corev1.Pod {
+	pod := Pod(namespace, name, "", "app", ready)
+	pod.Spec.NodeName = nodeName
+	pod.Spec.Volumes = []corev1.Volume{{
+		Name: "data",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+		},
+	}}
+	if !ready {
+		pod.Status.Phase = corev1.PodPending
+		pod.Status.ContainerStatuses[0].State = corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"},
+		}
+	}
+	return pod
+}
+
+// Node builds a node with 4 CPUs and 16Gi of allocatable memory, Ready or NotReady
+func Node(name string, ready bool) *corev1.Node {
+	status := corev1.ConditionTrue
+	if !ready {
+		status = corev1.ConditionFalse
+	}
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("16Gi"),
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubernetes.io/hostname": name}},
+		Status: corev1.NodeStatus{
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+			Capacity:    allocatable,
+			Allocatable: allocatable,
+		},
+	}
+}
+
+// PVC builds a PersistentVolumeClaim in the given phase, bound to volumeName if set
+func PVC(namespace, name, phase, volumeName string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: volumeName},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase: corev1.PersistentVolumeClaimPhase(phase),
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("100Gi"),
+			},
+		},
+	}
+}
+
+// PV builds a bound PersistentVolume
+func PV(name string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("100Gi"),
+			},
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+			StorageClassName:              "fluid",
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+	}
+}
+
+// Event builds an event involving the named object, whose UID follows the fixture convention <kind>-<name>
+func Event(namespace, kind, objectName, eventType, reason, message string) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%s", objectName, strings.ToLower(reason)),
+			Namespace: namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      kind,
+			Name:      objectName,
+			Namespace: namespace,
+			UID:       apitypes.UID(strings.ToLower(kind) + "-" + objectName),
+		},
+		Type:    eventType,
+		Reason:  reason,
+		Message: message,
+		Count:   1,
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FluidAPI describes the data.fluid.io API served by the cluster
+type FluidAPI struct {
+	PreferredVersion string
+	// Resources maps each served version to its resources, e.g. datasets and alluxioruntimes
+	Resources map[string][]string
+}
+
+// Served reports whether a resource is served in any version
+func (a *FluidAPI) Served(resource string) bool {
+	for _, resources := range a.Resources {
+		for _, r := range resources {
+			if r == resource {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DiscoverFluidAPI returns the versions and resources of the data.fluid.io group, or nil
+// if the cluster does not serve it. Versions whose resources could not be listed are
+// reported in the error.
+func (c *Client) DiscoverFluidAPI() (*FluidAPI, error) {
+	groups, err := c.clientset.Discovery().ServerGroups()
+	if err != nil {
+		return nil, newAPIError(err, "list", "API groups", "", "")
+	}
+
+	for _, group := range groups.Groups {
+		if group.Name != FluidGroup {
+			continue
+		}
+		api := &FluidAPI{PreferredVersion: group.PreferredVersion.Version, Resources: map[string][]string{}}
+		var errs []error
+		for _, v := range group.Versions {
+			list, err := c.clientset.Discovery().ServerResourcesForGroupVersion(v.GroupVersion)
+			if err != nil {
+				errs = append(errs, newAPIError(err, "list", "resources of", "", v.GroupVersion))
+				continue
+			}
+			var resources []string
+			for _, r := range list.APIResources {
+				if !strings.Contains(r.Name, "/") {
+					resources = append(resources, r.Name)
+				}
+			}
+			sort.Strings(resources)
+			api.Resources[v.Version] = resources
+		}
+		return api, errors.Join(errs...)
+	}
+	return nil, nil
+}
+
+// ServerVersion returns the Kubernetes version of the cluster, e.g. v1.30.2
+func (c *Client) ServerVersion() (string, error) {
+	info, err := c.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", newAPIError(err, "get", "server version", "", "")
+	}
+	return info.GitVersion, nil
+}
+
+// ListMutatingWebhooks lists the MutatingWebhookConfigurations of the cluster
+func (c *Client) ListMutatingWebhooks(ctx context.Context) ([]admissionregistrationv1.MutatingWebhookConfiguration, error) {
+	list, err := c.clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, newAPIError(err, "list", "mutatingwebhookconfigurations", "", "")
+	}
+	return list.Items, nil
+}
+
+// ReadyEndpoints counts the ready endpoints of a Service across its EndpointSlices
+func (c *Client) ReadyEndpoints(ctx context.Context, namespace, service string) (int, error) {
+	list, err := c.clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + service,
+	})
+	if err != nil {
+		return 0, newAPIError(err, "list", "endpointslices of service", namespace, service)
+	}
+
+	ready := 0
+	for _, slice := range list.Items {
+		for _, endpoint := range slice.Endpoints {
+			// A nil ready condition means ready
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready++
+			}
+		}
+	}
+	return ready, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Interface is the read-only view of the cluster used by inspect and diagnose.
+// *Client implements it; tests can back a Client with fake clientsets.
+type Interface interface {
+	// Fluid custom resources
+	GetDataset(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error)
+	ListDatasets(ctx context.Context, namespace, labelSelector string) ([]unstructured.Unstructured, error)
+	GetRuntime(ctx context.Context, namespace, name, runtimeType string) (*unstructured.Unstructured, error)
+	FindRuntime(ctx context.Context, dataset *unstructured.Unstructured) (*unstructured.Unstructured, string, error)
+
+	// Workloads and volumes
+	GetStatefulSet(ctx context.Context, namespace, name string) (*appsv1.StatefulSet, error)
+	GetDaemonSet(ctx context.Context, namespace, name string) (*appsv1.DaemonSet, error)
+	GetPVC(ctx context.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error)
+	GetPV(ctx context.Context, name string) (*corev1.PersistentVolume, error)
+	ListStatefulSetsByLabel(ctx context.Context, namespace, labelSelector string) (*appsv1.StatefulSetList, error)
+	ListDaemonSetsByLabel(ctx context.Context, namespace, labelSelector string) (*appsv1.DaemonSetList, error)
+	DiscoverResources(ctx context.Context, namespace, datasetName string, runtime *unstructured.Unstructured, cache *DiscoveryCache) (*ResourceGraph, error)
+
+	// Fluid control plane
+	DiscoverControlPlane(ctx context.Context, namespace string) (*ResourceGraph, error)
+	GetCSIDriver(ctx context.Context, name string) (*storagev1.CSIDriver, error)
+
+	// Fluid installation and access
+	DiscoverFluidAPI() (*FluidAPI, error)
+	RuntimeResources() ([]RuntimeResource, error)
+	ServerVersion() (string, error)
+	ListMutatingWebhooks(ctx context.Context) ([]admissionregistrationv1.MutatingWebhookConfiguration, error)
+	ReadyEndpoints(ctx context.Context, namespace, service string) (int, error)
+	CheckAccess(ctx context.Context, perms []Permission) ([]AccessResult, error)
+
+	// Nodes
+	GetNode(ctx context.Context, name string) (*corev1.Node, error)
+	ListNodes(ctx context.Context) ([]corev1.Node, error)
+	ListPodsOnNode(ctx context.Context, nodeName string) ([]corev1.Pod, error)
+
+	// Pods, events and logs
+	GetPod(ctx context.Context, namespace, name string) (*corev1.Pod, error)
+	GetPodsByLabel(ctx context.Context, namespace, labelSelector string) (*corev1.PodList, error)
+	ListPodsByOwner(ctx context.Context, namespace, ownerName, ownerKind string) ([]corev1.Pod, error)
+	ListPodsMountingPVC(ctx context.Context, namespace, claimName string) ([]corev1.Pod, error)
+	GetPodLogs(ctx context.Context, namespace, podName string, opts LogOptions) (string, error)
+	GetEventsForObject(ctx context.Context, object types.ResourceRef) ([]types.EventInfo, error)
+	GetAllRelatedEvents(ctx context.Context, objects []types.ResourceRef) ([]types.EventInfo, error)
+	EventsGroup() string
+}
+
+var _ Interface = &Client{}
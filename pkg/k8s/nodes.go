@@ -0,0 +1,63 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// GetNode fetches a Node
+func (c *Client) GetNode(ctx context.Context, name string) (*corev1.Node, error) {
+	node, err := c.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, newAPIError(err, "get", "node", "", name)
+	}
+	return node, nil
+}
+
+// ListNodes lists the Nodes of the cluster
+func (c *Client) ListNodes(ctx context.Context) ([]corev1.Node, error) {
+	list, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, newAPIError(err, "list", "nodes", "", "")
+	}
+	return list.Items, nil
+}
+
+// ListPodsOnNode lists the pods of every namespace scheduled on a node that have not terminated
+func (c *Client) ListPodsOnNode(ctx context.Context, nodeName string) ([]corev1.Pod, error) {
+	list, err := c.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, newAPIError(err, "list", "pods on node", "", nodeName)
+	}
+
+	var pods []corev1.Pod
+	for _, pod := range list.Items {
+		// The field selector is not applied by every client, e.g. fake ones
+		if pod.Spec.NodeName != nodeName || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
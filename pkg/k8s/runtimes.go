@@ -0,0 +1,221 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/parallel"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// FluidGroup is the API group of the Fluid custom resources
+const FluidGroup = "data.fluid.io"
+
+// RuntimeResource is a Fluid runtime kind served by the cluster
+type RuntimeResource struct {
+	Kind     string // e.g. AlluxioRuntime
+	Resource string // e.g. alluxioruntimes
+	Version  string // the preferred served version, e.g. v1alpha1
+}
+
+// GroupVersionResource returns the resource of the runtime kind in its version
+func (r RuntimeResource) GroupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: FluidGroup, Version: r.Version, Resource: r.Resource}
+}
+
+// matches reports whether a runtime type names this kind. The type may be the kind
+// (AlluxioRuntime), the resource (alluxioruntimes) or the short form used in the
+// Dataset status.runtimes (alluxio), in any case.
+func (r RuntimeResource) matches(runtimeType string) bool {
+	t := strings.ToLower(runtimeType)
+	kind := strings.ToLower(r.Kind)
+	return t == kind || t+"runtime" == kind || t == r.Resource
+}
+
+// knownRuntimes are probed when the discovery API cannot be queried
+var knownRuntimes = []RuntimeResource{
+	{Kind: "AlluxioRuntime", Resource: "alluxioruntimes", Version: "v1alpha1"},
+	{Kind: "EFCRuntime", Resource: "efcruntimes", Version: "v1alpha1"},
+	{Kind: "GooseFSRuntime", Resource: "goosefsruntimes", Version: "v1alpha1"},
+	{Kind: "JindoRuntime", Resource: "jindoruntimes", Version: "v1alpha1"},
+	{Kind: "JuiceFSRuntime", Resource: "juicefsruntimes", Version: "v1alpha1"},
+	{Kind: "ThinRuntime", Resource: "thinruntimes", Version: "v1alpha1"},
+	{Kind: "VineyardRuntime", Resource: "vineyardruntimes", Version: "v1alpha1"},
+}
+
+// RuntimeResources lists the runtime kinds served in the data.fluid.io group, sorted by
+// kind, each in the first served version that has it, starting with the preferred one.
+// They are discovered once per Client. When the discovery API fails, the built-in
+// runtime kinds at v1alpha1 are returned along with the error.
+func (c *Client) RuntimeResources() ([]RuntimeResource, error) {
+	c.runtimesOnce.Do(func() {
+		c.runtimes, c.datasetVersion, c.runtimesErr = c.discoverRuntimeResources()
+	})
+	return c.runtimes, c.runtimesErr
+}
+
+// discoverRuntimeResources discovers the served runtime kinds and the version of the Dataset
+// resource, which is empty if it could not be discovered
+func (c *Client) discoverRuntimeResources() ([]RuntimeResource, string, error) {
+	groups, err := c.clientset.Discovery().ServerGroups()
+	if err != nil {
+		return knownRuntimes, "", newAPIError(err, "list", "API groups", "", "")
+	}
+
+	var group *metav1.APIGroup
+	for i := range groups.Groups {
+		if groups.Groups[i].Name == FluidGroup {
+			group = &groups.Groups[i]
+			break
+		}
+	}
+	if group == nil {
+		// Fluid is not installed
+		return nil, "", nil
+	}
+
+	versions := []metav1.GroupVersionForDiscovery{group.PreferredVersion}
+	for _, v := range group.Versions {
+		if v.Version != group.PreferredVersion.Version {
+			versions = append(versions, v)
+		}
+	}
+
+	var runtimes []RuntimeResource
+	var datasetVersion string
+	var errs []error
+	seen := map[string]bool{}
+	for _, v := range versions {
+		list, err := c.clientset.Discovery().ServerResourcesForGroupVersion(v.GroupVersion)
+		if err != nil {
+			errs = append(errs, newAPIError(err, "list", "resources of", "", v.GroupVersion))
+			continue
+		}
+		for _, r := range list.APIResources {
+			if r.Name == "datasets" && datasetVersion == "" {
+				datasetVersion = v.Version
+			}
+			// Skip subresources such as alluxioruntimes/status
+			if strings.Contains(r.Name, "/") || !strings.HasSuffix(r.Kind, "Runtime") || seen[r.Kind] {
+				continue
+			}
+			seen[r.Kind] = true
+			runtimes = append(runtimes, RuntimeResource{Kind: r.Kind, Resource: r.Name, Version: v.Version})
+		}
+	}
+
+	sort.Slice(runtimes, func(i, j int) bool { return runtimes[i].Kind < runtimes[j].Kind })
+	if len(runtimes) == 0 && len(errs) > 0 {
+		return knownRuntimes, datasetVersion, errors.Join(errs...)
+	}
+	return runtimes, datasetVersion, errors.Join(errs...)
+}
+
+// GetRuntime fetches a Runtime CR by type, given as a kind, resource or short name
+// (AlluxioRuntime, alluxioruntimes or alluxio). It returns nil if the Runtime does
+// not exist or the type is not served by the cluster.
+func (c *Client) GetRuntime(ctx context.Context, namespace, name, runtimeType string) (*unstructured.Unstructured, error) {
+	resources, err := c.RuntimeResources()
+	if resource, ok := findRuntimeResource(resources, runtimeType); ok {
+		return c.getRuntime(ctx, resource, namespace, name)
+	}
+	return nil, err
+}
+
+// FindRuntime returns the Runtime bound to a Dataset and its resource name (e.g. alluxioruntimes).
+// The runtimes listed in the Dataset status.runtimes are authoritative. When none of them is
+// found, every served runtime kind is probed for a Runtime named after the Dataset. Lookup
+// errors other than NotFound are returned, joined, along with the Runtime if one was found,
+// so callers can report them.
+func (c *Client) FindRuntime(ctx context.Context, dataset *unstructured.Unstructured) (*unstructured.Unstructured, string, error) {
+	resources, err := c.RuntimeResources()
+	errs := []error{err}
+
+	// The runtimes the Dataset is bound to
+	bound, _, _ := unstructured.NestedSlice(dataset.Object, "status", "runtimes")
+	for _, b := range bound {
+		ref, ok := b.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := ref["name"].(string)
+		namespace, _ := ref["namespace"].(string)
+		runtimeType, _ := ref["type"].(string)
+		if name == "" {
+			continue
+		}
+		if namespace == "" {
+			namespace = dataset.GetNamespace()
+		}
+
+		resource, ok := findRuntimeResource(resources, runtimeType)
+		if !ok {
+			errs = append(errs, fmt.Errorf("runtime type %q bound to dataset %s/%s is not served by the cluster",
+				runtimeType, dataset.GetNamespace(), dataset.GetName()))
+			continue
+		}
+		runtime, err := c.getRuntime(ctx, resource, namespace, name)
+		if runtime != nil {
+			return runtime, resource.Resource, errors.Join(errs...)
+		}
+		errs = append(errs, err)
+	}
+
+	// A Runtime of any kind named after the Dataset
+	found := make([]*unstructured.Unstructured, len(resources))
+	probeErrs := make([]error, len(resources))
+	parallel.ForEach(ctx, len(resources), parallel.DefaultLimit, func(ctx context.Context, i int) {
+		found[i], probeErrs[i] = c.getRuntime(ctx, resources[i], dataset.GetNamespace(), dataset.GetName())
+	})
+	errs = append(errs, probeErrs...)
+
+	for i, runtime := range found {
+		if runtime != nil {
+			return runtime, resources[i].Resource, errors.Join(errs...)
+		}
+	}
+	return nil, "", errors.Join(errs...)
+}
+
+// getRuntime fetches a Runtime of a served kind. It returns nil if the Runtime does not exist.
+func (c *Client) getRuntime(ctx context.Context, resource RuntimeResource, namespace, name string) (*unstructured.Unstructured, error) {
+	runtime, err := c.dynamicClient.Resource(resource.GroupVersionResource()).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, newAPIError(err, "get", resource.Resource, namespace, name)
+	}
+	return runtime, nil
+}
+
+func findRuntimeResource(resources []RuntimeResource, runtimeType string) (RuntimeResource, bool) {
+	for _, r := range resources {
+		if r.matches(runtimeType) {
+			return r, true
+		}
+	}
+	return RuntimeResource{}, false
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/k8s/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFindRuntime(t *testing.T) {
+	bound := fake.Dataset("default", "demo", "Bound")
+	_ = unstructured.SetNestedSlice(bound.Object, []interface{}{
+		map[string]interface{}{"name": "demo", "namespace": "default", "type": "juicefs"},
+	}, "status", "runtimes")
+
+	tests := []struct {
+		name         string
+		dataset      *unstructured.Unstructured
+		objects      []runtime.Object
+		denied       []fake.Denied
+		wantResource string
+		wantErr      bool
+		wantDenied   bool
+	}{
+		{
+			name:    "bound runtime takes precedence",
+			dataset: bound,
+			objects: []runtime.Object{
+				fake.Runtime("AlluxioRuntime", "default", "demo", nil),
+				fake.Runtime("JuiceFSRuntime", "default", "demo", nil),
+			},
+			wantResource: "juicefsruntimes",
+		},
+		{
+			name:         "runtime kind found by discovery",
+			dataset:      fake.Dataset("default", "demo", "Bound"),
+			objects:      []runtime.Object{fake.Runtime("CacheRuntime", "default", "demo", nil)},
+			wantResource: "cacheruntimes",
+		},
+		{
+			name:    "no runtime",
+			dataset: fake.Dataset("default", "demo", "NotBound"),
+		},
+		{
+			name:         "denied probe is reported",
+			dataset:      fake.Dataset("default", "demo", "Bound"),
+			objects:      []runtime.Object{fake.Runtime("AlluxioRuntime", "default", "demo", nil)},
+			denied:       []fake.Denied{{Verb: "get", Resource: "goosefsruntimes"}},
+			wantResource: "alluxioruntimes",
+			wantErr:      true,
+			wantDenied:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientDenying(tt.denied, tt.objects...)
+			runtime, resource, err := client.FindRuntime(context.Background(), tt.dataset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindRuntime() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if k8s.IsForbidden(err) != tt.wantDenied {
+				t.Errorf("IsForbidden(%v) = %t, want %t", err, !tt.wantDenied, tt.wantDenied)
+			}
+			if resource != tt.wantResource {
+				t.Errorf("resource = %q, want %q", resource, tt.wantResource)
+			}
+			if (runtime != nil) != (tt.wantResource != "") {
+				t.Errorf("runtime = %v, want one only when a resource is expected", runtime)
+			}
+		})
+	}
+}
+
+func TestRuntimeResources(t *testing.T) {
+	resources, err := fake.NewClient().RuntimeResources()
+	if err != nil {
+		t.Fatalf("RuntimeResources() error = %v", err)
+	}
+
+	kinds := map[string]string{}
+	for _, r := range resources {
+		kinds[r.Kind] = r.Resource
+	}
+	if kinds["CacheRuntime"] != "cacheruntimes" || kinds["AlluxioRuntime"] != "alluxioruntimes" {
+		t.Errorf("runtime kinds = %v, want CacheRuntime and AlluxioRuntime", kinds)
+	}
+	if _, ok := kinds["Dataset"]; ok {
+		t.Error("Dataset is not a runtime kind")
+	}
+}
+
+func TestDatasetResource(t *testing.T) {
+	// A cluster serving the Dataset only in a newer version
+	v1beta1 := schema.GroupVersionResource{Group: k8s.FluidGroup, Version: "v1beta1", Resource: "datasets"}
+	clientset := kubefake.NewSimpleClientset()
+	clientset.Resources = []*metav1.APIResourceList{{
+		GroupVersion: v1beta1.GroupVersion().String(),
+		APIResources: []metav1.APIResource{{Name: "datasets", Namespaced: true, Kind: "Dataset"}},
+	}}
+	dataset := fake.Dataset("default", "demo", "Bound")
+	dataset.SetAPIVersion(v1beta1.GroupVersion().String())
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{v1beta1: "DatasetList"}, dataset)
+	client := k8s.NewClientFromInterfaces(clientset, dynamicClient)
+
+	if got := client.DatasetResource(); got != v1beta1 {
+		t.Errorf("DatasetResource() = %v, want %v", got, v1beta1)
+	}
+	if _, err := client.GetDataset(context.Background(), "default", "demo"); err != nil {
+		t.Errorf("GetDataset() error = %v", err)
+	}
+	if datasets, err := client.ListDatasets(context.Background(), "", ""); err != nil || len(datasets) != 1 {
+		t.Errorf("ListDatasets() = %d datasets, error %v, want 1", len(datasets), err)
+	}
+
+	// Without discovery, the Dataset is looked up at v1alpha1
+	undiscovered := k8s.NewClientFromInterfaces(kubefake.NewSimpleClientset(), dynamicClient)
+	if got := undiscovered.DatasetResource().Version; got != "v1alpha1" {
+		t.Errorf("fallback version = %q, want v1alpha1", got)
+	}
+}
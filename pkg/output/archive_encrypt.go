@@ -0,0 +1,153 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// EncryptedExtension is appended to the name of an encrypted archive
+const EncryptedExtension = ".age"
+
+// ageMagic starts every binary age file
+const ageMagic = "age-encryption.org/"
+
+// ErrArchiveEncrypted is returned when reading an encrypted archive without an identity
+var ErrArchiveEncrypted = errors.New("archive is encrypted")
+
+// LoadRecipients reads the age X25519 public keys (age1...) of the files, one per line.
+// Empty lines and lines starting with # are ignored.
+func LoadRecipients(paths []string) ([]age.Recipient, error) {
+	var recipients []age.Recipient
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open recipients file: %w", err)
+		}
+		parsed, err := age.ParseRecipients(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse recipients file %s: %w", path, err)
+		}
+		recipients = append(recipients, parsed...)
+	}
+	return recipients, nil
+}
+
+// LoadIdentities reads the age X25519 secret keys (AGE-SECRET-KEY-1...) of the files, as
+// written by age-keygen
+func LoadIdentities(paths []string) ([]age.Identity, error) {
+	var identities []age.Identity
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open identity file: %w", err)
+		}
+		parsed, err := age.ParseIdentities(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse identity file %s: %w", path, err)
+		}
+		identities = append(identities, parsed...)
+	}
+	return identities, nil
+}
+
+// encryptingWriter wraps w so that everything written is encrypted to the recipients.
+// Closing it flushes the last chunk; it does not close w.
+func encryptingWriter(w io.Writer, recipients []age.Recipient) (io.WriteCloser, error) {
+	ew, err := age.Encrypt(w, recipients...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt archive: %w", err)
+	}
+	return ew, nil
+}
+
+// decryptingReader returns the plaintext of r when it holds an age file, binary or
+// armored, and r itself otherwise. The plaintext is only ever held in memory.
+func decryptingReader(r *bufio.Reader, identities []age.Identity) (*bufio.Reader, error) {
+	var src io.Reader
+	if peek, _ := r.Peek(len(armor.Header)); string(peek) == armor.Header {
+		src = armor.NewReader(r)
+	} else if peek, _ := r.Peek(len(ageMagic)); string(peek) == ageMagic {
+		src = r
+	} else {
+		return r, nil
+	}
+
+	if len(identities) == 0 {
+		return nil, ErrArchiveEncrypted
+	}
+	plaintext, err := age.Decrypt(src, identities...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt archive: %w", err)
+	}
+	return bufio.NewReader(plaintext), nil
+}
+
+// DecryptArchive writes the plaintext of an encrypted archive to w, such as stdout
+func DecryptArchive(w io.Writer, path string, identities []age.Identity) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	plaintext, err := decryptingReader(r, identities)
+	if err != nil {
+		return err
+	}
+	if plaintext == r {
+		return fmt.Errorf("archive %s is not encrypted", path)
+	}
+	if _, err := io.Copy(w, plaintext); err != nil {
+		return fmt.Errorf("failed to decrypt archive: %w", err)
+	}
+	return nil
+}
+
+// readArchiveStream reads every file of a tar.gz or zip stream, decrypting it first
+// when it is encrypted
+func readArchiveStream(r io.Reader, identities []age.Identity) (map[string][]byte, error) {
+	br, err := decryptingReader(bufio.NewReader(r), identities)
+	if err != nil {
+		return nil, err
+	}
+	magic, _ := br.Peek(len(zipMagic))
+	if string(magic) != zipMagic {
+		return readTarGz(br)
+	}
+
+	// The zip index is at the end of the file, so the whole file is read first
+	content, err := io.ReadAll(io.LimitReader(br, maxArchiveSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxArchiveSize {
+		return nil, fmt.Errorf("archive is too large (over %d bytes)", maxArchiveSize)
+	}
+	return readZip(bytes.NewReader(content), int64(len(content)))
+}
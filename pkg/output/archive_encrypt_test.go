@@ -0,0 +1,145 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose/mock"
+)
+
+// writeKeys writes an identity file and the matching recipients file, as age-keygen does
+func writeKeys(t *testing.T) (identityFile, recipientFile string) {
+	t.Helper()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	identityFile = filepath.Join(dir, "key.txt")
+	recipientFile = filepath.Join(dir, "key.pub")
+	identityContent := "# public key: " + identity.Recipient().String() + "\n" + identity.String() + "\n"
+	if err := os.WriteFile(identityFile, []byte(identityContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(recipientFile, []byte("# vendor\n\n"+identity.Recipient().String()+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return identityFile, recipientFile
+}
+
+func TestEncryptedArchive(t *testing.T) {
+	identityFile, recipientFile := writeKeys(t)
+	recipients, err := LoadRecipients([]string{recipientFile})
+	if err != nil {
+		t.Fatalf("LoadRecipients: %v", err)
+	}
+	identities, err := LoadIdentities([]string{identityFile})
+	if err != nil {
+		t.Fatalf("LoadIdentities: %v", err)
+	}
+	_, otherRecipientFile := writeKeys(t)
+	otherRecipients, _ := LoadRecipients([]string{otherRecipientFile})
+
+	for _, format := range []string{ArchiveFormatTarGz, ArchiveFormatZip} {
+		t.Run(format, func(t *testing.T) {
+			archiver := NewArchiver().WithOutputDir(t.TempDir()).WithFormat(format).WithEncryption(recipients...)
+			path, err := archiver.CreateArchive(mock.MockDiagnosticResult("demo-data", "default"))
+			if err != nil {
+				t.Fatalf("CreateArchive: %v", err)
+			}
+			if !strings.HasSuffix(path, archiveExtensions[format]+EncryptedExtension) {
+				t.Errorf("path = %s, want the %s extension", path, EncryptedExtension)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.HasPrefix(content, []byte(ageMagic)) || bytes.Contains(content, []byte("demo-data")) {
+				t.Error("archive is not an age file")
+			}
+
+			if _, err := ReadArchive(path); !errors.Is(err, ErrArchiveEncrypted) {
+				t.Errorf("ReadArchive without identity error = %v, want ErrArchiveEncrypted", err)
+			}
+			wrong, _ := age.GenerateX25519Identity()
+			if _, err := ReadArchive(path, wrong); err == nil {
+				t.Error("ReadArchive with the wrong identity should fail")
+			}
+
+			result, err := ReadArchive(path, identities...)
+			if err != nil {
+				t.Fatalf("ReadArchive: %v", err)
+			}
+			if result.DatasetName != "demo-data" {
+				t.Errorf("dataset = %q", result.DatasetName)
+			}
+			verification, err := VerifyArchive(path, identities...)
+			if err != nil {
+				t.Fatalf("VerifyArchive: %v", err)
+			}
+			if problems := verification.Problems(); len(problems) != 0 {
+				t.Errorf("problems = %+v", problems)
+			}
+
+			// The decrypted stream is the plain archive
+			var plaintext bytes.Buffer
+			if err := DecryptArchive(&plaintext, path, identities); err != nil {
+				t.Fatalf("DecryptArchive: %v", err)
+			}
+			files, err := readArchiveStream(&plaintext, nil)
+			if err != nil || len(files[ManifestFile]) == 0 {
+				t.Errorf("decrypted archive: %d files, %v", len(files), err)
+			}
+
+			// Several recipients can each decrypt the archive
+			multi := NewArchiver().WithOutputDir(t.TempDir()).WithFormat(format).
+				WithEncryption(append(otherRecipients, recipients...)...)
+			path, err = multi.CreateArchive(mock.MockDiagnosticResult("demo-data", "default"))
+			if err != nil {
+				t.Fatalf("CreateArchive with two recipients: %v", err)
+			}
+			if _, err := ReadArchive(path, identities...); err != nil {
+				t.Errorf("ReadArchive with the second recipient: %v", err)
+			}
+		})
+	}
+
+	dir := NewArchiver().WithOutputDir(t.TempDir()).WithFormat(ArchiveFormatDir).WithEncryption(recipients...)
+	if _, err := dir.CreateArchive(mock.MockDiagnosticResult("demo-data", "default")); err == nil {
+		t.Error("encrypting a dir archive should fail")
+	}
+}
+
+func TestLoadKeysErrors(t *testing.T) {
+	identityFile, recipientFile := writeKeys(t)
+	if _, err := LoadRecipients([]string{identityFile}); err == nil {
+		t.Error("LoadRecipients of an identity file should fail")
+	}
+	if _, err := LoadIdentities([]string{recipientFile}); err == nil {
+		t.Error("LoadIdentities of a recipients file should fail")
+	}
+	if _, err := LoadIdentities([]string{filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("LoadIdentities of a missing file should fail")
+	}
+}
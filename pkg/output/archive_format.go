@@ -0,0 +1,348 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+)
+
+// Archive formats
+const (
+	ArchiveFormatTarGz = "tar.gz"
+	ArchiveFormatZip   = "zip"
+	ArchiveFormatDir   = "dir" // A plain directory
+)
+
+// ArchiveFormats lists the formats Archiver writes
+var ArchiveFormats = []string{ArchiveFormatTarGz, ArchiveFormatZip, ArchiveFormatDir}
+
+// archiveExtensions are the file name extensions of the formats
+var archiveExtensions = map[string]string{
+	ArchiveFormatTarGz: ".tar.gz",
+	ArchiveFormatZip:   ".zip",
+	ArchiveFormatDir:   "",
+}
+
+// zipMagic starts every zip file
+const zipMagic = "PK\x03\x04"
+
+// maxArchiveSize bounds the size of a zip archive read into memory
+const maxArchiveSize = 1 << 30
+
+// ValidateArchiveFormat checks that format is one of ArchiveFormats
+func ValidateArchiveFormat(format string) error {
+	if _, ok := archiveExtensions[format]; !ok {
+		return fmt.Errorf("unknown archive format %q (supported: %s)", format, strings.Join(ArchiveFormats, ", "))
+	}
+	return nil
+}
+
+// archiveSink stores the files of an archive in a given format
+type archiveSink interface {
+	write(name string, content []byte, modTime time.Time) error
+	// Close flushes the archive. Its error must be checked: an unflushed archive is truncated.
+	Close() error
+}
+
+// tarGzSink writes a gzip-compressed tar stream
+type tarGzSink struct {
+	gw *gzip.Writer
+	tw *tar.Writer
+}
+
+func newTarGzSink(w io.Writer) *tarGzSink {
+	gw := gzip.NewWriter(w)
+	return &tarGzSink{gw: gw, tw: tar.NewWriter(gw)}
+}
+
+func (s *tarGzSink) write(name string, content []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	}
+	if err := s.tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write tar header for %s: %w", name, err)
+	}
+	if _, err := s.tw.Write(content); err != nil {
+		return fmt.Errorf("failed to write tar content for %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *tarGzSink) Close() error {
+	// The tar footer goes through gzip, so the tar writer is closed first
+	return errors.Join(s.tw.Close(), s.gw.Close())
+}
+
+// zipSink writes a zip stream, which does not need a seekable writer
+type zipSink struct {
+	zw *zip.Writer
+}
+
+func (s *zipSink) write(name string, content []byte, modTime time.Time) error {
+	header := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modTime}
+	header.SetMode(0644)
+	w, err := s.zw.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("failed to write zip header for %s: %w", name, err)
+	}
+	if _, err := w.Write(content); err != nil {
+		return fmt.Errorf("failed to write zip content for %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *zipSink) Close() error {
+	return s.zw.Close()
+}
+
+// dirSink writes each file under a directory
+type dirSink struct {
+	dir string
+}
+
+func (s *dirSink) write(name string, content []byte, modTime time.Time) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", name, err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return os.Chtimes(path, modTime, modTime)
+}
+
+func (s *dirSink) Close() error {
+	return nil
+}
+
+// checkStreamable checks that an archive of the format is a single stream of bytes, which
+// can be written to stdout or encrypted
+func checkStreamable(format string) error {
+	if format == ArchiveFormatDir {
+		return fmt.Errorf("a %s archive is not a single file, use %s or %s", format, ArchiveFormatTarGz, ArchiveFormatZip)
+	}
+	return ValidateArchiveFormat(format)
+}
+
+// newStreamSink returns the sink writing an archive of a streamable format to w
+func newStreamSink(format string, w io.Writer) (archiveSink, error) {
+	if err := checkStreamable(format); err != nil {
+		return nil, err
+	}
+	if format == ArchiveFormatZip {
+		return &zipSink{zw: zip.NewWriter(w)}, nil
+	}
+	return newTarGzSink(w), nil
+}
+
+// writeFileAtomically writes a file through a temporary file in the same directory,
+// linked into place once complete, so that a failed write never leaves a partial file
+// behind. The link fails if path exists, so an existing file is never replaced, even
+// one created while the archive was being written.
+func writeFileAtomically(path string, write func(w io.Writer) error) error {
+	// Fail early; the link below is what guarantees an existing file is kept
+	if _, err := os.Lstat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create archive file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // Once linked, the file remains at path
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write archive file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write archive file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write archive file: %w", err)
+	}
+	if err := os.Link(tmp.Name(), path); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("%s already exists", path)
+		}
+		return fmt.Errorf("failed to write archive file: %w", err)
+	}
+	return nil
+}
+
+// writeDirAtomically fills a temporary directory next to path and renames it into place.
+// os.Rename does not replace a directory, nor a file by a directory, so an existing archive
+// is never replaced, even one created while the archive was being written.
+func writeDirAtomically(path string, write func(dir string) error) error {
+	if _, err := os.Lstat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	defer os.RemoveAll(tmp) // A no-op once renamed
+
+	if err := write(tmp); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, 0755); err != nil {
+		return fmt.Errorf("failed to write archive directory: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		if _, statErr := os.Lstat(path); statErr == nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+		return fmt.Errorf("failed to write archive directory: %w", err)
+	}
+	return nil
+}
+
+// readArchiveFiles reads every file of an archive in any of the ArchiveFormats into memory,
+// keyed by their normalized entry names. Encrypted archives are decrypted with the identities.
+func readArchiveFiles(path string, identities []age.Identity) (map[string][]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	if info.IsDir() {
+		files, err := readDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive %s: %w", path, err)
+		}
+		return normalizeEntryNames(files), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	files, err := readArchiveStream(file, identities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", path, err)
+	}
+	return normalizeEntryNames(files), nil
+}
+
+// normalizeEntryNames keys the files of an archive by their path from the archive root.
+// Names are cleaned, so that the ./dataset.yaml of an archive re-packed with "tar czf x.tgz ."
+// is dataset.yaml, and a top-level directory holding every file, as in an archive of the
+// extracted directory, is stripped.
+func normalizeEntryNames(files map[string][]byte) map[string][]byte {
+	cleaned := make(map[string][]byte, len(files))
+	topDir := ""
+	shared := true
+	for name, content := range files {
+		name = strings.TrimPrefix(path.Clean("/"+name), "/")
+		if name == "" {
+			continue
+		}
+		cleaned[name] = content
+
+		dir, _, nested := strings.Cut(name, "/")
+		if !nested || (topDir != "" && dir != topDir) {
+			shared = false
+		}
+		topDir = dir
+	}
+	if !shared || len(cleaned) == 0 {
+		return cleaned
+	}
+
+	stripped := make(map[string][]byte, len(cleaned))
+	for name, content := range cleaned {
+		stripped[strings.TrimPrefix(name, topDir+"/")] = content
+	}
+	return stripped
+}
+
+// readZip reads every regular file of a zip archive into memory
+func readZip(r io.ReaderAt, size int64) (map[string][]byte, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		if f.UncompressedSize64 > maxArchiveEntrySize {
+			return nil, fmt.Errorf("entry %s is too large (%d bytes)", f.Name, f.UncompressedSize64)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		content, err := io.ReadAll(io.LimitReader(rc, maxArchiveEntrySize))
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		files[f.Name] = content
+	}
+	return files, nil
+}
+
+// readDir reads every regular file under an archive directory, keyed by slash-separated path
+func readDir(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if info.Size() > maxArchiveEntrySize {
+			return fmt.Errorf("entry %s is too large (%d bytes)", rel, info.Size())
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	return files, err
+}
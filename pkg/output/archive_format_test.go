@@ -0,0 +1,181 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose/mock"
+)
+
+func TestArchiveFormats(t *testing.T) {
+	for _, format := range ArchiveFormats {
+		t.Run(format, func(t *testing.T) {
+			dir := t.TempDir()
+			archiver := NewArchiver().WithOutputDir(dir).WithFormat(format).WithName("support-case")
+			path, err := archiver.CreateArchive(mock.MockDiagnosticResult("demo-data", "default"))
+			if err != nil {
+				t.Fatalf("CreateArchive: %v", err)
+			}
+			if want := filepath.Join(dir, "support-case"+archiveExtensions[format]); path != want {
+				t.Errorf("path = %s, want %s", path, want)
+			}
+
+			// Only the archive is left, without temporary files
+			names, _ := os.ReadDir(dir)
+			if len(names) != 1 {
+				t.Errorf("output directory holds %d entries, want 1", len(names))
+			}
+
+			result, err := ReadArchive(path)
+			if err != nil {
+				t.Fatalf("ReadArchive: %v", err)
+			}
+			if result.DatasetName != "demo-data" || len(result.Manifests) == 0 {
+				t.Errorf("read back dataset %q with %d manifests", result.DatasetName, len(result.Manifests))
+			}
+			verification, err := VerifyArchive(path)
+			if err != nil {
+				t.Fatalf("VerifyArchive: %v", err)
+			}
+			if problems := verification.Problems(); len(problems) != 0 {
+				t.Errorf("problems = %+v", problems)
+			}
+
+			// An existing archive is never replaced
+			if _, err := archiver.CreateArchive(mock.MockDiagnosticResult("demo-data", "default")); err == nil ||
+				!strings.Contains(err.Error(), "already exists") {
+				t.Errorf("second CreateArchive error = %v, want already exists", err)
+			}
+		})
+	}
+}
+
+func TestWriteAtomicallyKeepsConcurrentArchive(t *testing.T) {
+	// Another writer creates the archive while this one is being written
+	tests := []struct {
+		name  string
+		write func(path string) error
+	}{
+		{
+			name: "file",
+			write: func(path string) error {
+				return writeFileAtomically(path, func(w io.Writer) error {
+					if err := os.WriteFile(path, []byte("other"), 0644); err != nil {
+						return err
+					}
+					_, err := w.Write([]byte("mine"))
+					return err
+				})
+			},
+		},
+		{
+			name: "dir",
+			write: func(path string) error {
+				return writeDirAtomically(path, func(dir string) error {
+					if err := os.Mkdir(path, 0755); err != nil {
+						return err
+					}
+					if err := os.WriteFile(filepath.Join(path, "other"), nil, 0644); err != nil {
+						return err
+					}
+					return os.WriteFile(filepath.Join(dir, "mine"), nil, 0644)
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "archive")
+			if err := tt.write(path); err == nil || !strings.Contains(err.Error(), "already exists") {
+				t.Fatalf("error = %v, want already exists", err)
+			}
+
+			if info, _ := os.Stat(path); info != nil && info.IsDir() {
+				if _, err := os.Stat(filepath.Join(path, "other")); err != nil {
+					t.Errorf("existing directory was replaced: %v", err)
+				}
+			} else if data, _ := os.ReadFile(path); string(data) != "other" {
+				t.Errorf("existing file holds %q, want other", data)
+			}
+			if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+				t.Errorf("output directory holds %d entries, want 1", len(entries))
+			}
+		})
+	}
+}
+
+func TestArchiveName(t *testing.T) {
+	result := mock.MockDiagnosticResult("demo-data", "default")
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{name: "case-42", format: ArchiveFormatTarGz, want: "case-42.tar.gz"},
+		{name: "case-42.tar.gz", format: ArchiveFormatTarGz, want: "case-42.tar.gz"},
+		{name: "case-42.zip", format: ArchiveFormatZip, want: "case-42.zip"},
+		{name: "case-42", format: ArchiveFormatDir, want: "case-42"},
+		{name: "", format: ArchiveFormatZip, want: "fluid-diagnose-demo-data-"},
+		{name: "../case-42", format: ArchiveFormatTarGz, wantErr: true},
+		{name: "..", format: ArchiveFormatDir, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NewArchiver().WithName(tt.name).archiveName(result, tt.format)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("archiveName(%q) = %q, want an error", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("archiveName(%q): %v", tt.name, err)
+			continue
+		}
+		if !strings.HasPrefix(got, tt.want) || !strings.HasSuffix(got, archiveExtensions[tt.format]) {
+			t.Errorf("archiveName(%q, %s) = %q, want %q", tt.name, tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestWriteArchive(t *testing.T) {
+	for _, format := range []string{ArchiveFormatTarGz, ArchiveFormatZip} {
+		var buf bytes.Buffer
+		if err := NewArchiver().WithFormat(format).WriteArchive(&buf, mock.MockDiagnosticResult("demo-data", "default")); err != nil {
+			t.Fatalf("%s: WriteArchive: %v", format, err)
+		}
+		path := filepath.Join(t.TempDir(), "stdout")
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if result, err := ReadArchive(path); err != nil || result.DatasetName != "demo-data" {
+			t.Errorf("%s: ReadArchive of the stream: %v", format, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := NewArchiver().WithFormat(ArchiveFormatDir).WriteArchive(&buf, mock.MockDiagnosticResult("demo-data", "default")); err == nil {
+		t.Error("streaming a dir archive should fail")
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+const (
+	// ArchiveSchemaVersion is the version of the archive layout written by Archiver.
+	// Version 2 added the collection metadata and the entry checksums.
+	ArchiveSchemaVersion = "2"
+
+	// ManifestFile is the name of the archive index
+	ManifestFile = "manifest.json"
+)
+
+// supportedSchemaVersions are the archive layouts ReadArchive understands
+var supportedSchemaVersions = []string{"1", ArchiveSchemaVersion}
+
+// ArchiveManifest indexes the contents of a diagnostic archive
+type ArchiveManifest struct {
+	SchemaVersion string             `json:"schemaVersion"`
+	DatasetName   string             `json:"datasetName"`
+	Namespace     string             `json:"namespace"`
+	RuntimeType   string             `json:"runtimeType,omitempty"`
+	CollectedAt   time.Time          `json:"collectedAt"`
+	HealthStatus  types.HealthStatus `json:"healthStatus"`
+	// Collection is the tool, cluster and settings of the diagnosis
+	Collection *types.CollectionInfo `json:"collection,omitempty"`
+	// Steps is the status and duration of each collection step
+	Steps     []types.CollectionStep `json:"steps,omitempty"`
+	Logs      []ArchiveLogFile       `json:"logs,omitempty"`
+	Manifests []ArchiveObject        `json:"manifests,omitempty"`
+	// Entries lists every other file of the archive with its checksum
+	Entries []ArchiveEntry `json:"entries,omitempty"`
+}
+
+// ArchiveEntry is the size and SHA-256 of an archive file
+type ArchiveEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// checkSchemaVersion returns an error for an archive layout this version cannot read
+func checkSchemaVersion(version string) error {
+	for _, v := range supportedSchemaVersions {
+		if v == version {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported archive schema version %q (supported: %s), upgrade kubectl-fluid to read it",
+		version, strings.Join(supportedSchemaVersions, ", "))
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// ArchiveObject describes an object manifest under manifests/
+type ArchiveObject struct {
+	Path      string `json:"path"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// ArchiveLogFile describes a log file under pods/
+type ArchiveLogFile struct {
+	Path          string `json:"path"`
+	Component     string `json:"component"` // master, worker, fuse, controlplane
+	PodName       string `json:"podName"`
+	ContainerName string `json:"containerName"`
+	ContainerType string `json:"containerType,omitempty"`
+	Previous      bool   `json:"previous,omitempty"`
+	TailLines     int64  `json:"tailLines"`
+	Truncated     bool   `json:"truncated"`
+	Error         string `json:"error,omitempty"`
+	Filter        string `json:"filter,omitempty"`
+
+	entry *types.LogEntry
+}
+
+// newArchiveManifest builds the manifest for a diagnostic result
+func newArchiveManifest(result *types.DiagnosticResult) *ArchiveManifest {
+	manifest := &ArchiveManifest{
+		SchemaVersion: ArchiveSchemaVersion,
+		DatasetName:   result.DatasetName,
+		Namespace:     result.Namespace,
+		RuntimeType:   result.RuntimeType,
+		CollectedAt:   result.CollectedAt,
+		HealthStatus:  result.HealthStatus,
+		Collection:    result.Collection,
+		Steps:         result.CollectionSteps,
+	}
+
+	if result.Logs.Master != nil {
+		manifest.addLog("pods/master.log", "master", result.Logs.Master)
+	}
+	for i := range result.Logs.MasterExtra {
+		manifest.addLog(fmt.Sprintf("pods/master-%d.log", i+1), "master", &result.Logs.MasterExtra[i])
+	}
+	for i := range result.Logs.Workers {
+		manifest.addLog(fmt.Sprintf("pods/worker-%d.log", i), "worker", &result.Logs.Workers[i])
+	}
+	for i := range result.Logs.Fuse {
+		manifest.addLog(fmt.Sprintf("pods/fuse-%d.log", i), "fuse", &result.Logs.Fuse[i])
+	}
+	for i := range result.Logs.ControlPlane {
+		manifest.addLog(fmt.Sprintf("pods/controlplane-%d.log", i), "controlplane", &result.Logs.ControlPlane[i])
+	}
+
+	for i := range result.Manifests {
+		m := &result.Manifests[i]
+		manifest.Manifests = append(manifest.Manifests, ArchiveObject{
+			Path:      m.Path(),
+			Kind:      m.Kind,
+			Namespace: m.Namespace,
+			Name:      m.Name,
+		})
+	}
+
+	return manifest
+}
+
+func (m *ArchiveManifest) addLog(path, component string, entry *types.LogEntry) {
+	// Entries with neither logs nor an error carry no information
+	if entry.Logs == "" && entry.Error == "" {
+		return
+	}
+	m.Logs = append(m.Logs, ArchiveLogFile{
+		Path:          path,
+		Component:     component,
+		PodName:       entry.PodName,
+		ContainerName: entry.ContainerName,
+		ContainerType: entry.ContainerType,
+		Previous:      entry.Previous,
+		TailLines:     entry.TailLines,
+		Truncated:     entry.Truncated,
+		Error:         entry.Error,
+		Filter:        entry.Filter,
+		entry:         entry,
+	})
+}
+
+// logEntry rebuilds the LogEntry described by the manifest around the given log content
+func (f *ArchiveLogFile) logEntry(logs string) types.LogEntry {
+	return types.LogEntry{
+		PodName:       f.PodName,
+		ContainerName: f.ContainerName,
+		ContainerType: f.ContainerType,
+		Previous:      f.Previous,
+		Logs:          logs,
+		TailLines:     f.TailLines,
+		Truncated:     f.Truncated,
+		Error:         f.Error,
+		Filter:        f.Filter,
+	}
+}
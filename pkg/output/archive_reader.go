@@ -0,0 +1,302 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// maxArchiveEntrySize bounds the size of a single archive entry read into memory
+const maxArchiveEntrySize = 64 << 20
+
+// ReadArchive loads a diagnostic archive written by CreateArchive, in any of the
+// ArchiveFormats, back into a DiagnosticResult. Encrypted archives are decrypted in memory
+// with the identities. Archives without a manifest (written before the manifest was introduced) are read using
+// the legacy layout. Failure hints are loaded as recorded; callers re-run analysis as needed.
+func ReadArchive(path string, identities ...age.Identity) (*types.DiagnosticResult, error) {
+	files, err := readArchiveFiles(path, identities)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseArchiveFiles(files)
+}
+
+// readTarGz reads every regular file of a tar.gz stream into memory
+func readTarGz(r io.Reader) (map[string][]byte, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxArchiveEntrySize {
+			return nil, fmt.Errorf("entry %s is too large (%d bytes)", header.Name, header.Size)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		files[header.Name] = content
+	}
+
+	return files, nil
+}
+
+// parseArchiveFiles rebuilds a DiagnosticResult from the archive entries
+func parseArchiveFiles(files map[string][]byte) (*types.DiagnosticResult, error) {
+	datasetYAML, ok := files["dataset.yaml"]
+	if !ok {
+		return nil, fmt.Errorf("archive has no dataset.yaml, is it a diagnostic archive?")
+	}
+
+	result := &types.DiagnosticResult{
+		DatasetYAML: string(datasetYAML),
+		RuntimeYAML: string(files["runtime.yaml"]),
+	}
+
+	var manifest *ArchiveManifest
+	if content, ok := files[ManifestFile]; ok {
+		manifest = &ArchiveManifest{}
+		if err := json.Unmarshal(content, manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+		}
+		if err := checkSchemaVersion(manifest.SchemaVersion); err != nil {
+			return nil, err
+		}
+		result.DatasetName = manifest.DatasetName
+		result.Namespace = manifest.Namespace
+		result.RuntimeType = manifest.RuntimeType
+		result.CollectedAt = manifest.CollectedAt
+		result.HealthStatus = manifest.HealthStatus
+		result.Collection = manifest.Collection
+		result.CollectionSteps = manifest.Steps
+	} else {
+		parseLegacyMetadata(files, result)
+	}
+
+	if content, ok := files["resources.json"]; ok {
+		if err := json.Unmarshal(content, &result.Resources); err != nil {
+			return nil, fmt.Errorf("failed to parse resources.json: %w", err)
+		}
+	}
+
+	if content, ok := files["failure_hints.json"]; ok {
+		if err := json.Unmarshal(content, &result.FailureHints); err != nil {
+			return nil, fmt.Errorf("failed to parse failure_hints.json: %w", err)
+		}
+	}
+
+	if content, ok := files[RedactionFile]; ok {
+		result.Redaction = &types.RedactionReport{}
+		if err := json.Unmarshal(content, result.Redaction); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", RedactionFile, err)
+		}
+	}
+
+	if content, ok := files["events.json"]; ok {
+		if err := json.Unmarshal(content, &result.Events); err != nil {
+			return nil, fmt.Errorf("failed to parse events.json: %w", err)
+		}
+	} else if content, ok := files["events.log"]; ok {
+		result.Events = parseEventsLog(string(content))
+	}
+
+	if manifest != nil {
+		for i := range manifest.Logs {
+			file := &manifest.Logs[i]
+			_, logs := parseLogFile(string(files[file.Path]))
+			entry := file.logEntry(logs)
+			addLogEntry(&result.Logs, file.Component, entry)
+		}
+		for _, object := range manifest.Manifests {
+			result.Manifests = append(result.Manifests, types.Manifest{
+				Kind:      object.Kind,
+				Namespace: object.Namespace,
+				Name:      object.Name,
+				YAML:      string(files[object.Path]),
+			})
+		}
+	} else {
+		parseLegacyLogs(files, &result.Logs)
+	}
+
+	return result, nil
+}
+
+// parseLegacyMetadata derives result metadata from the CR snapshots and summary
+func parseLegacyMetadata(files map[string][]byte, result *types.DiagnosticResult) {
+	var dataset struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+	}
+	if err := yaml.Unmarshal(files["dataset.yaml"], &dataset); err == nil {
+		result.DatasetName = dataset.Metadata.Name
+		result.Namespace = dataset.Metadata.Namespace
+	}
+
+	var runtime struct {
+		Kind string `json:"kind"`
+	}
+	if err := yaml.Unmarshal(files["runtime.yaml"], &runtime); err == nil && runtime.Kind != "" {
+		result.RuntimeType = strings.ToLower(runtime.Kind) + "s"
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(files["summary.txt"])))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, "Collected At:"); ok {
+			if t, err := time.ParseInLocation("2006-01-02 15:04:05", strings.TrimSpace(value), time.Local); err == nil {
+				result.CollectedAt = t
+			}
+		}
+		if value, ok := strings.CutPrefix(line, "Health:"); ok {
+			result.HealthStatus = types.HealthStatus(strings.TrimSpace(value))
+		}
+	}
+}
+
+var legacyLogFile = regexp.MustCompile(`^pods/(master|worker|fuse)(?:-(\d+))?\.log$`)
+
+// parseLegacyLogs reads pods/*.log files using the header written by formatLogEntry
+func parseLegacyLogs(files map[string][]byte, logs *types.DiagnosticLogs) {
+	var names []string
+	for name := range files {
+		if legacyLogFile.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+
+	// Keep worker-N and fuse-N entries in index order
+	sort.Slice(names, func(i, j int) bool {
+		mi := legacyLogFile.FindStringSubmatch(names[i])
+		mj := legacyLogFile.FindStringSubmatch(names[j])
+		if mi[1] != mj[1] {
+			return mi[1] < mj[1]
+		}
+		ni, _ := strconv.Atoi(mi[2])
+		nj, _ := strconv.Atoi(mj[2])
+		return ni < nj
+	})
+
+	for _, name := range names {
+		component := legacyLogFile.FindStringSubmatch(name)[1]
+		header, content := parseLogFile(string(files[name]))
+		tailLines, _ := strconv.ParseInt(header["Tail Lines"], 10, 64)
+		addLogEntry(logs, component, types.LogEntry{
+			PodName:       header["Pod"],
+			ContainerName: header["Container"],
+			ContainerType: header["Container Type"],
+			Previous:      header["Previous"] == "true",
+			Logs:          content,
+			TailLines:     tailLines,
+			Error:         header["Error"],
+		})
+	}
+}
+
+// parseLogFile splits a log file written by formatLogEntry into its header fields and log content
+func parseLogFile(content string) (map[string]string, string) {
+	header := make(map[string]string)
+	head, logs, found := strings.Cut(content, "#\n\n")
+	if !found {
+		return header, content
+	}
+	for _, line := range strings.Split(head, "\n") {
+		if key, value, ok := strings.Cut(strings.TrimPrefix(line, "# "), ": "); ok {
+			header[key] = value
+		}
+	}
+	return header, logs
+}
+
+func addLogEntry(logs *types.DiagnosticLogs, component string, entry types.LogEntry) {
+	switch component {
+	case "master":
+		// The first current log of a main container is the master log, any other is extra
+		if logs.Master == nil && !entry.Previous && (entry.ContainerType == "" || entry.ContainerType == types.ContainerTypeMain) {
+			logs.Master = &entry
+		} else {
+			logs.MasterExtra = append(logs.MasterExtra, entry)
+		}
+	case "worker":
+		logs.Workers = append(logs.Workers, entry)
+	case "fuse":
+		logs.Fuse = append(logs.Fuse, entry)
+	case "controlplane":
+		logs.ControlPlane = append(logs.ControlPlane, entry)
+	}
+}
+
+var eventsLogLine = regexp.MustCompile(`^\[([^\]]+)\] (\S+) ([^/]*)/(\S*): (.*)$`)
+
+// parseEventsLog parses the human-readable events.log of archives without events.json.
+// Count, FirstTimestamp and Source were not recorded and are left empty.
+func parseEventsLog(content string) []types.EventInfo {
+	var events []types.EventInfo
+	var current *types.EventInfo
+
+	for _, line := range strings.Split(content, "\n") {
+		if m := eventsLogLine.FindStringSubmatch(line); m != nil {
+			events = append(events, types.EventInfo{
+				Type:       m[2],
+				ObjectKind: m[3],
+				ObjectName: m[4],
+				Reason:     m[5],
+			})
+			current = &events[len(events)-1]
+			if t, err := time.ParseInLocation("2006-01-02 15:04:05", m[1], time.Local); err == nil {
+				current.LastTimestamp = t
+			}
+			continue
+		}
+		if current != nil && strings.HasPrefix(line, "    ") && current.Message == "" {
+			current.Message = strings.TrimPrefix(line, "    ")
+		}
+	}
+
+	return events
+}
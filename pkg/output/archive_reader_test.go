@@ -0,0 +1,226 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose/mock"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// legacyArchive was written before archives carried a manifest and events.json
+const legacyArchive = "../../fluid-diagnose-demo-data-20260208-005357.tar.gz"
+
+func TestArchiveRoundTrip(t *testing.T) {
+	want := mock.MockDiagnosticResult("demo-data", "fluid-system")
+	want.Logs.Fuse = append(want.Logs.Fuse, types.LogEntry{
+		PodName:       "demo-data-fuse-zzzzz",
+		ContainerName: "alluxio-fuse",
+		TailLines:     100,
+		Error:         "pods \"demo-data-fuse-zzzzz\" is forbidden",
+	})
+	want.Logs.Workers = append(want.Logs.Workers, types.LogEntry{
+		PodName:       "demo-data-worker-1",
+		ContainerName: "alluxio-worker",
+		ContainerType: types.ContainerTypeMain,
+		Previous:      true,
+		Logs:          "worker exited: out of memory",
+		TailLines:     100,
+	})
+	want.Logs.MasterExtra = append(want.Logs.MasterExtra, types.LogEntry{
+		PodName:       "demo-data-master-0",
+		ContainerName: "init-journal",
+		ContainerType: types.ContainerTypeInit,
+		Logs:          "formatting journal",
+		TailLines:     100,
+	})
+
+	archiver := &Archiver{outputDir: t.TempDir()}
+	path, err := archiver.CreateArchive(want)
+	if err != nil {
+		t.Fatalf("CreateArchive: %v", err)
+	}
+
+	got, err := ReadArchive(path)
+	if err != nil {
+		t.Fatalf("ReadArchive: %v", err)
+	}
+
+	if got.DatasetName != want.DatasetName || got.Namespace != want.Namespace || got.RuntimeType != want.RuntimeType {
+		t.Errorf("metadata = %s/%s (%s), want %s/%s (%s)",
+			got.Namespace, got.DatasetName, got.RuntimeType, want.Namespace, want.DatasetName, want.RuntimeType)
+	}
+	if !got.CollectedAt.Equal(want.CollectedAt) {
+		t.Errorf("collectedAt = %v, want %v", got.CollectedAt, want.CollectedAt)
+	}
+	if got.DatasetYAML != want.DatasetYAML || got.RuntimeYAML != want.RuntimeYAML {
+		t.Error("CR snapshots differ after round trip")
+	}
+	if !reflect.DeepEqual(got.Resources, want.Resources) {
+		t.Errorf("resources differ after round trip:\ngot  %+v\nwant %+v", got.Resources, want.Resources)
+	}
+	if !reflect.DeepEqual(got.Collection, want.Collection) || !reflect.DeepEqual(got.CollectionSteps, want.CollectionSteps) {
+		t.Errorf("collection differs after round trip:\ngot  %+v %+v\nwant %+v %+v",
+			got.Collection, got.CollectionSteps, want.Collection, want.CollectionSteps)
+	}
+	if !reflect.DeepEqual(got.Manifests, want.Manifests) || len(got.Manifests) == 0 {
+		t.Errorf("manifests differ after round trip:\ngot  %+v\nwant %+v", got.Manifests, want.Manifests)
+	}
+	if !reflect.DeepEqual(got.Logs, want.Logs) {
+		t.Errorf("logs differ after round trip:\ngot  %+v\nwant %+v", got.Logs, want.Logs)
+	}
+	if !reflect.DeepEqual(got.FailureHints, want.FailureHints) {
+		t.Errorf("failure hints differ after round trip")
+	}
+	if len(got.Events) != len(want.Events) {
+		t.Fatalf("events = %d, want %d", len(got.Events), len(want.Events))
+	}
+	for i := range want.Events {
+		if !got.Events[i].LastTimestamp.Equal(want.Events[i].LastTimestamp) || got.Events[i].Message != want.Events[i].Message {
+			t.Errorf("event %d = %+v, want %+v", i, got.Events[i], want.Events[i])
+		}
+	}
+}
+
+func TestReadLegacyArchive(t *testing.T) {
+	result, err := ReadArchive(legacyArchive)
+	if err != nil {
+		t.Fatalf("ReadArchive: %v", err)
+	}
+
+	if result.DatasetName != "demo-data" || result.Namespace != "default" {
+		t.Errorf("dataset = %s/%s, want default/demo-data", result.Namespace, result.DatasetName)
+	}
+	if result.RuntimeType != "alluxioruntimes" {
+		t.Errorf("runtime type = %q, want alluxioruntimes", result.RuntimeType)
+	}
+	if result.CollectedAt.IsZero() {
+		t.Error("expected collectedAt from summary.txt")
+	}
+	if result.Resources.Workers == nil || result.Resources.Workers.Ready != 1 {
+		t.Errorf("workers = %+v, want 1 ready", result.Resources.Workers)
+	}
+	if len(result.Events) == 0 {
+		t.Fatal("expected events parsed from events.log")
+	}
+	first := result.Events[0]
+	if first.Type != "Warning" || first.ObjectKind != "Pod" || first.ObjectName != "demo-data-fuse-x7k2p" ||
+		first.Reason != "FailedScheduling" || first.Message == "" {
+		t.Errorf("first event = %+v", first)
+	}
+	if result.Logs.Master == nil || result.Logs.Master.ContainerName != "alluxio-master" {
+		t.Errorf("master log = %+v", result.Logs.Master)
+	}
+	if len(result.Logs.Fuse) != 1 || result.Logs.Fuse[0].PodName != "demo-data-fuse-x7k2p" || result.Logs.Fuse[0].TailLines != 100 {
+		t.Errorf("fuse logs = %+v", result.Logs.Fuse)
+	}
+}
+
+func TestReadRepackedArchive(t *testing.T) {
+	dir := t.TempDir()
+	original, err := NewArchiver().WithOutputDir(dir).WithName("original").
+		CreateArchive(mock.MockDiagnosticResult("demo-data", "default"))
+	if err != nil {
+		t.Fatalf("CreateArchive: %v", err)
+	}
+	files, err := readArchiveFiles(original, nil)
+	if err != nil {
+		t.Fatalf("readArchiveFiles: %v", err)
+	}
+
+	// Re-packed from the extracted files, as by "tar czf x.tgz ." or "tar czf x.tgz case-1/"
+	for _, prefix := range []string{"./", "case-1/", "./case-1/"} {
+		t.Run(prefix, func(t *testing.T) {
+			repacked := make(map[string][]byte, len(files))
+			for name, content := range files {
+				repacked[prefix+name] = content
+			}
+			path := filepath.Join(dir, strings.NewReplacer(".", "dot", "/", "-").Replace(prefix)+".tar.gz")
+			writeTarGz(t, path, repacked)
+
+			result, err := ReadArchive(path)
+			if err != nil {
+				t.Fatalf("ReadArchive: %v", err)
+			}
+			if result.DatasetName != "demo-data" || len(result.Manifests) == 0 {
+				t.Errorf("read back dataset %q with %d manifests", result.DatasetName, len(result.Manifests))
+			}
+			verification, err := VerifyArchive(path)
+			if err != nil {
+				t.Fatalf("VerifyArchive: %v", err)
+			}
+			if problems := verification.Problems(); len(problems) != 0 {
+				t.Errorf("problems = %+v", problems)
+			}
+		})
+	}
+}
+
+func TestArchiveContext(t *testing.T) {
+	result := mock.MockDiagnosticResult("demo-data", "default")
+	result.CollectionSteps = append(result.CollectionSteps,
+		types.CollectionStep{Name: "logs", Status: types.StepStatusPartial})
+	path, err := NewArchiver().WithOutputDir(t.TempDir()).CreateArchive(result)
+	if err != nil {
+		t.Fatalf("CreateArchive: %v", err)
+	}
+	files, err := readArchiveFiles(path, nil)
+	if err != nil {
+		t.Fatalf("readArchiveFiles: %v", err)
+	}
+
+	// context.json is the context of the diagnose package
+	var ctx types.DiagnosticContext
+	if err := json.Unmarshal(files["context.json"], &ctx); err != nil {
+		t.Fatalf("context.json: %v", err)
+	}
+	if ctx.CacheStatus == nil || len(ctx.CollectionSteps) != 1 {
+		t.Errorf("cache status = %+v, collection steps = %+v, want both", ctx.CacheStatus, ctx.CollectionSteps)
+	}
+	if want := diagnose.ToContext(result).Summary; ctx.Summary != want {
+		t.Errorf("summary = %+v, want %+v", ctx.Summary, want)
+	}
+}
+
+func TestReadArchiveErrors(t *testing.T) {
+	if _, err := ReadArchive("does-not-exist.tar.gz"); err == nil {
+		t.Error("expected an error for a missing archive")
+	}
+	if _, err := parseArchiveFiles(map[string][]byte{"summary.txt": []byte("x")}); err == nil {
+		t.Error("expected an error for an archive without dataset.yaml")
+	}
+	_, err := parseArchiveFiles(map[string][]byte{
+		"dataset.yaml": []byte("kind: Dataset"),
+		ManifestFile:   []byte(`{"schemaVersion": "99"}`),
+	})
+	if err == nil {
+		t.Error("expected an error for an unknown schema version")
+	}
+	_, err = parseArchiveFiles(map[string][]byte{
+		"dataset.yaml": []byte("kind: Dataset"),
+		ManifestFile:   []byte(`{"schemaVersion": "1", "datasetName": "demo"}`),
+	})
+	if err != nil {
+		t.Errorf("schema version 1 is no longer read: %v", err)
+	}
+}
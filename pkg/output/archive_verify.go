@@ -0,0 +1,125 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"filippo.io/age"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// Outcomes of checking an archive entry against the manifest
+const (
+	EntryOK       = "ok"
+	EntryModified = "modified" // The content does not match the recorded checksum
+	EntryMissing  = "missing"  // The manifest lists a file the archive does not contain
+	EntryUnlisted = "unlisted" // The archive contains a file the manifest does not list
+)
+
+// ArchiveVerification is the outcome of checking an archive against its manifest
+type ArchiveVerification struct {
+	Path          string                `json:"path"`
+	SchemaVersion string                `json:"schemaVersion"`
+	DatasetName   string                `json:"datasetName"`
+	Namespace     string                `json:"namespace"`
+	Collection    *types.CollectionInfo `json:"collection,omitempty"`
+	Entries       []EntryCheck          `json:"entries"`
+}
+
+// EntryCheck is the outcome of checking one archive entry
+type EntryCheck struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	// Expected and Actual are the recorded and computed SHA-256 of a modified entry
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// Problems returns the entries that are not ok
+func (v *ArchiveVerification) Problems() []EntryCheck {
+	var problems []EntryCheck
+	for _, entry := range v.Entries {
+		if entry.Status != EntryOK {
+			problems = append(problems, entry)
+		}
+	}
+	return problems
+}
+
+// VerifyArchive checks every entry of an archive against the checksums of its manifest.
+// It returns an error when the archive cannot be read or carries no checksums, which is
+// the case of archives written before schema version 2. Encrypted archives are decrypted
+// with the identities.
+func VerifyArchive(path string, identities ...age.Identity) (*ArchiveVerification, error) {
+	files, err := readArchiveFiles(path, identities)
+	if err != nil {
+		return nil, err
+	}
+
+	content, ok := files[ManifestFile]
+	if !ok {
+		return nil, fmt.Errorf("archive has no %s, it was written before archives could be verified", ManifestFile)
+	}
+	manifest := &ArchiveManifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	if err := checkSchemaVersion(manifest.SchemaVersion); err != nil {
+		return nil, err
+	}
+	if len(manifest.Entries) == 0 {
+		return nil, fmt.Errorf("archive schema version %s has no checksums to verify", manifest.SchemaVersion)
+	}
+
+	verification := &ArchiveVerification{
+		Path:          path,
+		SchemaVersion: manifest.SchemaVersion,
+		DatasetName:   manifest.DatasetName,
+		Namespace:     manifest.Namespace,
+		Collection:    manifest.Collection,
+	}
+	listed := map[string]bool{ManifestFile: true}
+	for _, entry := range manifest.Entries {
+		listed[entry.Path] = true
+		check := EntryCheck{Path: entry.Path, Status: EntryOK}
+		data, ok := files[entry.Path]
+		switch {
+		case !ok:
+			check.Status = EntryMissing
+		case checksum(data) != entry.SHA256:
+			check.Status = EntryModified
+			check.Expected = entry.SHA256
+			check.Actual = checksum(data)
+		}
+		verification.Entries = append(verification.Entries, check)
+	}
+
+	var unlisted []string
+	for name := range files {
+		if !listed[name] {
+			unlisted = append(unlisted, name)
+		}
+	}
+	sort.Strings(unlisted)
+	for _, name := range unlisted {
+		verification.Entries = append(verification.Entries, EntryCheck{Path: name, Status: EntryUnlisted})
+	}
+	return verification, nil
+}
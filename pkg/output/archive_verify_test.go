@@ -0,0 +1,128 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose/mock"
+)
+
+// writeTarGz writes files to a tar.gz archive at path
+func writeTarGz(t *testing.T, path string, files map[string][]byte) {
+	t.Helper()
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyArchive(t *testing.T) {
+	path, err := (&Archiver{outputDir: t.TempDir()}).CreateArchive(mock.MockDiagnosticResult("demo-data", "default"))
+	if err != nil {
+		t.Fatalf("CreateArchive: %v", err)
+	}
+
+	verification, err := VerifyArchive(path)
+	if err != nil {
+		t.Fatalf("VerifyArchive: %v", err)
+	}
+	if problems := verification.Problems(); len(problems) != 0 || len(verification.Entries) == 0 {
+		t.Fatalf("untouched archive: problems = %+v, entries = %d", problems, len(verification.Entries))
+	}
+	if verification.Collection == nil || verification.Collection.ServerVersion != "v1.30.2" {
+		t.Errorf("collection = %+v", verification.Collection)
+	}
+
+	// Alter one file, remove another and add a third
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := readTarGz(file)
+	file.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files["dataset.yaml"] = append(files["dataset.yaml"], []byte("# edited\n")...)
+	delete(files, "events.log")
+	files["extra.txt"] = []byte("not collected")
+	tampered := filepath.Join(t.TempDir(), "tampered.tar.gz")
+	writeTarGz(t, tampered, files)
+
+	verification, err = VerifyArchive(tampered)
+	if err != nil {
+		t.Fatalf("VerifyArchive: %v", err)
+	}
+	want := map[string]string{"dataset.yaml": EntryModified, "events.log": EntryMissing, "extra.txt": EntryUnlisted}
+	problems := verification.Problems()
+	if len(problems) != len(want) {
+		t.Errorf("problems = %+v, want %v", problems, want)
+	}
+	for _, problem := range problems {
+		if want[problem.Path] != problem.Status {
+			t.Errorf("%s: status = %s, want %s", problem.Path, problem.Status, want[problem.Path])
+		}
+	}
+
+	// Archives without checksums cannot be verified
+	delete(files, ManifestFile)
+	unindexed := filepath.Join(t.TempDir(), "unindexed.tar.gz")
+	writeTarGz(t, unindexed, files)
+	if _, err := VerifyArchive(unindexed); err == nil {
+		t.Error("expected an error for an archive without a manifest")
+	}
+}
+
+func TestCreateArchiveIsReproducible(t *testing.T) {
+	result := mock.MockDiagnosticResult("demo-data", "default")
+	first, err := (&Archiver{outputDir: t.TempDir()}).CreateArchive(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := (&Archiver{outputDir: t.TempDir()}).CreateArchive(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := os.ReadFile(first)
+	b, _ := os.ReadFile(second)
+	if !bytes.Equal(a, b) {
+		t.Error("archives of the same diagnosis differ")
+	}
+}
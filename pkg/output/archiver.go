@@ -17,142 +17,305 @@ limitations under the License.
 package output
 
 import (
-	"archive/tar"
-	"compress/gzip"
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"filippo.io/age"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/redact"
 	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
 )
 
+// RedactionFile is the report of the values masked in an archive
+const RedactionFile = "redaction.json"
+
 // Archiver creates diagnostic archives
 type Archiver struct {
-	outputDir string
+	outputDir  string
+	name       string
+	format     string
+	redaction  *redact.Policy
+	recipients []age.Recipient
 }
 
 // NewArchiver creates a new Archiver
 func NewArchiver() *Archiver {
 	return &Archiver{
 		outputDir: ".", // Current directory
+		format:    ArchiveFormatTarGz,
+		redaction: redact.DefaultPolicy(),
 	}
 }
 
-// CreateArchive creates a tar.gz archive of diagnostic data
+// WithOutputDir sets the directory the archive is written to
+func (a *Archiver) WithOutputDir(dir string) *Archiver {
+	a.outputDir = dir
+	return a
+}
+
+// WithName sets the name of the archive, replacing fluid-diagnose-<dataset>-<timestamp>.
+// The extension of the format is appended when missing.
+func (a *Archiver) WithName(name string) *Archiver {
+	a.name = name
+	return a
+}
+
+// WithFormat sets the format of the archive, one of ArchiveFormats
+func (a *Archiver) WithFormat(format string) *Archiver {
+	a.format = format
+	return a
+}
+
+// WithRedaction sets the policy masking secrets in the archived files
+func (a *Archiver) WithRedaction(policy *redact.Policy) *Archiver {
+	a.redaction = policy
+	return a
+}
+
+// WithEncryption encrypts the archive to the age recipients, so that only the holders of
+// their identities can read it. The plaintext is encrypted as it is written and never
+// touches disk. Directory archives cannot be encrypted.
+func (a *Archiver) WithEncryption(recipients ...age.Recipient) *Archiver {
+	a.recipients = recipients
+	return a
+}
+
+// CreateArchive writes an archive of diagnostic data to the output directory and returns
+// its path. The archive is written under a temporary name and renamed once complete, so
+// that a failed write leaves nothing behind; an existing archive is never replaced.
+// Secrets are masked in the result, in place, before any file is written, and the masked
+// values are counted in redaction.json along with those masked during collection.
 func (a *Archiver) CreateArchive(result *types.DiagnosticResult) (string, error) {
-	timestamp := time.Now().Format("20060102-150405")
-	archiveName := fmt.Sprintf("fluid-diagnose-%s-%s.tar.gz", result.DatasetName, timestamp)
+	format := a.archiveFormat()
+	if err := ValidateArchiveFormat(format); err != nil {
+		return "", err
+	}
+	archiveName, err := a.archiveName(result, format)
+	if err != nil {
+		return "", err
+	}
 	archivePath := filepath.Join(a.outputDir, archiveName)
+	if len(a.recipients) > 0 {
+		if err := checkStreamable(format); err != nil {
+			return "", fmt.Errorf("cannot encrypt archive: %w", err)
+		}
+	}
+
+	a.redact(result)
+
+	if format == ArchiveFormatDir {
+		err = writeDirAtomically(archivePath, func(dir string) error {
+			return a.writeArchive(&dirSink{dir: dir}, result)
+		})
+	} else {
+		err = writeFileAtomically(archivePath, func(w io.Writer) error {
+			return a.writeStream(w, format, result)
+		})
+	}
+	if err != nil {
+		return "", err
+	}
+	return archivePath, nil
+}
+
+// WriteArchive streams an archive of diagnostic data to w, such as stdout. Only the tar.gz
+// and zip formats can be streamed. Secrets are masked and the archive encrypted as by
+// CreateArchive.
+func (a *Archiver) WriteArchive(w io.Writer, result *types.DiagnosticResult) error {
+	format := a.archiveFormat()
+	if err := checkStreamable(format); err != nil {
+		return err
+	}
+	a.redact(result)
+	return a.writeStream(w, format, result)
+}
+
+// writeStream writes a tar.gz or zip archive to w, through encryption when recipients are set
+func (a *Archiver) writeStream(w io.Writer, format string, result *types.DiagnosticResult) error {
+	if len(a.recipients) == 0 {
+		sink, err := newStreamSink(format, w)
+		if err != nil {
+			return err
+		}
+		return a.writeArchive(sink, result)
+	}
 
-	// Create the archive file
-	file, err := os.Create(archivePath)
+	ew, err := encryptingWriter(w, a.recipients)
+	if err != nil {
+		return err
+	}
+	sink, err := newStreamSink(format, ew)
 	if err != nil {
-		return "", fmt.Errorf("failed to create archive file: %w", err)
+		return err
+	}
+	if err := a.writeArchive(sink, result); err != nil {
+		return err
+	}
+	if err := ew.Close(); err != nil {
+		return fmt.Errorf("failed to encrypt archive: %w", err)
+	}
+	return nil
+}
+
+func (a *Archiver) archiveFormat() string {
+	if a.format == "" {
+		return ArchiveFormatTarGz
 	}
-	defer file.Close()
+	return a.format
+}
 
-	// Create gzip writer
-	gw := gzip.NewWriter(file)
-	defer gw.Close()
+// archiveName returns the file name of the archive, with the extension of its format and
+// .age when encrypted
+func (a *Archiver) archiveName(result *types.DiagnosticResult, format string) (string, error) {
+	name := a.name
+	if name == "" {
+		timestamp := time.Now().Format("20060102-150405")
+		name = fmt.Sprintf("fluid-diagnose-%s-%s", result.DatasetName, timestamp)
+	}
+	if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid archive name %q: set the directory separately", name)
+	}
+	ext := archiveExtensions[format]
+	if len(a.recipients) > 0 {
+		name = strings.TrimSuffix(name, EncryptedExtension)
+		name = strings.TrimSuffix(name, ext)
+		return name + ext + EncryptedExtension, nil
+	}
+	return strings.TrimSuffix(name, ext) + ext, nil
+}
 
-	// Create tar writer
-	tw := tar.NewWriter(gw)
-	defer tw.Close()
+// redact masks secrets in the result and merges what was masked into its report
+func (a *Archiver) redact(result *types.DiagnosticResult) {
+	redactor := a.redaction.NewRedactor()
+	redactor.Result(result)
+	result.Redaction = redact.Merge(result.Redaction, redactor.Report())
+}
 
+// writeArchive writes every file of the archive to the sink and closes it. Entries carry
+// the collection time, so that an archive of the same diagnosis is identical whenever it
+// is written.
+func (a *Archiver) writeArchive(sink archiveSink, result *types.DiagnosticResult) error {
+	w := &archiveWriter{sink: sink, modTime: result.CollectedAt}
+	if err := a.writeEntries(w, result); err != nil {
+		sink.Close()
+		return err
+	}
+	return sink.Close()
+}
+
+// writeEntries adds the files of the archive
+func (a *Archiver) writeEntries(w *archiveWriter, result *types.DiagnosticResult) error {
 	// Add files to archive
 
 	// 1. dataset.yaml
-	if err := a.addFileToTar(tw, "dataset.yaml", result.DatasetYAML); err != nil {
-		return "", err
+	if err := w.add("dataset.yaml", result.DatasetYAML); err != nil {
+		return err
 	}
 
 	// 2. runtime.yaml (if exists)
 	if result.RuntimeYAML != "" {
-		if err := a.addFileToTar(tw, "runtime.yaml", result.RuntimeYAML); err != nil {
-			return "", err
+		if err := w.add("runtime.yaml", result.RuntimeYAML); err != nil {
+			return err
 		}
 	}
 
-	// 3. events.log
+	// 3. events.log and events.json
 	eventsContent := a.formatEvents(result.Events)
-	if err := a.addFileToTar(tw, "events.log", eventsContent); err != nil {
-		return "", err
+	if err := w.add("events.log", eventsContent); err != nil {
+		return err
+	}
+	eventsJSON, _ := json.MarshalIndent(result.Events, "", "  ")
+	if err := w.add("events.json", string(eventsJSON)); err != nil {
+		return err
 	}
 
 	// 4. resources.json
 	resourcesJSON, _ := json.MarshalIndent(result.Resources, "", "  ")
-	if err := a.addFileToTar(tw, "resources.json", string(resourcesJSON)); err != nil {
-		return "", err
+	if err := w.add("resources.json", string(resourcesJSON)); err != nil {
+		return err
+	}
+
+	// 4b. nodes.txt - where fuse and worker pods can run
+	if len(result.Resources.Nodes) > 0 {
+		var nodes strings.Builder
+		_ = WriteNodeTable(&nodes, result.Resources.Nodes, "")
+		if err := w.add("nodes.txt", nodes.String()); err != nil {
+			return err
+		}
 	}
 
 	// 5. failure_hints.json
 	hintsJSON, _ := json.MarshalIndent(result.FailureHints, "", "  ")
-	if err := a.addFileToTar(tw, "failure_hints.json", string(hintsJSON)); err != nil {
-		return "", err
+	if err := w.add("failure_hints.json", string(hintsJSON)); err != nil {
+		return err
 	}
 
 	// 6. pods/ directory with logs
-	if result.Logs.Master != nil && result.Logs.Master.Logs != "" {
-		if err := a.addFileToTar(tw, "pods/master.log", formatLogEntry(result.Logs.Master)); err != nil {
-			return "", err
+	manifest := newArchiveManifest(result)
+	for _, file := range manifest.Logs {
+		if err := w.add(file.Path, formatLogEntry(file.entry)); err != nil {
+			return err
 		}
 	}
 
-	for i, entry := range result.Logs.Workers {
-		if entry.Logs != "" {
-			filename := fmt.Sprintf("pods/worker-%d.log", i)
-			if err := a.addFileToTar(tw, filename, formatLogEntry(&entry)); err != nil {
-				return "", err
-			}
-		}
-	}
-
-	for i, entry := range result.Logs.Fuse {
-		if entry.Logs != "" {
-			filename := fmt.Sprintf("pods/fuse-%d.log", i)
-			if err := a.addFileToTar(tw, filename, formatLogEntry(&entry)); err != nil {
-				return "", err
-			}
+	// 6b. manifests/ directory with the YAML of the objects backing the Dataset
+	for i := range result.Manifests {
+		m := &result.Manifests[i]
+		if err := w.add(m.Path(), m.YAML); err != nil {
+			return err
 		}
 	}
 
 	// 7. summary.txt - Human readable summary
 	summary := a.generateSummary(result)
-	if err := a.addFileToTar(tw, "summary.txt", summary); err != nil {
-		return "", err
+	if err := w.add("summary.txt", summary); err != nil {
+		return err
 	}
 
 	// 8. context.json - AI-ready context
-	diagnoser := &contextConverter{}
-	context := diagnoser.ToContext(result)
-	contextJSON, _ := json.MarshalIndent(context, "", "  ")
-	if err := a.addFileToTar(tw, "context.json", string(contextJSON)); err != nil {
-		return "", err
+	contextJSON, _ := json.MarshalIndent(diagnose.ToContext(result), "", "  ")
+	if err := w.add("context.json", string(contextJSON)); err != nil {
+		return err
 	}
 
-	return archivePath, nil
-}
-
-// addFileToTar adds a file to the tar archive
-func (a *Archiver) addFileToTar(tw *tar.Writer, name string, content string) error {
-	header := &tar.Header{
-		Name:    name,
-		Mode:    0644,
-		Size:    int64(len(content)),
-		ModTime: time.Now(),
+	// 9. redaction.json - what was masked, without the values
+	redactionJSON, _ := json.MarshalIndent(result.Redaction, "", "  ")
+	if err := w.add(RedactionFile, string(redactionJSON)); err != nil {
+		return err
 	}
 
-	if err := tw.WriteHeader(header); err != nil {
-		return fmt.Errorf("failed to write tar header for %s: %w", name, err)
+	// 10. manifest.json - index used to read the archive back, with the checksums of
+	// every entry above
+	manifest.Entries = w.entries
+	manifestJSON, _ := json.MarshalIndent(manifest, "", "  ")
+	if err := w.add(ManifestFile, string(manifestJSON)); err != nil {
+		return err
 	}
 
-	if _, err := tw.Write([]byte(content)); err != nil {
-		return fmt.Errorf("failed to write tar content for %s: %w", name, err)
-	}
+	return nil
+}
 
+// archiveWriter adds entries to an archive and records their checksums
+type archiveWriter struct {
+	sink    archiveSink
+	modTime time.Time
+	entries []ArchiveEntry
+}
+
+// add adds a file to the archive
+func (w *archiveWriter) add(name string, content string) error {
+	modTime := w.modTime
+	if modTime.IsZero() {
+		modTime = time.Now()
+	}
+	if err := w.sink.write(name, []byte(content), modTime); err != nil {
+		return err
+	}
+	w.entries = append(w.entries, ArchiveEntry{Path: name, Size: int64(len(content)), SHA256: checksum([]byte(content))})
 	return nil
 }
 
@@ -187,8 +350,23 @@ func (a *Archiver) generateSummary(result *types.DiagnosticResult) string {
 	sb.WriteString(fmt.Sprintf("Namespace:    %s\n", result.Namespace))
 	sb.WriteString(fmt.Sprintf("Collected At: %s\n", result.CollectedAt.Format("2006-01-02 15:04:05")))
 	sb.WriteString(fmt.Sprintf("Health:       %s\n", result.HealthStatus))
+	if result.Redaction != nil {
+		sb.WriteString(fmt.Sprintf("Redacted:     %d secret value(s), see %s\n", result.Redaction.Total, RedactionFile))
+	}
 	sb.WriteString("\n")
 
+	if report := result.Permissions; report != nil && (report.Error != "" || len(report.Denied()) > 0) {
+		sb.WriteString("MISSING PERMISSIONS\n")
+		sb.WriteString("-------------------\n")
+		if report.Error != "" {
+			sb.WriteString(fmt.Sprintf("Failed to review the permissions: %s\n", report.Error))
+		}
+		for _, check := range report.Denied() {
+			sb.WriteString(fmt.Sprintf("%s (used by %s)\n", check.Permission, strings.Join(check.Steps, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+
 	sb.WriteString("RESOURCE STATUS\n")
 	sb.WriteString("---------------\n")
 
@@ -210,8 +388,45 @@ func (a *Archiver) generateSummary(result *types.DiagnosticResult) string {
 	if result.Resources.PVC != nil {
 		sb.WriteString(fmt.Sprintf("PVC:     %s\n", result.Resources.PVC.Phase))
 	}
+	if apps := result.Resources.Apps; len(apps) > 0 {
+		sb.WriteString(fmt.Sprintf("Apps:    %d/%d ready\n", readyApps(apps), len(apps)))
+	}
 	sb.WriteString("\n")
 
+	if len(result.Resources.Apps) > 0 {
+		sb.WriteString("APPLICATION PODS\n")
+		sb.WriteString("----------------\n")
+		for _, app := range result.Resources.Apps {
+			sb.WriteString(fmt.Sprintf("%s: %s, node %s, fuse ready %t\n",
+				app.Name, app.Phase, orDash(app.NodeName), app.FuseReady))
+			for _, event := range app.MountEvents {
+				sb.WriteString(fmt.Sprintf("  %s (x%d): %s\n", event.Reason, event.Count, event.Message))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if cp := result.Resources.ControlPlane; cp != nil {
+		sb.WriteString("FLUID CONTROL PLANE\n")
+		sb.WriteString("-------------------\n")
+		if len(cp.Components) == 0 {
+			sb.WriteString(fmt.Sprintf("No Fluid components found in namespace %s\n", cp.Namespace))
+		}
+		for _, c := range cp.Components {
+			if c.Relevant {
+				sb.WriteString(fmt.Sprintf("%s/%s (%s): %d/%d ready, %d restarts\n",
+					cp.Namespace, c.Name, c.Kind, c.Ready, c.Desired, c.Restarts))
+			}
+		}
+		if cp.CSIDriver != nil {
+			sb.WriteString(fmt.Sprintf("CSIDriver %s: found %t\n", cp.CSIDriver.Name, cp.CSIDriver.Found))
+		}
+		for _, node := range cp.CSINodes {
+			sb.WriteString(fmt.Sprintf("CSI nodeplugin on %s: %s, ready %t\n", node.Node, orDash(node.Pod), node.Ready))
+		}
+		sb.WriteString("\n")
+	}
+
 	if len(result.FailureHints) > 0 {
 		sb.WriteString("DETECTED ISSUES\n")
 		sb.WriteString("---------------\n")
@@ -221,6 +436,9 @@ func (a *Archiver) generateSummary(result *types.DiagnosticResult) string {
 				hint.Component,
 				hint.Issue))
 			sb.WriteString(fmt.Sprintf("  -> %s\n", hint.Suggestion))
+			if hint.RuleID != "" {
+				sb.WriteString(fmt.Sprintf("  Rule: %s (%s)\n", hint.RuleID, hint.DocURL))
+			}
 		}
 		sb.WriteString("\n")
 	}
@@ -230,10 +448,17 @@ func (a *Archiver) generateSummary(result *types.DiagnosticResult) string {
 	sb.WriteString("- dataset.yaml:       Dataset CR snapshot\n")
 	sb.WriteString("- runtime.yaml:       Runtime CR snapshot\n")
 	sb.WriteString("- events.log:         Kubernetes events\n")
-	sb.WriteString("- resources.json:     Resource status details\n")
+	sb.WriteString("- events.json:        Kubernetes events (machine-readable)\n")
+	sb.WriteString("- resources.json:     Resource status details, including application pods\n")
+	sb.WriteString("- nodes.txt:          Nodes and why fuse or worker pods can or cannot run there\n")
 	sb.WriteString("- failure_hints.json: Detected issues\n")
-	sb.WriteString("- pods/               Container logs\n")
+	sb.WriteString("- pods/               Container logs, and control-plane log lines mentioning the Dataset\n")
+	if len(result.Manifests) > 0 {
+		sb.WriteString("- manifests/          Cleaned, redacted YAML of the workloads, pods, services, configmaps, PVC, PV, nodes and CSIDriver\n")
+	}
 	sb.WriteString("- context.json:       AI-ready diagnostic context\n")
+	sb.WriteString("- redaction.json:     Number of secrets masked per file and rule\n")
+	sb.WriteString("- manifest.json:      Archive index and metadata\n")
 	sb.WriteString("\n")
 
 	sb.WriteString("----------\n")
@@ -248,7 +473,16 @@ func formatLogEntry(entry *types.LogEntry) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("# Pod: %s\n", entry.PodName))
 	sb.WriteString(fmt.Sprintf("# Container: %s\n", entry.ContainerName))
+	if entry.ContainerType != "" {
+		sb.WriteString(fmt.Sprintf("# Container Type: %s\n", entry.ContainerType))
+	}
+	if entry.Previous {
+		sb.WriteString("# Previous: true\n")
+	}
 	sb.WriteString(fmt.Sprintf("# Tail Lines: %d\n", entry.TailLines))
+	if entry.Filter != "" {
+		sb.WriteString(fmt.Sprintf("# Filter: %s\n", entry.Filter))
+	}
 	if entry.Error != "" {
 		sb.WriteString(fmt.Sprintf("# Error: %s\n", entry.Error))
 	}
@@ -256,68 +490,3 @@ func formatLogEntry(entry *types.LogEntry) string {
 	sb.WriteString(entry.Logs)
 	return sb.String()
 }
-
-// contextConverter helps convert DiagnosticResult to DiagnosticContext
-type contextConverter struct{}
-
-func (c *contextConverter) ToContext(result *types.DiagnosticResult) *types.DiagnosticContext {
-	ctx := &types.DiagnosticContext{
-		DatasetYAML:  result.DatasetYAML,
-		RuntimeYAML:  result.RuntimeYAML,
-		Events:       result.Events,
-		FailureHints: result.FailureHints,
-		CollectedAt:  result.CollectedAt,
-		Version:      "1.0",
-		Logs:         make(map[string]string),
-	}
-
-	// Build summary
-	ctx.Summary = types.ContextSummary{
-		DatasetName:  result.DatasetName,
-		Namespace:    result.Namespace,
-		DatasetPhase: extractPhaseFromDiagnostic(result),
-		RuntimeType:  result.RuntimeType,
-		HealthStatus: result.HealthStatus,
-	}
-
-	// Add resource status to summary
-	if result.Resources.Master != nil {
-		ctx.Summary.MasterReady = fmt.Sprintf("%d/%d", result.Resources.Master.Ready, result.Resources.Master.Desired)
-	}
-	if result.Resources.Workers != nil {
-		ctx.Summary.WorkersReady = fmt.Sprintf("%d/%d", result.Resources.Workers.Ready, result.Resources.Workers.Desired)
-	}
-	if result.Resources.Fuse != nil {
-		ctx.Summary.FuseReady = fmt.Sprintf("%d/%d", result.Resources.Fuse.Ready, result.Resources.Fuse.Desired)
-	}
-	if result.Resources.PVC != nil {
-		ctx.Summary.PVCStatus = result.Resources.PVC.Phase
-	}
-
-	// Count events
-	for _, event := range result.Events {
-		if event.Type == "Warning" {
-			ctx.Summary.WarningCount++
-		}
-	}
-	ctx.Summary.ErrorCount = len(result.FailureHints)
-
-	// Add logs
-	if result.Logs.Master != nil && result.Logs.Master.Logs != "" {
-		ctx.Logs["master"] = result.Logs.Master.Logs
-	}
-	for i, entry := range result.Logs.Workers {
-		if entry.Logs != "" {
-			key := fmt.Sprintf("worker-%d", i)
-			ctx.Logs[key] = entry.Logs
-		}
-	}
-	for i, entry := range result.Logs.Fuse {
-		if entry.Logs != "" {
-			key := fmt.Sprintf("fuse-%d", i)
-			ctx.Logs[key] = entry.Logs
-		}
-	}
-
-	return ctx
-}
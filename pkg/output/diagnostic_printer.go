@@ -21,6 +21,8 @@ import (
 	"io"
 	"strings"
 
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/redact"
 	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
 )
 
@@ -54,8 +56,11 @@ func NewDiagnosticPrinter(w io.Writer) *DiagnosticPrinter {
 // Print prints the diagnostic result
 func (p *DiagnosticPrinter) Print(result *types.DiagnosticResult) {
 	p.printHeader(result)
+	p.printPermissions(result)
 	p.printResourceTree(result)
 	p.println("")
+	p.printNodes(result)
+	p.printControlPlane(result)
 	p.printFailureHints(result)
 	p.printEvents(result)
 	p.printLogs(result)
@@ -74,6 +79,10 @@ func (p *DiagnosticPrinter) printHeader(result *types.DiagnosticResult) {
 	p.printf("  %s: %s\n", p.color(colorBold, "Namespace"), result.Namespace)
 	p.printf("  %s: %s\n", p.color(colorBold, "Collected At"), result.CollectedAt.Format("2006-01-02 15:04:05"))
 	p.printf("  %s: %s\n", p.color(colorBold, "Health Status"), p.formatHealthStatus(result.HealthStatus))
+	if report := result.Redaction; report != nil && report.Total > 0 {
+		p.printf("  %s: %s\n", p.color(colorBold, "Redacted"),
+			p.color(colorDim, fmt.Sprintf("%d secret value(s) masked as %s", report.Total, redact.Mask)))
+	}
 	p.println("")
 }
 
@@ -124,6 +133,28 @@ func (p *DiagnosticPrinter) printResourceTree(result *types.DiagnosticResult) {
 		}
 	}
 
+	// Application pods mounting the PVC
+	if apps := result.Resources.Apps; len(apps) > 0 {
+		p.printf("  %s\n", p.color(colorDim, "│"))
+		p.printf("  └── %s Apps: %d/%d ready\n", p.color(colorCyan, "🚀"), readyApps(apps), len(apps))
+		for i, app := range apps {
+			p.printAppNode(&app, i == len(apps)-1)
+		}
+	}
+
+	// Cache node
+	if cache := result.CacheStatus; cache != nil {
+		p.printf("  %s\n", p.color(colorDim, "│"))
+		p.printf("  └── %s Cache: %s / %s", p.color(colorCyan, "🗄️"), orDash(cache.Cached), orDash(cache.CacheCapacity))
+		if cache.CachedPercentage != "" {
+			p.printf(" (%s of dataset)", cache.CachedPercentage)
+		}
+		if cache.CacheHitRatio != "" {
+			p.printf(", hit ratio %s", cache.CacheHitRatio)
+		}
+		p.println("")
+	}
+
 	p.println("")
 }
 
@@ -162,6 +193,56 @@ func (p *DiagnosticPrinter) printComponentNode(name string, status *types.PodGro
 	}
 }
 
+func (p *DiagnosticPrinter) printAppNode(app *types.AppPodStatus, isLast bool) {
+	prefix := "├──"
+	if isLast {
+		prefix = "└──"
+	}
+
+	node := app.NodeName
+	if node == "" {
+		node = "unscheduled"
+	}
+	state := app.Phase
+	if app.Reason != "" {
+		state = app.Reason
+	}
+	fuse := ""
+	if app.NodeName != "" && !app.FuseReady {
+		fuse = " " + p.color(colorRed, "no ready fuse")
+	}
+
+	p.printf("      %s %s %s [%s] on %s%s\n",
+		prefix,
+		p.formatStatusIcon(app.Ready),
+		app.Name,
+		state,
+		node,
+		fuse)
+	if len(app.MountEvents) > 0 {
+		childPrefix := "│   "
+		if isLast {
+			childPrefix = "    "
+		}
+		event := app.MountEvents[0]
+		p.printf("      %s└── %s %s\n",
+			childPrefix,
+			p.color(colorRed, event.Reason+":"),
+			p.truncate(event.Message, 80))
+	}
+}
+
+// readyApps counts the ready application pods
+func readyApps(apps []types.AppPodStatus) int {
+	ready := 0
+	for _, app := range apps {
+		if app.Ready {
+			ready++
+		}
+	}
+	return ready
+}
+
 func (p *DiagnosticPrinter) printFailureHints(result *types.DiagnosticResult) {
 	if len(result.FailureHints) == 0 {
 		p.println(p.color(colorGreen, "=== NO ISSUES DETECTED ==="))
@@ -179,9 +260,9 @@ func (p *DiagnosticPrinter) printFailureHints(result *types.DiagnosticResult) {
 
 	for _, hint := range result.FailureHints {
 		switch hint.Severity {
-		case "critical":
+		case diagnose.SeverityCritical:
 			criticals = append(criticals, hint)
-		case "warning":
+		case diagnose.SeverityWarning:
 			warnings = append(warnings, hint)
 		default:
 			infos = append(infos, hint)
@@ -190,18 +271,7 @@ func (p *DiagnosticPrinter) printFailureHints(result *types.DiagnosticResult) {
 
 	printHints := func(hints []types.FailureHint, icon, colorCode string) {
 		for _, hint := range hints {
-			p.printf("  %s %s [%s]\n",
-				p.color(colorCode, icon),
-				p.color(colorCode, hint.Issue),
-				hint.Component)
-			p.printf("     %s %s\n",
-				p.color(colorDim, "→"),
-				hint.Suggestion)
-			if hint.Evidence != "" {
-				p.printf("     %s %s\n",
-					p.color(colorDim, "Evidence:"),
-					p.truncate(hint.Evidence, 80))
-			}
+			p.printHint(hint, icon, colorCode, "  ")
 			p.println("")
 		}
 	}
@@ -220,6 +290,45 @@ func (p *DiagnosticPrinter) printFailureHints(result *types.DiagnosticResult) {
 	}
 }
 
+func (p *DiagnosticPrinter) printNodes(result *types.DiagnosticResult) {
+	if len(result.Resources.Nodes) == 0 {
+		return
+	}
+
+	p.println(p.color(colorBold, "=== NODES ==="))
+	p.println("")
+	_ = WriteNodeTable(p.writer, result.Resources.Nodes, "  ")
+	p.println("")
+}
+
+func (p *DiagnosticPrinter) printControlPlane(result *types.DiagnosticResult) {
+	cp := result.Resources.ControlPlane
+	if cp == nil {
+		return
+	}
+
+	p.println(p.color(colorBold, "=== FLUID CONTROL PLANE ==="))
+	p.println("")
+	if len(cp.Components) == 0 {
+		p.printf("  %s\n\n", p.color(colorDim, "No Fluid components found in namespace "+cp.Namespace))
+		return
+	}
+	for _, c := range cp.Components {
+		if !c.Relevant {
+			continue
+		}
+		p.printf("  %s %s/%s (%s): %d/%d ready, %d restarts\n",
+			p.formatStatusIcon(c.Desired > 0 && c.Ready >= c.Desired), cp.Namespace, c.Name, c.Kind, c.Ready, c.Desired, c.Restarts)
+	}
+	if cp.CSIDriver != nil {
+		p.printf("  %s CSIDriver %s\n", p.formatStatusIcon(cp.CSIDriver.Found), cp.CSIDriver.Name)
+	}
+	for _, node := range cp.CSINodes {
+		p.printf("  %s CSI nodeplugin on %s: %s\n", p.formatStatusIcon(node.Ready), node.Node, orDash(node.Pod))
+	}
+	p.println("")
+}
+
 func (p *DiagnosticPrinter) printEvents(result *types.DiagnosticResult) {
 	if len(result.Events) == 0 {
 		return
@@ -268,6 +377,12 @@ func (p *DiagnosticPrinter) printLogs(result *types.DiagnosticResult) {
 	if result.Logs.Master != nil && result.Logs.Master.Logs != "" {
 		hasLogs = true
 	}
+	for _, m := range result.Logs.MasterExtra {
+		if m.Logs != "" {
+			hasLogs = true
+			break
+		}
+	}
 	if len(result.Logs.Workers) > 0 {
 		for _, w := range result.Logs.Workers {
 			if w.Logs != "" {
@@ -284,6 +399,12 @@ func (p *DiagnosticPrinter) printLogs(result *types.DiagnosticResult) {
 			}
 		}
 	}
+	for _, c := range result.Logs.ControlPlane {
+		if c.Logs != "" {
+			hasLogs = true
+			break
+		}
+	}
 
 	if !hasLogs {
 		return
@@ -296,6 +417,13 @@ func (p *DiagnosticPrinter) printLogs(result *types.DiagnosticResult) {
 		p.printLogSection("MASTER", result.Logs.Master)
 	}
 
+	for i, entry := range result.Logs.MasterExtra {
+		if entry.Logs != "" {
+			label := fmt.Sprintf("MASTER-%d", i+1)
+			p.printLogSection(label, &entry)
+		}
+	}
+
 	for i, entry := range result.Logs.Workers {
 		if entry.Logs != "" {
 			label := fmt.Sprintf("WORKER-%d", i)
@@ -309,14 +437,37 @@ func (p *DiagnosticPrinter) printLogs(result *types.DiagnosticResult) {
 			p.printLogSection(label, &entry)
 		}
 	}
+
+	for i, entry := range result.Logs.ControlPlane {
+		if entry.Logs != "" {
+			label := fmt.Sprintf("CONTROL PLANE-%d", i)
+			p.printLogSection(label, &entry)
+		}
+	}
 }
 
 func (p *DiagnosticPrinter) printLogSection(label string, entry *types.LogEntry) {
-	p.printf("  ┌─ %s [%s/%s] (%d lines)\n",
+	container := entry.ContainerName
+	if entry.ContainerType != "" && entry.ContainerType != types.ContainerTypeMain {
+		container += " (" + entry.ContainerType + ")"
+	}
+	instance := ""
+	if entry.Previous {
+		instance = " " + p.color(colorYellow, "previous instance")
+	}
+	limit := fmt.Sprintf("%d lines", entry.TailLines)
+	if entry.TailLines < 0 {
+		limit = "whole log"
+	}
+	if entry.Filter != "" {
+		limit += fmt.Sprintf(", lines about %s", entry.Filter)
+	}
+	p.printf("  ┌─ %s [%s/%s] (%s)%s\n",
 		p.color(colorCyan, label),
 		entry.PodName,
-		entry.ContainerName,
-		entry.TailLines)
+		container,
+		limit,
+		instance)
 	p.println("  │")
 
 	// Print last N lines
@@ -362,6 +513,29 @@ func (p *DiagnosticPrinter) printFooter(result *types.DiagnosticResult) {
 
 // Helper functions
 
+// printHint prints a hint with its suggestion and evidence
+func (p *DiagnosticPrinter) printHint(hint types.FailureHint, icon, colorCode, indent string) {
+	p.printf("%s%s %s [%s]",
+		indent,
+		p.color(colorCode, icon),
+		p.color(colorCode, hint.Issue),
+		hint.Component)
+	if hint.RuleID != "" {
+		p.printf(" %s", p.color(colorDim, "("+hint.RuleID+")"))
+	}
+	p.println("")
+	p.printf("%s   %s %s\n",
+		indent,
+		p.color(colorDim, "→"),
+		hint.Suggestion)
+	if hint.Evidence != "" {
+		p.printf("%s   %s %s\n",
+			indent,
+			p.color(colorDim, "Evidence:"),
+			p.truncate(hint.Evidence, 80))
+	}
+}
+
 func (p *DiagnosticPrinter) formatHealthStatus(status types.HealthStatus) string {
 	switch status {
 	case types.HealthStatusHealthy:
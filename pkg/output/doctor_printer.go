@@ -0,0 +1,70 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"strings"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/diagnose"
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// PrintDoctor prints the installation checklist
+func (p *DiagnosticPrinter) PrintDoctor(result *types.DoctorResult) {
+	p.println("")
+	p.println(p.color(colorBold, "╔══════════════════════════════════════════════════════════════════════════════╗"))
+	p.println(p.color(colorBold, "║                           FLUID INSTALLATION CHECK                           ║"))
+	p.println(p.color(colorBold, "╚══════════════════════════════════════════════════════════════════════════════╝"))
+	p.println("")
+	p.printf("  %s: %s\n", p.color(colorBold, "Fluid Namespace"), result.FluidNamespace)
+	p.printf("  %s: %s\n", p.color(colorBold, "Kubernetes"), orDash(result.ServerVersion))
+	p.printf("  %s: %s\n", p.color(colorBold, "Checked At"), result.CheckedAt.Format("2006-01-02 15:04:05"))
+	p.printf("  %s: %s\n", p.color(colorBold, "Result"), p.formatDoctorStatus(result.Status))
+	p.println("")
+
+	p.println(p.color(colorBold, "=== CHECKS ==="))
+	p.println("")
+	for _, check := range result.Checks {
+		p.printf("  %s %s\n", p.formatDoctorStatus(check.Status), p.color(colorBold, check.Title))
+		for _, detail := range check.Details {
+			p.printf("      %s\n", p.color(colorDim, detail))
+		}
+		for _, hint := range check.Hints {
+			icon, colorCode := "ℹ️", colorBlue
+			switch hint.Severity {
+			case diagnose.SeverityCritical:
+				icon, colorCode = "❌", colorRed
+			case diagnose.SeverityWarning:
+				icon, colorCode = "⚠️", colorYellow
+			}
+			p.printHint(hint, icon, colorCode, "      ")
+		}
+		p.println("")
+	}
+}
+
+func (p *DiagnosticPrinter) formatDoctorStatus(status types.DoctorStatus) string {
+	label := "[" + strings.ToUpper(string(status)) + "]"
+	switch status {
+	case types.DoctorPass:
+		return p.color(colorGreen, label)
+	case types.DoctorWarn:
+		return p.color(colorYellow, label)
+	default:
+		return p.color(colorRed, label)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// WriteNodeTable writes the nodes of a diagnosis as a table, each line prefixed with
+// indent, followed by the reasons fuse or worker pods cannot run on each node
+func WriteNodeTable(w io.Writer, nodes []types.NodeDiagnostic, indent string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, indent+strings.Join([]string{"NODE", "STATUS", "CPU", "MEMORY", "FUSE", "WORKER", "PODS"}, "\t"))
+	for _, node := range nodes {
+		fmt.Fprintln(tw, indent+strings.Join([]string{
+			node.Name,
+			nodeStatusText(&node),
+			orDash(node.CPU),
+			orDash(node.Memory),
+			placementText(node.Fuse),
+			placementText(node.Worker),
+			fmt.Sprintf("%d runtime, %d app", len(node.RuntimePods), len(node.AppPods)),
+		}, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		for _, p := range []struct {
+			component string
+			placement *types.NodePlacement
+		}{{"fuse", node.Fuse}, {"worker", node.Worker}} {
+			if p.placement == nil || p.placement.CanRun {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "%s%s: %s cannot run: %s\n", indent, node.Name, p.component,
+				strings.Join(p.placement.Reasons, "; ")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// nodeStatusText formats the status of a node the way kubectl get nodes does, with any pressure
+func nodeStatusText(node *types.NodeDiagnostic) string {
+	status := []string{"Ready"}
+	if !node.Ready {
+		status[0] = "NotReady"
+	}
+	if node.Unschedulable {
+		status = append(status, "SchedulingDisabled")
+	}
+	return strings.Join(append(status, node.Pressure...), ",")
+}
+
+// placementText summarizes whether pods of a component run or can run on a node
+func placementText(placement *types.NodePlacement) string {
+	switch {
+	case placement == nil:
+		return "-"
+	case placement.Running && placement.CanRun:
+		return "running"
+	case placement.Running:
+		return "running (blocked)"
+	case placement.CanRun:
+		return "yes"
+	default:
+		return "no"
+	}
+}
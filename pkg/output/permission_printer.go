@@ -0,0 +1,77 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// PrintPermissions prints every reviewed permission and the collection steps using it
+func (p *DiagnosticPrinter) PrintPermissions(report *types.PermissionReport) {
+	p.println(p.color(colorBold, "=== PERMISSIONS ==="))
+	if report.Error != "" {
+		p.printf("  %s %s\n", p.color(colorYellow, "⚠️"), "Failed to review the permissions: "+report.Error)
+		return
+	}
+	for _, check := range report.Checks {
+		p.printPermissionCheck(check)
+	}
+	p.printf("  %s\n", p.permissionSummary(report))
+}
+
+// printPermissions prints the permissions summary of a diagnosis and the denied permissions,
+// ahead of the results they affect
+func (p *DiagnosticPrinter) printPermissions(result *types.DiagnosticResult) {
+	report := result.Permissions
+	if report == nil {
+		return
+	}
+	denied := report.Denied()
+	if report.Error == "" && len(denied) == 0 {
+		p.printf("  %s: %s\n\n", p.color(colorBold, "Permissions"), p.permissionSummary(report))
+		return
+	}
+
+	p.println(p.color(colorBold, "=== PERMISSIONS ==="))
+	if report.Error != "" {
+		p.printf("  %s %s\n\n", p.color(colorYellow, "⚠️"), "Failed to review the permissions: "+report.Error)
+		return
+	}
+	for _, check := range denied {
+		p.printPermissionCheck(check)
+	}
+	p.printf("  %s\n\n", p.permissionSummary(report))
+}
+
+func (p *DiagnosticPrinter) printPermissionCheck(check types.PermissionCheck) {
+	usage := "used by " + strings.Join(check.Steps, ", ")
+	if !check.Allowed && len(check.RequiredBy) > 0 {
+		usage += "; skips " + strings.Join(check.RequiredBy, ", ")
+	}
+	p.printf("  %s %-60s %s\n", p.formatStatusIcon(check.Allowed), check.Permission, p.color(colorDim, usage))
+}
+
+func (p *DiagnosticPrinter) permissionSummary(report *types.PermissionReport) string {
+	denied := len(report.Denied())
+	if denied == 0 {
+		return p.color(colorGreen, fmt.Sprintf("✓ all %d permissions granted", len(report.Checks)))
+	}
+	return p.color(colorYellow, fmt.Sprintf("%d of %d permissions denied", denied, len(report.Checks)))
+}
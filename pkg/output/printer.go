@@ -44,7 +44,10 @@ func (p *TextPrinter) Print(result *types.InspectionResult) {
 	}
 
 	p.printResourceStatus(&result.Resources)
-	p.printConditions(result.Dataset.Conditions)
+	p.printCacheStatus(result.CacheStatus)
+	p.printConditions("CONDITIONS:", result.Dataset.Conditions)
+	p.printConditions("RUNTIME CONDITIONS:", result.Conditions)
+	p.printWarnings(result.Warnings)
 	p.printFooter()
 }
 
@@ -170,13 +173,39 @@ func (p *TextPrinter) printResourceStatus(resources *types.ResourceStatus) {
 	p.println("")
 }
 
-func (p *TextPrinter) printConditions(conditions []types.ConditionInfo) {
+func (p *TextPrinter) printCacheStatus(cache *types.CacheStatus) {
+	if cache == nil {
+		return
+	}
+
+	p.println("CACHE STATUS:")
+	p.println(strings.Repeat("-", 40))
+
+	fields := []struct{ label, value string }{
+		{"Capacity", cache.CacheCapacity},
+		{"Cached", cache.Cached},
+		{"Cached Percentage", cache.CachedPercentage},
+		{"Cacheable", cache.Cacheable},
+		{"Hit Ratio", cache.CacheHitRatio},
+		{"Low Watermark", cache.LowWaterMark},
+		{"High Watermark", cache.HighWaterMark},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			p.printf("%-20s %s\n", f.label+":", f.value)
+		}
+	}
+
+	p.println("")
+}
+
+func (p *TextPrinter) printConditions(title string, conditions []types.ConditionInfo) {
 	if len(conditions) == 0 {
 		return
 	}
 
 	p.println(strings.Repeat("=", 80))
-	p.println("CONDITIONS:")
+	p.println(title)
 	p.println(strings.Repeat("=", 80))
 
 	for _, cond := range conditions {
@@ -199,6 +228,20 @@ func (p *TextPrinter) printConditions(conditions []types.ConditionInfo) {
 	p.println("")
 }
 
+func (p *TextPrinter) printWarnings(warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	p.println("WARNINGS:")
+	p.println(strings.Repeat("-", 40))
+	for _, w := range warnings {
+		p.printf("⚠️  %s\n", w)
+	}
+
+	p.println("")
+}
+
 func (p *TextPrinter) formatPhase(phase string) string {
 	switch phase {
 	case "Bound":
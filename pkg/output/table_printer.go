@@ -0,0 +1,165 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// PrintJSON writes v as indented JSON
+func PrintJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// PrintYAML writes v as YAML, using its JSON field names
+func PrintYAML(w io.Writer, v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// TablePrinter prints inspection results as a kubectl-style table, one row per Dataset
+type TablePrinter struct {
+	writer        io.Writer
+	wide          bool
+	showNamespace bool
+}
+
+// NewTablePrinter creates a new TablePrinter. Wide mode adds the namespace, volume and cache columns.
+func NewTablePrinter(w io.Writer, wide bool) *TablePrinter {
+	return &TablePrinter{writer: w, wide: wide, showNamespace: wide}
+}
+
+// WithNamespace adds the NAMESPACE column outside of wide mode, as for lists across namespaces
+func (p *TablePrinter) WithNamespace(show bool) *TablePrinter {
+	p.showNamespace = show || p.wide
+	return p
+}
+
+// Print prints the inspection results
+func (p *TablePrinter) Print(results ...*types.InspectionResult) error {
+	tw := tabwriter.NewWriter(p.writer, 0, 0, 3, ' ', 0)
+
+	// The wide columns keep the order documented in docs/inspect-output.md
+	headers := []string{"NAME", "PHASE", "RUNTIME", "MASTER", "WORKERS", "FUSE", "PVC"}
+	if p.showNamespace {
+		headers = append([]string{"NAMESPACE"}, headers...)
+	}
+	if p.wide {
+		headers = append(headers, "VOLUME", "CACHED", "CACHE%", "CAPACITY", "UFS TOTAL", "FILES")
+	} else {
+		headers = append(headers, "CACHE%")
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, result := range results {
+		fmt.Fprintln(tw, strings.Join(p.row(result), "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func (p *TablePrinter) row(result *types.InspectionResult) []string {
+	res := &result.Resources
+
+	runtimeType := "-"
+	var master, worker, fuse *types.ComponentStatus
+	if result.Runtime != nil {
+		runtimeType = result.Runtime.Type
+		master, worker, fuse = &result.Runtime.Master, &result.Runtime.Worker, &result.Runtime.Fuse
+	}
+
+	pvcPhase, volume := "-", "-"
+	if res.PVC != nil {
+		pvcPhase = res.PVC.Phase
+		volume = orDash(res.PVC.VolumeName)
+	}
+
+	cached, percentage, capacity := "-", "-", "-"
+	if cache := result.CacheStatus; cache != nil {
+		cached = orDash(cache.Cached)
+		percentage = orDash(cache.CachedPercentage)
+		capacity = orDash(cache.CacheCapacity)
+	}
+
+	row := []string{
+		result.Dataset.Name,
+		orDash(result.Dataset.Phase),
+		runtimeType,
+		statefulSetReady(res.MasterStatefulSet, master),
+		statefulSetReady(res.WorkerStatefulSet, worker),
+		daemonSetReady(res.FuseDaemonSet, fuse),
+		pvcPhase,
+	}
+	if p.showNamespace {
+		row = append([]string{result.Dataset.Namespace}, row...)
+	}
+	if !p.wide {
+		return append(row, percentage)
+	}
+
+	return append(row,
+		volume,
+		cached,
+		percentage,
+		capacity,
+		orDash(result.Dataset.UfsTotal),
+		orDash(result.Dataset.FileNum),
+	)
+}
+
+// statefulSetReady formats ready/desired replicas, falling back to the counts reported by the Runtime
+func statefulSetReady(sts *types.StatefulSetStatus, component *types.ComponentStatus) string {
+	if sts != nil {
+		return fmt.Sprintf("%d/%d", sts.ReadyReplicas, sts.Replicas)
+	}
+	return componentReady(component)
+}
+
+// daemonSetReady formats ready/desired pods, falling back to the counts reported by the Runtime
+func daemonSetReady(ds *types.DaemonSetStatus, component *types.ComponentStatus) string {
+	if ds != nil {
+		return fmt.Sprintf("%d/%d", ds.Ready, ds.DesiredScheduled)
+	}
+	return componentReady(component)
+}
+
+func componentReady(component *types.ComponentStatus) string {
+	if component == nil || (component.DesiredScheduled == 0 && component.Ready == 0) {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d", component.Ready, component.DesiredScheduled)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
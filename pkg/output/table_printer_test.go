@@ -0,0 +1,157 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+func testInspectionResult() *types.InspectionResult {
+	return &types.InspectionResult{
+		Dataset: types.DatasetInfo{Name: "demo", Namespace: "default", Phase: "Bound", UfsTotal: "10.00GiB"},
+		Runtime: &types.RuntimeInfo{
+			Name: "demo",
+			Type: "juicefs",
+			Fuse: types.ComponentStatus{DesiredScheduled: 3, Ready: 3},
+		},
+		Resources: types.ResourceStatus{
+			WorkerStatefulSet: &types.StatefulSetStatus{Name: "demo-worker", Replicas: 2, ReadyReplicas: 1},
+			PVC:               &types.PVCStatus{Name: "demo", Phase: "Bound", VolumeName: "default-demo"},
+		},
+		Conditions:  []types.ConditionInfo{{Type: "Ready", Status: "True"}},
+		CacheStatus: &types.CacheStatus{Cached: "1.00GiB", CachedPercentage: "10.0%"},
+	}
+}
+
+func TestTablePrinter(t *testing.T) {
+	tests := []struct {
+		name          string
+		wide          bool
+		showNamespace bool
+		want          []string
+	}{
+		{
+			name: "default",
+			want: []string{"demo", "Bound", "juicefs", "-", "1/2", "3/3", "Bound", "10.0%"},
+		},
+		{
+			name:          "all namespaces",
+			showNamespace: true,
+			want:          []string{"default", "demo", "Bound", "juicefs", "-", "1/2", "3/3", "Bound", "10.0%"},
+		},
+		{
+			name: "wide",
+			wide: true,
+			want: []string{"default", "demo", "Bound", "juicefs", "-", "1/2", "3/3", "Bound",
+				"default-demo", "1.00GiB", "10.0%", "-", "10.00GiB", "-"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewTablePrinter(&buf, tt.wide).WithNamespace(tt.showNamespace).Print(testInspectionResult()); err != nil {
+				t.Fatal(err)
+			}
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("output = %q, want header and one row", buf.String())
+			}
+			if got := strings.Fields(lines[1]); strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("row = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestInspectionResultJSONSchema guards the documented field names of 'inspect dataset -o json'
+func TestInspectionResultJSONSchema(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PrintJSON(&buf, testInspectionResult()); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"dataset", "runtime", "resources", "conditions", "cacheStatus"} {
+		if _, ok := doc[key]; !ok {
+			t.Errorf("missing top-level key %q in %s", key, buf.String())
+		}
+	}
+
+	resources := doc["resources"].(map[string]interface{})
+	worker := resources["workerStatefulSet"].(map[string]interface{})
+	if worker["readyReplicas"] != float64(1) {
+		t.Errorf("workerStatefulSet = %v, want readyReplicas 1", worker)
+	}
+	if cache := doc["cacheStatus"].(map[string]interface{}); cache["cachedPercentage"] != "10.0%" {
+		t.Errorf("cacheStatus = %v", cache)
+	}
+
+	buf.Reset()
+	if err := PrintYAML(&buf, testInspectionResult()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "cachedPercentage: 10.0%") {
+		t.Errorf("yaml output does not use JSON field names:\n%s", buf.String())
+	}
+}
+
+func TestWriteNodeTable(t *testing.T) {
+	nodes := []types.NodeDiagnostic{
+		{
+			Name: "node-1", Ready: true, CPU: "1500m/4", Memory: "2Gi/16Gi",
+			RuntimePods: []string{"demo-worker-0", "demo-fuse-abcde"},
+			Fuse:        &types.NodePlacement{Running: true, CanRun: true},
+			Worker:      &types.NodePlacement{Running: true, CanRun: true},
+		},
+		{
+			Name: "node-2", Unschedulable: true, Pressure: []string{"DiskPressure"},
+			AppPods: []string{"train-0"},
+			Fuse:    &types.NodePlacement{Reasons: []string{"node is not ready", "taint gpu:NoSchedule not tolerated"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteNodeTable(&buf, nodes, "  "); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want header, two nodes and one reason line:\n%s", len(lines), buf.String())
+	}
+	for i, want := range [][]string{
+		{"NODE", "STATUS", "CPU", "MEMORY", "FUSE", "WORKER", "PODS"},
+		{"node-1", "Ready", "1500m/4", "2Gi/16Gi", "running", "running", "2", "runtime,", "0", "app"},
+		{"node-2", "NotReady,SchedulingDisabled,DiskPressure", "-", "-", "no", "-", "0", "runtime,", "1", "app"},
+	} {
+		if got := strings.Fields(lines[i]); strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("line %d = %q, want fields %q", i, lines[i], want)
+		}
+	}
+	if want := "  node-2: fuse cannot run: node is not ready; taint gpu:NoSchedule not tolerated"; lines[3] != want {
+		t.Errorf("reason line = %q, want %q", lines[3], want)
+	}
+}
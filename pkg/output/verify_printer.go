@@ -0,0 +1,45 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+)
+
+// PrintArchiveVerification prints the collection metadata of an archive and the entries
+// that do not match its manifest
+func (p *DiagnosticPrinter) PrintArchiveVerification(v *ArchiveVerification) {
+	p.printf("  %s: %s\n", p.color(colorBold, "Archive"), v.Path)
+	p.printf("  %s: %s/%s\n", p.color(colorBold, "Dataset"), v.Namespace, v.DatasetName)
+	p.printf("  %s: %s\n", p.color(colorBold, "Schema Version"), v.SchemaVersion)
+	if c := v.Collection; c != nil {
+		p.printf("  %s: %s\n", p.color(colorBold, "Tool Version"), orDash(c.ToolVersion))
+		p.printf("  %s: %s\n", p.color(colorBold, "Kubernetes"), orDash(c.ServerVersion))
+		p.printf("  %s: %s\n", p.color(colorBold, "Kube Context"), orDash(c.KubeContext))
+	}
+	p.println("")
+
+	problems := v.Problems()
+	for _, entry := range problems {
+		p.printf("  %s %-9s %s\n", p.formatStatusIcon(false), entry.Status, entry.Path)
+	}
+	if len(problems) == 0 {
+		p.printf("  %s\n", p.color(colorGreen, fmt.Sprintf("✓ all %d entries match their checksums", len(v.Entries))))
+		return
+	}
+	p.printf("\n  %s\n", p.color(colorRed, fmt.Sprintf("%d of %d entries failed verification", len(problems), len(v.Entries))))
+}
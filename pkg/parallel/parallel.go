@@ -0,0 +1,65 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package parallel runs independent API calls concurrently with a bounded number of workers.
+package parallel
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultLimit is the number of concurrent API calls used when no limit is configured
+const DefaultLimit = 8
+
+// ForEach calls fn for every index in [0, n) with at most limit calls running at once,
+// and returns when all started calls have returned. Once ctx is done no further calls
+// are started. A limit below 1 uses DefaultLimit.
+func ForEach(ctx context.Context, n, limit int, fn func(ctx context.Context, i int)) {
+	if limit < 1 {
+		limit = DefaultLimit
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, limit)
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		// A free worker and a done context may be ready at the same time
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(ctx, i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// Run calls every function concurrently, at most limit at a time, and waits for them to return
+func Run(ctx context.Context, limit int, fns ...func(ctx context.Context)) {
+	ForEach(ctx, len(fns), limit, func(ctx context.Context, i int) {
+		fns[i](ctx)
+	})
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parallel
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachLimit(t *testing.T) {
+	var running, peak, calls atomic.Int32
+	ForEach(context.Background(), 20, 3, func(ctx context.Context, i int) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		calls.Add(1)
+	})
+
+	if calls.Load() != 20 {
+		t.Errorf("calls = %d, want 20", calls.Load())
+	}
+	if peak.Load() > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", peak.Load())
+	}
+}
+
+func TestForEachStopsWhenDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	ForEach(ctx, 100, 1, func(ctx context.Context, i int) {
+		if calls.Add(1) == 5 {
+			cancel()
+		}
+	})
+
+	if got := calls.Load(); got != 5 {
+		t.Errorf("calls = %d, want 5 before cancellation stops the loop", got)
+	}
+}
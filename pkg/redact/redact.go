@@ -0,0 +1,253 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package redact masks credentials in the CR snapshots, events and logs collected by
+// diagnose, before they reach the terminal, JSON output or an archive. Only the secret
+// value is masked, so the surrounding line keeps its diagnostic value.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+)
+
+// Mask replaces every redacted value
+const Mask = "[REDACTED]"
+
+// RuleUser names the findings of user-supplied patterns in the report
+const RuleUser = "user-pattern"
+
+// sensitiveKey matches the names of fields, options, env vars and parameters holding secrets
+const sensitiveKey = `(?i)(?:pass(?:word|wd)?|secret|token|credential|api[._-]?key|access[._-]?key|secret[._-]?key|private[._-]?key|auth[._-]?key|session[._-]?key)`
+
+var sensitiveKeyPattern = regexp.MustCompile(sensitiveKey)
+
+// referenceKey matches keys that name or locate a secret rather than hold it, e.g. secretName
+var referenceKeyPattern = regexp.MustCompile(`(?i)(?:name|ref|file|path|namespace)$`)
+
+// isSensitiveKey reports whether a key names a secret value
+func isSensitiveKey(key string) bool {
+	return sensitiveKeyPattern.MatchString(key) && !referenceKeyPattern.MatchString(key)
+}
+
+// rule masks a credential shape. The value is the capture group named "value", or the
+// whole match when the pattern has none. A match whose group named "key" is not a
+// sensitive key is left alone.
+type rule struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// builtinRules recognize known credential shapes in free text
+var builtinRules = []rule{
+	{"private-key", regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{"url-password", regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s:/@]+:(?P<value>[^\s@/]+)@`)},
+	{"bearer-token", regexp.MustCompile(`(?i)\bbearer\s+(?P<value>[A-Za-z0-9\-._~+/]+=*)`)},
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)},
+	{"aws-access-key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"aliyun-access-key", regexp.MustCompile(`\bLTAI[0-9A-Za-z]{12,20}\b`)},
+	// key=value, key: value and "key": "value" pairs whose key names a secret. A quoted
+	// value is masked up to the closing quote, so it may contain spaces.
+	{"key-value", regexp.MustCompile(keyValuePrefix + `"(?P<value>[^"\n]+)"`)},
+	{"key-value", regexp.MustCompile(keyValuePrefix + `'(?P<value>[^'\n]+)'`)},
+	{"key-value", regexp.MustCompile(keyValuePrefix + `"?(?P<value>[^\s"',;&]+)`)},
+}
+
+// keyValuePrefix matches a sensitive key and its separator, up to the value
+const keyValuePrefix = `(?i)(?P<key>[\w.-]*` + sensitiveKey + `[\w.-]*)"?\s*[=:]\s*`
+
+// Options configures a Policy
+type Options struct {
+	// Patterns are extra regular expressions of secrets. The group named "value" is masked,
+	// or the whole match when there is none.
+	Patterns []string
+	// Allow are regular expressions of values that are never masked, e.g. public endpoints
+	Allow []string
+}
+
+// Policy is a compiled set of redaction rules. It is safe for concurrent use.
+type Policy struct {
+	rules []rule
+	allow []*regexp.Regexp
+}
+
+// DefaultPolicy returns the policy of the built-in rules
+func DefaultPolicy() *Policy {
+	return &Policy{rules: builtinRules}
+}
+
+// NewPolicy compiles the built-in rules with the user patterns and allowlist
+func NewPolicy(opts Options) (*Policy, error) {
+	p := DefaultPolicy()
+	p.rules = append([]rule(nil), builtinRules...)
+	for _, expr := range opts.Patterns {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", expr, err)
+		}
+		p.rules = append(p.rules, rule{name: RuleUser, pattern: re})
+	}
+	for _, expr := range opts.Allow {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction allowlist pattern %q: %w", expr, err)
+		}
+		p.allow = append(p.allow, re)
+	}
+	return p, nil
+}
+
+// NewRedactor returns a Redactor applying the policy and recording what it masks. A nil
+// policy is the default policy.
+func (p *Policy) NewRedactor() *Redactor {
+	if p == nil {
+		p = DefaultPolicy()
+	}
+	return &Redactor{policy: p, counts: map[finding]int{}}
+}
+
+// Redactor masks values and counts them by location and rule. It is safe for concurrent use.
+type Redactor struct {
+	policy *Policy
+
+	mu     sync.Mutex
+	counts map[finding]int
+}
+
+type finding struct {
+	location string
+	rule     string
+}
+
+// Text masks the secrets in free text such as a log or an event message. location names
+// the text in the report, e.g. "logs/demo-worker-0/worker".
+func (r *Redactor) Text(location, s string) string {
+	for _, rule := range r.policy.rules {
+		s = r.apply(location, rule, s)
+	}
+	return s
+}
+
+// Object masks, in place, the values of the sensitive fields of an object such as a
+// Dataset or Runtime, then the secrets found in its other string values
+func (r *Redactor) Object(location string, obj map[string]interface{}) {
+	r.walk(location, "", obj)
+}
+
+// Report returns the counts of masked values, sorted by location and rule. It never
+// contains the values themselves.
+func (r *Redactor) Report() *types.RedactionReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report := &types.RedactionReport{}
+	for f, n := range r.counts {
+		report.Findings = append(report.Findings, types.RedactionFinding{Location: f.location, Rule: f.rule, Count: n})
+		report.Total += n
+	}
+	sort.Slice(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.Location != b.Location {
+			return a.Location < b.Location
+		}
+		return a.Rule < b.Rule
+	})
+	return report
+}
+
+func (r *Redactor) apply(location string, rule rule, s string) string {
+	matches := rule.pattern.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return s
+	}
+	group := rule.pattern.SubexpIndex("value")
+	keyGroup := rule.pattern.SubexpIndex("key")
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if group > 0 {
+			start, end = m[2*group], m[2*group+1]
+		}
+		if start < 0 || !r.maskable(s[start:end]) {
+			continue
+		}
+		if keyGroup > 0 && !isSensitiveKey(s[m[2*keyGroup]:m[2*keyGroup+1]]) {
+			continue
+		}
+		sb.WriteString(s[last:start])
+		sb.WriteString(Mask)
+		last = end
+		r.count(location, rule.name)
+	}
+	sb.WriteString(s[last:])
+	return sb.String()
+}
+
+// maskable reports whether a value is neither masked already nor allowed
+func (r *Redactor) maskable(value string) bool {
+	if value == "" || strings.Contains(value, Mask) {
+		return false
+	}
+	for _, allow := range r.policy.allow {
+		if allow.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Redactor) count(location, rule string) {
+	r.mu.Lock()
+	r.counts[finding{location, rule}]++
+	r.mu.Unlock()
+}
+
+// walk masks the string values of sensitive keys, and of name/value pairs with a sensitive
+// name such as env vars and encryptOptions, then scans the remaining strings as text
+func (r *Redactor) walk(location, key string, v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		sensitiveName := false
+		if name, ok := v["name"].(string); ok {
+			sensitiveName = isSensitiveKey(name)
+		}
+		for k, child := range v {
+			if s, ok := child.(string); ok && (isSensitiveKey(k) || (k == "value" && sensitiveName)) {
+				if r.maskable(s) {
+					v[k] = Mask
+					r.count(location, "sensitive-field")
+				}
+				continue
+			}
+			v[k] = r.walk(location, k, child)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = r.walk(location, key, v[i])
+		}
+		return v
+	case string:
+		return r.Text(location, v)
+	}
+	return v
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"github.com/mrhapile/kubectl-fluid-inspect/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Result masks, in place, the secrets of a diagnosis: its CR snapshots and manifests,
+// events, the mount events of its application pods, its logs and the evidence of its
+// hints. It leaves result.Redaction to the caller.
+func (r *Redactor) Result(result *types.DiagnosticResult) {
+	result.DatasetYAML = r.YAML("dataset.yaml", result.DatasetYAML)
+	result.RuntimeYAML = r.YAML("runtime.yaml", result.RuntimeYAML)
+	for i := range result.Manifests {
+		m := &result.Manifests[i]
+		m.YAML = r.YAML(m.Path(), m.YAML)
+	}
+
+	for i := range result.Events {
+		result.Events[i].Message = r.Text("events", result.Events[i].Message)
+	}
+	for i := range result.Resources.Apps {
+		app := &result.Resources.Apps[i]
+		for j := range app.MountEvents {
+			app.MountEvents[j].Message = r.Text("apps/"+app.Name, app.MountEvents[j].Message)
+		}
+	}
+
+	logs := &result.Logs
+	if logs.Master != nil {
+		r.logEntry(logs.Master)
+	}
+	for _, entries := range [][]types.LogEntry{logs.MasterExtra, logs.Workers, logs.Fuse, logs.ControlPlane} {
+		for i := range entries {
+			r.logEntry(&entries[i])
+		}
+	}
+
+	for i := range result.FailureHints {
+		result.FailureHints[i].Evidence = r.Text("failure-hints", result.FailureHints[i].Evidence)
+	}
+}
+
+// YAML masks the secrets of an object serialized as YAML. The sensitive fields are found
+// structurally; a document that does not parse is masked as text.
+func (r *Redactor) YAML(location, doc string) string {
+	if doc == "" {
+		return doc
+	}
+	obj := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+		return r.Text(location, doc)
+	}
+
+	before := r.total()
+	r.Object(location, obj)
+	if r.total() == before {
+		// Nothing was masked: keep the document as it was written
+		return doc
+	}
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return r.Text(location, doc)
+	}
+	return string(out)
+}
+
+func (r *Redactor) logEntry(entry *types.LogEntry) {
+	entry.Logs = r.Text("logs/"+entry.PodName+"/"+entry.ContainerName, entry.Logs)
+}
+
+func (r *Redactor) total() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	total := 0
+	for _, n := range r.counts {
+		total += n
+	}
+	return total
+}
+
+// Merge adds up reports, e.g. of the collection and of a later pass over an archive.
+// Nil reports are skipped.
+func Merge(reports ...*types.RedactionReport) *types.RedactionReport {
+	counts := map[finding]int{}
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+		for _, f := range report.Findings {
+			counts[finding{f.Location, f.Rule}] += f.Count
+		}
+	}
+	merged := &Redactor{counts: counts}
+	return merged.Report()
+}
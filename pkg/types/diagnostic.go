@@ -17,6 +17,8 @@ limitations under the License.
 package types
 
 import (
+	"path"
+	"strings"
 	"time"
 )
 
@@ -32,6 +34,9 @@ type DiagnosticResult struct {
 	RuntimeYAML string `json:"runtimeYaml,omitempty"`
 	RuntimeType string `json:"runtimeType,omitempty"`
 
+	// Cache statistics derived from the CR snapshots
+	CacheStatus *CacheStatus `json:"cacheStatus,omitempty"`
+
 	// Events
 	Events []EventInfo `json:"events"`
 
@@ -44,6 +49,123 @@ type DiagnosticResult struct {
 	// Analysis (for AI integration)
 	FailureHints []FailureHint `json:"failureHints,omitempty"`
 	HealthStatus HealthStatus  `json:"healthStatus"`
+
+	// How each collection step ran
+	CollectionSteps []CollectionStep `json:"collectionSteps,omitempty"`
+
+	// The tool, cluster and settings the diagnosis was collected with
+	Collection *CollectionInfo `json:"collection,omitempty"`
+
+	// The access the current user has to what the collection steps read
+	Permissions *PermissionReport `json:"permissions,omitempty"`
+
+	// What was masked in the collected data
+	Redaction *RedactionReport `json:"redaction,omitempty"`
+
+	// Full YAML of the objects backing the Dataset, collected for archives
+	Manifests []Manifest `json:"manifests,omitempty"`
+}
+
+// Manifest is the cleaned YAML of an object backing a Dataset, such as a workload, pod,
+// PVC or node
+type Manifest struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	YAML      string `json:"yaml"`
+}
+
+// Path returns the path of the manifest in an archive, e.g.
+// manifests/statefulsets/default/demo-master.yaml, or manifests/nodes/node-1.yaml for
+// cluster-scoped objects
+func (m *Manifest) Path() string {
+	return path.Join("manifests", strings.ToLower(m.Kind)+"s", m.Namespace, m.Name+".yaml")
+}
+
+// RedactionReport counts the values masked in a diagnosis, never the values themselves
+type RedactionReport struct {
+	Total    int                `json:"total"`
+	Findings []RedactionFinding `json:"findings,omitempty"`
+}
+
+// RedactionFinding counts the values a redaction rule masked in one location
+type RedactionFinding struct {
+	Location string `json:"location"` // e.g. dataset.yaml, events, logs/demo-worker-0/worker
+	Rule     string `json:"rule"`
+	Count    int    `json:"count"`
+}
+
+// StepStatus is the outcome of a collection step
+type StepStatus string
+
+const (
+	StepStatusComplete StepStatus = "Complete"
+	StepStatusPartial  StepStatus = "Partial" // The step timed out and collected only part of its data
+	StepStatusFailed   StepStatus = "Failed"
+	StepStatusSkipped  StepStatus = "Skipped" // A permission the step requires is denied
+)
+
+// CollectionStep records the outcome of one collection step of a diagnosis
+type CollectionStep struct {
+	Name     string     `json:"name"`
+	Status   StepStatus `json:"status"`
+	Duration string     `json:"duration"`
+	Error    string     `json:"error,omitempty"`
+	// MissingPermissions are the denied permissions the step uses, e.g. "get pods/log -n default"
+	MissingPermissions []string `json:"missingPermissions,omitempty"`
+}
+
+// CollectionInfo describes where and how a diagnosis was collected
+type CollectionInfo struct {
+	// ToolVersion is the version of kubectl-fluid that collected the diagnosis
+	ToolVersion string `json:"toolVersion,omitempty"`
+	// ServerVersion is the Kubernetes version of the cluster, e.g. v1.30.2
+	ServerVersion string `json:"serverVersion,omitempty"`
+	// KubeContext is the kubeconfig context in use, empty in-cluster
+	KubeContext string            `json:"kubeContext,omitempty"`
+	Options     CollectionOptions `json:"options"`
+}
+
+// CollectionOptions are the settings a diagnosis was collected with
+type CollectionOptions struct {
+	StepTimeout    string         `json:"stepTimeout"`
+	FluidNamespace string         `json:"fluidNamespace"`
+	TailLines      int64          `json:"tailLines"`
+	Since          string         `json:"since,omitempty"`
+	LimitBytes     int64          `json:"limitBytes,omitempty"`
+	AllPods        bool           `json:"allPods,omitempty"`
+	MaxPods        map[string]int `json:"maxPods,omitempty"`
+	PreferNodes    []string       `json:"preferNodes,omitempty"`
+	// Manifests are the categories of the collected object manifests
+	Manifests []string `json:"manifests,omitempty"`
+}
+
+// PermissionReport is the outcome of reviewing the permissions a diagnosis uses
+type PermissionReport struct {
+	Checks []PermissionCheck `json:"checks,omitempty"`
+	// Error is set when the permissions could not be reviewed
+	Error string `json:"error,omitempty"`
+}
+
+// PermissionCheck is the outcome of reviewing one permission
+type PermissionCheck struct {
+	Permission string `json:"permission"` // e.g. "get pods/log -n default"
+	Allowed    bool   `json:"allowed"`
+	// Steps are the collection steps using the permission
+	Steps []string `json:"steps"`
+	// RequiredBy are the steps that collect nothing without the permission, and are skipped
+	RequiredBy []string `json:"requiredBy,omitempty"`
+}
+
+// Denied returns the checks of the denied permissions
+func (r *PermissionReport) Denied() []PermissionCheck {
+	var denied []PermissionCheck
+	for _, check := range r.Checks {
+		if !check.Allowed {
+			denied = append(denied, check)
+		}
+	}
+	return denied
 }
 
 // EventInfo contains Kubernetes event information
@@ -66,12 +188,96 @@ type DiagnosticResources struct {
 	Fuse    *PodGroupStatus `json:"fuse,omitempty"`
 	PVC     *PVCDiagnostic  `json:"pvc,omitempty"`
 	PV      *PVDiagnostic   `json:"pv,omitempty"`
+	// Discovered lists every object found for the Dataset's Runtime
+	Discovered []ResourceRef `json:"discovered,omitempty"`
+	// Apps lists the application pods mounting the Dataset's PVC
+	Apps []AppPodStatus `json:"apps,omitempty"`
+	// Nodes lists the nodes running the Dataset's pods, or that could run its unscheduled pods
+	Nodes []NodeDiagnostic `json:"nodes,omitempty"`
+	// ControlPlane is the health of the Fluid controllers, webhook and CSI plugin
+	ControlPlane *ControlPlaneStatus `json:"controlPlane,omitempty"`
+}
+
+// Roles of the Fluid control-plane components
+const (
+	ControlPlaneDatasetController = "dataset-controller"
+	ControlPlaneRuntimeController = "runtime-controller"
+	ControlPlaneWebhook           = "webhook"
+	ControlPlaneCSINodePlugin     = "csi-nodeplugin"
+)
+
+// ControlPlaneStatus describes the Fluid control plane serving a Dataset
+type ControlPlaneStatus struct {
+	Namespace  string                  `json:"namespace"`
+	Components []ControlPlaneComponent `json:"components,omitempty"`
+	CSIDriver  *CSIDriverStatus        `json:"csiDriver,omitempty"`
+	// CSINodes reports the CSI nodeplugin on each node running an application pod
+	CSINodes []CSINodeStatus `json:"csiNodes,omitempty"`
+}
+
+// ControlPlaneComponent is a Fluid controller, webhook or CSI plugin workload
+type ControlPlaneComponent struct {
+	Role string `json:"role"` // dataset-controller, runtime-controller, webhook, csi-nodeplugin
+	// Relevant is unset on runtime controllers of other runtime types than the Dataset's
+	Relevant bool `json:"relevant"`
+	// Restarts is the total restart count of the component's pods
+	Restarts int32 `json:"restarts"`
+	PodGroupStatus
+}
+
+// CSIDriverStatus describes the CSIDriver object of Fluid
+type CSIDriverStatus struct {
+	Name           string `json:"name"`
+	Found          bool   `json:"found"`
+	AttachRequired bool   `json:"attachRequired,omitempty"`
+	PodInfoOnMount bool   `json:"podInfoOnMount,omitempty"`
+}
+
+// CSINodeStatus reports the CSI nodeplugin pod on a node; Pod is empty if there is none
+type CSINodeStatus struct {
+	Node  string `json:"node"`
+	Pod   string `json:"pod,omitempty"`
+	Ready bool   `json:"ready"`
+}
+
+// NodeDiagnostic describes a node and whether the runtime's fuse and worker pods can run on it
+type NodeDiagnostic struct {
+	Name          string   `json:"name"`
+	Ready         bool     `json:"ready"`
+	Unschedulable bool     `json:"unschedulable,omitempty"` // The node is cordoned
+	Pressure      []string `json:"pressure,omitempty"`      // MemoryPressure, DiskPressure, PIDPressure
+	// CPU and Memory are the resources requested by the node's pods over its allocatable resources
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+	// RuntimePods and AppPods are the Dataset's runtime and application pods on the node
+	RuntimePods []string       `json:"runtimePods,omitempty"`
+	AppPods     []string       `json:"appPods,omitempty"`
+	Fuse        *NodePlacement `json:"fuse,omitempty"`
+	Worker      *NodePlacement `json:"worker,omitempty"`
+}
+
+// NodePlacement explains whether the pods of a runtime component can run on a node
+type NodePlacement struct {
+	Running bool     `json:"running"` // A pod of the component runs on the node
+	CanRun  bool     `json:"canRun"`
+	Reasons []string `json:"reasons,omitempty"` // Why the pods cannot run on the node
+}
+
+// AppPodStatus is the status of an application pod mounting the Dataset's PVC
+type AppPodStatus struct {
+	PodStatus
+	// Volume is the name of the pod volume referencing the PVC
+	Volume string `json:"volume"`
+	// FuseReady is set when a Ready fuse pod runs on the pod's node
+	FuseReady bool `json:"fuseReady"`
+	// MountEvents are the pod's volume mount and attach failures
+	MountEvents []EventInfo `json:"mountEvents,omitempty"`
 }
 
 // PodGroupStatus contains status for a group of pods
 type PodGroupStatus struct {
 	Name        string      `json:"name"`
-	Kind        string      `json:"kind"` // StatefulSet, DaemonSet
+	Kind        string      `json:"kind"` // StatefulSet, DaemonSet, Deployment
 	Desired     int32       `json:"desired"`
 	Ready       int32       `json:"ready"`
 	Available   int32       `json:"available"`
@@ -81,17 +287,43 @@ type PodGroupStatus struct {
 	FailingPods []PodStatus `json:"failingPods,omitempty"`
 }
 
-// PodStatus contains individual pod status
+// PodStatus contains individual pod status. RestartCount is the highest restart count
+// of its containers; ContainerState, Reason and Message are those of the container
+// keeping the pod from being ready, or of its first container.
 type PodStatus struct {
-	Name           string   `json:"name"`
-	Phase          string   `json:"phase"`
-	Ready          bool     `json:"ready"`
-	RestartCount   int32    `json:"restartCount"`
-	Reason         string   `json:"reason,omitempty"`
-	Message        string   `json:"message,omitempty"`
-	NodeName       string   `json:"nodeName,omitempty"`
-	ContainerState string   `json:"containerState,omitempty"`
-	Conditions     []string `json:"conditions,omitempty"`
+	Name           string            `json:"name"`
+	Namespace      string            `json:"namespace,omitempty"`
+	Phase          string            `json:"phase"`
+	Ready          bool              `json:"ready"`
+	RestartCount   int32             `json:"restartCount"`
+	Reason         string            `json:"reason,omitempty"`
+	Message        string            `json:"message,omitempty"`
+	NodeName       string            `json:"nodeName,omitempty"`
+	ContainerState string            `json:"containerState,omitempty"`
+	Conditions     []string          `json:"conditions,omitempty"`
+	Containers     []ContainerStatus `json:"containers,omitempty"`
+}
+
+// Container types
+const (
+	ContainerTypeInit    = "init"    // Init container, run to completion before the others start
+	ContainerTypeSidecar = "sidecar" // Init container with restartPolicy Always, running alongside the others
+	ContainerTypeMain    = "main"    // Regular container
+)
+
+// ContainerStatus is the state of a single container of a pod
+type ContainerStatus struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"` // init, sidecar, main
+	Ready        bool   `json:"ready"`
+	RestartCount int32  `json:"restartCount"`
+	State        string `json:"state,omitempty"` // Waiting, Running, Terminated
+	Reason       string `json:"reason,omitempty"`
+	Message      string `json:"message,omitempty"`
+	ExitCode     int32  `json:"exitCode,omitempty"`
+	// LastTerminationReason and LastExitCode describe how the previous instance ended
+	LastTerminationReason string `json:"lastTerminationReason,omitempty"`
+	LastExitCode          int32  `json:"lastExitCode,omitempty"`
 }
 
 // PVCDiagnostic contains PVC diagnostic info
@@ -114,19 +346,40 @@ type PVDiagnostic struct {
 
 // DiagnosticLogs contains collected logs
 type DiagnosticLogs struct {
-	Master  *LogEntry  `json:"master,omitempty"`
-	Workers []LogEntry `json:"workers,omitempty"`
-	Fuse    []LogEntry `json:"fuse,omitempty"`
+	// Master holds the current logs of the master's main container
+	Master *LogEntry `json:"master,omitempty"`
+	// MasterExtra holds the other logs of the master pod: previous instances, init containers and sidecars
+	MasterExtra []LogEntry `json:"masterExtra,omitempty"`
+	Workers     []LogEntry `json:"workers,omitempty"`
+	Fuse        []LogEntry `json:"fuse,omitempty"`
+	// ControlPlane holds the lines of the Fluid control-plane logs mentioning the Dataset
+	ControlPlane []LogEntry `json:"controlPlane,omitempty"`
 }
 
 // LogEntry contains log data for a single container
 type LogEntry struct {
 	PodName       string `json:"podName"`
 	ContainerName string `json:"containerName"`
-	Logs          string `json:"logs"`
-	TailLines     int64  `json:"tailLines"`
-	Truncated     bool   `json:"truncated"`
-	Error         string `json:"error,omitempty"`
+	ContainerType string `json:"containerType,omitempty"` // init, sidecar, main
+	// Previous is set for logs of the previous, terminated instance of the container
+	Previous  bool   `json:"previous,omitempty"`
+	Logs      string `json:"logs"`
+	TailLines int64  `json:"tailLines"` // -1 when the whole log was requested
+	// Truncated is set when the log hit the tail or byte limit, so older lines may be missing
+	Truncated bool   `json:"truncated"`
+	Error     string `json:"error,omitempty"`
+	// Filter is the <namespace>/<name> of the Dataset when only the lines about it were
+	// kept: lines naming it as <namespace>/<name>, or naming both as whole words
+	Filter string `json:"filter,omitempty"`
+}
+
+// ContextKey returns the key of the entry in DiagnosticContext.Logs: base, e.g. worker-0,
+// suffixed with .previous for the logs of a previous instance
+func (e *LogEntry) ContextKey(base string) string {
+	if e.Previous {
+		return base + ".previous"
+	}
+	return base
 }
 
 // FailureHint contains a detected issue with suggested action
@@ -136,6 +389,8 @@ type FailureHint struct {
 	Issue      string `json:"issue"`
 	Suggestion string `json:"suggestion"`
 	Evidence   string `json:"evidence,omitempty"`
+	RuleID     string `json:"ruleId,omitempty"` // ID of the rule that produced the hint
+	DocURL     string `json:"docUrl,omitempty"`
 }
 
 // HealthStatus represents overall health
@@ -155,9 +410,14 @@ type DiagnosticContext struct {
 	Summary      ContextSummary    `json:"summary"`
 	DatasetYAML  string            `json:"datasetYaml"`
 	RuntimeYAML  string            `json:"runtimeYaml,omitempty"`
+	CacheStatus  *CacheStatus      `json:"cacheStatus,omitempty"`
 	Events       []EventInfo       `json:"events"`
 	Logs         map[string]string `json:"logs"`
 	FailureHints []FailureHint     `json:"failureHints"`
+	Apps         []AppPodStatus    `json:"apps,omitempty"`
+
+	// Collection steps that did not complete or were denied permissions, if any
+	CollectionSteps []CollectionStep `json:"collectionSteps,omitempty"`
 
 	// Metadata
 	CollectedAt time.Time `json:"collectedAt"`
@@ -166,15 +426,17 @@ type DiagnosticContext struct {
 
 // ContextSummary provides a quick overview for AI
 type ContextSummary struct {
-	DatasetName  string       `json:"datasetName"`
-	Namespace    string       `json:"namespace"`
-	DatasetPhase string       `json:"datasetPhase"`
-	RuntimeType  string       `json:"runtimeType,omitempty"`
-	HealthStatus HealthStatus `json:"healthStatus"`
-	MasterReady  string       `json:"masterReady"`
-	WorkersReady string       `json:"workersReady"`
-	FuseReady    string       `json:"fuseReady"`
-	PVCStatus    string       `json:"pvcStatus"`
-	ErrorCount   int          `json:"errorCount"`
-	WarningCount int          `json:"warningCount"`
+	DatasetName      string       `json:"datasetName"`
+	Namespace        string       `json:"namespace"`
+	DatasetPhase     string       `json:"datasetPhase"`
+	RuntimeType      string       `json:"runtimeType,omitempty"`
+	HealthStatus     HealthStatus `json:"healthStatus"`
+	MasterReady      string       `json:"masterReady"`
+	WorkersReady     string       `json:"workersReady"`
+	FuseReady        string       `json:"fuseReady"`
+	PVCStatus        string       `json:"pvcStatus"`
+	AppsReady        string       `json:"appsReady,omitempty"`
+	ErrorCount       int          `json:"errorCount"`       // critical failure hints
+	WarningCount     int          `json:"warningCount"`     // Warning events
+	WarningHintCount int          `json:"warningHintCount"` // warning failure hints
 }
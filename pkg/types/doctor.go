@@ -0,0 +1,56 @@
+/*
+Copyright 2026 kubectl-fluid-inspect Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import "time"
+
+// DoctorStatus is the outcome of an installation check
+type DoctorStatus string
+
+const (
+	DoctorPass DoctorStatus = "pass"
+	DoctorWarn DoctorStatus = "warn"
+	DoctorFail DoctorStatus = "fail"
+)
+
+// Names of the installation checks
+const (
+	DoctorCheckCRDs        = "crds"
+	DoctorCheckControllers = "controllers"
+	DoctorCheckWebhook     = "webhook"
+	DoctorCheckCSI         = "csi"
+	DoctorCheckRBAC        = "rbac"
+)
+
+// DoctorCheck is one item of the installation checklist. Its status follows from the
+// most severe of its hints: critical fails the check and warning warns.
+type DoctorCheck struct {
+	Name    string        `json:"name"`
+	Title   string        `json:"title"`
+	Status  DoctorStatus  `json:"status"`
+	Details []string      `json:"details,omitempty"`
+	Hints   []FailureHint `json:"hints,omitempty"`
+}
+
+// DoctorResult is the checklist of a Fluid installation
+type DoctorResult struct {
+	FluidNamespace string        `json:"fluidNamespace"`
+	ServerVersion  string        `json:"serverVersion,omitempty"`
+	CheckedAt      time.Time     `json:"checkedAt"`
+	Status         DoctorStatus  `json:"status"`
+	Checks         []DoctorCheck `json:"checks"`
+}
@@ -16,107 +16,138 @@ limitations under the License.
 
 package types
 
-// InspectionResult contains the complete inspection result for a Dataset
+// InspectionResult contains the complete inspection result for a Dataset.
+// Its JSON form is the output of 'inspect dataset -o json' and is documented in
+// docs/inspect-output.md; fields may be added but are never renamed or removed.
 type InspectionResult struct {
-	Dataset     DatasetInfo
-	Runtime     *RuntimeInfo
-	Resources   ResourceStatus
-	Conditions  []ConditionInfo
-	CacheStatus *CacheStatus
+	Dataset   DatasetInfo    `json:"dataset"`
+	Runtime   *RuntimeInfo   `json:"runtime,omitempty"`
+	Resources ResourceStatus `json:"resources"`
+	// Conditions are the Runtime conditions; Dataset conditions are in Dataset.Conditions
+	Conditions  []ConditionInfo `json:"conditions,omitempty"`
+	CacheStatus *CacheStatus    `json:"cacheStatus,omitempty"`
+	// Warnings report lookups that failed, leaving parts of the result missing
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// InspectionList is the output of 'inspect datasets -o json'
+type InspectionList struct {
+	Items []*InspectionResult `json:"items"`
 }
 
 // DatasetInfo contains Dataset information
 type DatasetInfo struct {
-	Name        string
-	Namespace   string
-	Phase       string
-	UfsTotal    string
-	FileNum     string
-	Runtimes    []RuntimeRef
-	Conditions  []ConditionInfo
-	MountPoints []string
+	Name        string          `json:"name"`
+	Namespace   string          `json:"namespace"`
+	Phase       string          `json:"phase"`
+	UfsTotal    string          `json:"ufsTotal,omitempty"`
+	FileNum     string          `json:"fileNum,omitempty"`
+	Runtimes    []RuntimeRef    `json:"runtimes,omitempty"`
+	Conditions  []ConditionInfo `json:"conditions,omitempty"`
+	MountPoints []string        `json:"mountPoints,omitempty"`
 }
 
 // RuntimeRef contains a reference to a Runtime
 type RuntimeRef struct {
-	Name      string
-	Namespace string
-	Type      string
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Type      string `json:"type"`
 }
 
 // RuntimeInfo contains Runtime status information
 type RuntimeInfo struct {
-	Name      string
-	Namespace string
-	Type      string // alluxio, jindo, juicefs, etc.
-	Master    ComponentStatus
-	Worker    ComponentStatus
-	Fuse      ComponentStatus
+	Name      string          `json:"name"`
+	Namespace string          `json:"namespace"`
+	Type      string          `json:"type"` // alluxio, jindo, juicefs, etc.
+	Master    ComponentStatus `json:"master"`
+	Worker    ComponentStatus `json:"worker"`
+	Fuse      ComponentStatus `json:"fuse"`
 }
 
 // ComponentStatus contains status for a single component (master/worker/fuse)
 type ComponentStatus struct {
-	Phase            string
-	Reason           string
-	DesiredScheduled int32
-	CurrentScheduled int32
-	Ready            int32
-	Available        int32
-	Unavailable      int32
+	Phase            string `json:"phase,omitempty"`
+	Reason           string `json:"reason,omitempty"`
+	DesiredScheduled int32  `json:"desiredScheduled"`
+	CurrentScheduled int32  `json:"currentScheduled"`
+	Ready            int32  `json:"ready"`
+	Available        int32  `json:"available"`
+	Unavailable      int32  `json:"unavailable"`
 }
 
 // ResourceStatus contains Kubernetes resource status
 type ResourceStatus struct {
-	MasterStatefulSet *StatefulSetStatus
-	WorkerStatefulSet *StatefulSetStatus
-	FuseDaemonSet     *DaemonSetStatus
-	PVC               *PVCStatus
+	MasterStatefulSet *StatefulSetStatus `json:"masterStatefulSet,omitempty"`
+	WorkerStatefulSet *StatefulSetStatus `json:"workerStatefulSet,omitempty"`
+	FuseDaemonSet     *DaemonSetStatus   `json:"fuseDaemonSet,omitempty"`
+	PVC               *PVCStatus         `json:"pvc,omitempty"`
+	// Discovered lists every object found for the Dataset's Runtime
+	Discovered []ResourceRef `json:"discovered,omitempty"`
+}
+
+// Ways a discovered object was found
+const (
+	DiscoveredByOwner = "owner" // Owned by the Runtime CR, directly or through a workload
+	DiscoveredByLabel = "label" // Carries the fluid.io/dataset and fluid.io/dataset-namespace labels
+	DiscoveredByName  = "name"  // Matches the <dataset>-<component> and release=<dataset> conventions
+)
+
+// ResourceRef identifies a Kubernetes object discovered for a Dataset
+type ResourceRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	UID       string `json:"uid,omitempty"`
+	Via       string `json:"via"`
 }
 
-// StatefulSetStatus contains StatefulSet status
+// StatefulSetStatus contains StatefulSet status. The master and worker may also
+// be Deployments, as reported by Kind.
 type StatefulSetStatus struct {
-	Name            string
-	Replicas        int32
-	ReadyReplicas   int32
-	CurrentReplicas int32
-	Healthy         bool
+	Kind            string `json:"kind,omitempty"`
+	Name            string `json:"name"`
+	Replicas        int32  `json:"replicas"`
+	ReadyReplicas   int32  `json:"readyReplicas"`
+	CurrentReplicas int32  `json:"currentReplicas"`
+	Healthy         bool   `json:"healthy"`
 }
 
 // DaemonSetStatus contains DaemonSet status
 type DaemonSetStatus struct {
-	Name             string
-	DesiredScheduled int32
-	CurrentScheduled int32
-	Ready            int32
-	Available        int32
-	Unavailable      int32
-	Healthy          bool
+	Name             string `json:"name"`
+	DesiredScheduled int32  `json:"desiredScheduled"`
+	CurrentScheduled int32  `json:"currentScheduled"`
+	Ready            int32  `json:"ready"`
+	Available        int32  `json:"available"`
+	Unavailable      int32  `json:"unavailable"`
+	Healthy          bool   `json:"healthy"`
 }
 
 // PVCStatus contains PersistentVolumeClaim status
 type PVCStatus struct {
-	Name       string
-	Phase      string
-	VolumeName string
-	Capacity   string
+	Name       string `json:"name"`
+	Phase      string `json:"phase"`
+	VolumeName string `json:"volumeName,omitempty"`
+	Capacity   string `json:"capacity,omitempty"`
 }
 
 // ConditionInfo contains condition information
 type ConditionInfo struct {
-	Type    string
-	Status  string
-	Reason  string
-	Message string
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
 }
 
 // CacheStatus contains cache statistics
 type CacheStatus struct {
-	CacheCapacity    string
-	Cached           string
-	CachedPercentage string
-	Cacheable        string
-	LowWaterMark     string
-	HighWaterMark    string
+	CacheCapacity    string `json:"cacheCapacity,omitempty"`
+	Cached           string `json:"cached,omitempty"`
+	CachedPercentage string `json:"cachedPercentage,omitempty"`
+	Cacheable        string `json:"cacheable,omitempty"`
+	LowWaterMark     string `json:"lowWaterMark,omitempty"`
+	HighWaterMark    string `json:"highWaterMark,omitempty"`
+	CacheHitRatio    string `json:"cacheHitRatio,omitempty"`
 }
 
 // RuntimeTypes defines supported runtime types